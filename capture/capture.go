@@ -0,0 +1,60 @@
+// Package capture records normalised raw ingest traffic to rotating on-disk
+// files and reads those files back so the traffic can be replayed through
+// the decoders.
+package capture
+
+// Record is a single raw submission (one /raw POST or one gRPC
+// SubmitRawProto call) after provider normalisation. It is written as one
+// JSON line; byte slices are base64 encoded by encoding/json.
+type Record struct {
+	ReceivedMs  int64    `json:"received_ms"`
+	Source      string   `json:"source"`
+	Uuid        string   `json:"uuid,omitempty"`
+	Account     string   `json:"account,omitempty"`
+	Level       int      `json:"level"`
+	ScanContext string   `json:"scan_context,omitempty"`
+	Token       string   `json:"token,omitempty"` // raw token name, never the secret
+	Lat         float64  `json:"lat,omitempty"`
+	Lon         float64  `json:"lon,omitempty"`
+	TimestampMs int64    `json:"timestamp_ms"`
+	Protos      []Proto  `json:"protos,omitempty"`
+	Nebula      []Nebula `json:"nebula,omitempty"`
+	Push        []Push   `json:"push,omitempty"`
+}
+
+type Proto struct {
+	Method  int    `json:"method"`
+	Data    []byte `json:"data"`
+	Request []byte `json:"request,omitempty"`
+	HaveAr  *bool  `json:"have_ar,omitempty"`
+}
+
+type Nebula struct {
+	Endpoint  string            `json:"endpoint"`
+	Data      []byte            `json:"data"`
+	Request   []byte            `json:"request,omitempty"`
+	BattleId  string            `json:"battle_id,omitempty"`
+	Invasion  *InvasionContext  `json:"invasion,omitempty"`
+	Raid      *RaidContext      `json:"raid,omitempty"`
+	MaxBattle *MaxBattleContext `json:"max_battle,omitempty"`
+}
+
+type InvasionContext struct {
+	FortId     string `json:"fort_id"`
+	IncidentId string `json:"incident_id"`
+}
+
+type RaidContext struct {
+	FortId   string `json:"fort_id"`
+	RaidSeed int64  `json:"raid_seed,string,omitempty"`
+}
+
+type MaxBattleContext struct {
+	StationId       string `json:"station_id"`
+	BreadBattleSeed int64  `json:"bread_battle_seed,string,omitempty"`
+}
+
+type Push struct {
+	MessageType string `json:"message_type"`
+	Payload     []byte `json:"payload"`
+}
@@ -0,0 +1,126 @@
+package capture
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestWriterReplayRoundTrip(t *testing.T) {
+	dir := t.TempDir()
+	haveAr := true
+
+	w := NewWriter(dir, 10, 1, false)
+	want := []*Record{
+		{
+			ReceivedMs:  1000,
+			Source:      "http",
+			Uuid:        "device-1",
+			Account:     "acc",
+			Level:       35,
+			TimestampMs: 999,
+			Protos:      []Proto{{Method: 106, Data: []byte{1, 2, 3}, HaveAr: &haveAr}},
+		},
+		{
+			ReceivedMs: 2000,
+			Source:     "grpc",
+			Level:      40,
+			Nebula: []Nebula{{
+				Endpoint: "get-state",
+				Data:     []byte{4},
+				Invasion: &InvasionContext{FortId: "F", IncidentId: "I"},
+			}},
+			Push: []Push{{MessageType: "raid_lobby_player_count", Payload: []byte{5, 6}}},
+		},
+	}
+	for _, rec := range want {
+		w.Write(rec)
+	}
+	w.Close()
+
+	files, err := ResolveFiles(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var got []*Record
+	count, err := Replay(context.Background(), files, 0, func(rec *Record) {
+		got = append(got, rec)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if count != len(want) {
+		t.Fatalf("replayed %d records, want %d", count, len(want))
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("round trip mismatch:\ngot  %+v\nwant %+v", got, want)
+	}
+}
+
+func TestResolveFilesOrdersByFirstRecord(t *testing.T) {
+	dir := t.TempDir()
+	// The live lumberjack file holds the newest records but sorts first by name.
+	writeLines(t, filepath.Join(dir, "raw-capture.jsonl"), `{"received_ms":300}`)
+	writeLines(t, filepath.Join(dir, "raw-capture-2026-01-01T00-00-00.000.jsonl"), `{"received_ms":100}`)
+	writeLines(t, filepath.Join(dir, "raw-capture-2026-01-02T00-00-00.000.jsonl"), `{"received_ms":200}`)
+	writeLines(t, filepath.Join(dir, "notes.txt"), "ignored")
+
+	files, err := ResolveFiles(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{
+		filepath.Join(dir, "raw-capture-2026-01-01T00-00-00.000.jsonl"),
+		filepath.Join(dir, "raw-capture-2026-01-02T00-00-00.000.jsonl"),
+		filepath.Join(dir, "raw-capture.jsonl"),
+	}
+	if !reflect.DeepEqual(files, want) {
+		t.Errorf("got %v, want %v", files, want)
+	}
+}
+
+func TestReplayHonoursSpeed(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "raw-capture.jsonl")
+	writeLines(t, file, `{"received_ms":0}`, `{"received_ms":400}`)
+
+	start := time.Now()
+	if _, err := Replay(context.Background(), []string{file}, 2, func(*Record) {}); err != nil {
+		t.Fatal(err)
+	}
+	// 400ms of original traffic at double speed takes ~200ms.
+	if elapsed := time.Since(start); elapsed < 150*time.Millisecond || elapsed > 2*time.Second {
+		t.Errorf("replay at speed 2 took %s, want ~200ms", elapsed)
+	}
+}
+
+func TestReplayStopsOnCancel(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "raw-capture.jsonl")
+	writeLines(t, file, `{"received_ms":0}`, `{"received_ms":3600000}`)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	count, err := Replay(ctx, []string{file}, 1, func(*Record) { cancel() })
+	if err == nil {
+		t.Fatal("expected replay to be cancelled")
+	}
+	if count != 1 {
+		t.Errorf("replayed %d records before cancel, want 1", count)
+	}
+}
+
+func writeLines(t *testing.T, file string, lines ...string) {
+	t.Helper()
+	var content []byte
+	for _, line := range lines {
+		content = append(content, line...)
+		content = append(content, '\n')
+	}
+	if err := os.WriteFile(file, content, 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
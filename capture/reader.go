@@ -0,0 +1,155 @@
+package capture
+
+import (
+	"bufio"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// maxLineSize bounds a single capture line. /raw accepts bodies of several MB
+// of base64 (tuning.raw_body_limit), so a record can legitimately be large.
+const maxLineSize = 64 * 1048576
+
+var errStopReading = errors.New("stop reading")
+
+// ResolveFiles expands path into the capture files to replay. path may be a
+// single file, a directory (all .jsonl and .jsonl.gz files within it) or a glob
+// pattern. Files are ordered by the first record they contain, so the live
+// file written by lumberjack sorts after its timestamped backups.
+func ResolveFiles(path string) ([]string, error) {
+	var candidates []string
+
+	if info, err := os.Stat(path); err == nil && info.IsDir() {
+		entries, err := os.ReadDir(path)
+		if err != nil {
+			return nil, err
+		}
+		for _, entry := range entries {
+			name := entry.Name()
+			if !entry.IsDir() && (strings.HasSuffix(name, ".jsonl") || strings.HasSuffix(name, ".jsonl.gz")) {
+				candidates = append(candidates, filepath.Join(path, name))
+			}
+		}
+	} else {
+		matches, err := filepath.Glob(path)
+		if err != nil {
+			return nil, err
+		}
+		candidates = matches
+	}
+
+	if len(candidates) == 0 {
+		return nil, fmt.Errorf("no capture files found at %s", path)
+	}
+
+	firstReceived := make(map[string]int64, len(candidates))
+	for _, file := range candidates {
+		received, err := firstRecordTime(file)
+		if err != nil {
+			return nil, fmt.Errorf("reading %s: %w", file, err)
+		}
+		firstReceived[file] = received
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return firstReceived[candidates[i]] < firstReceived[candidates[j]]
+	})
+
+	return candidates, nil
+}
+
+func firstRecordTime(file string) (int64, error) {
+	var received int64
+	err := readFile(file, func(rec *Record) error {
+		received = rec.ReceivedMs
+		return errStopReading
+	})
+	if err != nil && !errors.Is(err, errStopReading) {
+		return 0, err
+	}
+	return received, nil
+}
+
+// Replay reads records from files in order and hands each to fn. With a speed
+// of zero records are replayed as fast as fn accepts them; otherwise the
+// original gaps between records are preserved, divided by speed (so 1 is real
+// time and 10 is ten times faster). Replay stops early if ctx is cancelled.
+// It returns the number of records replayed.
+func Replay(ctx context.Context, files []string, speed float64, fn func(*Record)) (int, error) {
+	count := 0
+	var firstReceived int64
+	var start time.Time
+
+	for _, file := range files {
+		err := readFile(file, func(rec *Record) error {
+			if speed > 0 {
+				if count == 0 {
+					firstReceived = rec.ReceivedMs
+					start = time.Now()
+				}
+				offset := time.Duration(float64(rec.ReceivedMs-firstReceived)/speed) * time.Millisecond
+				if wait := time.Until(start.Add(offset)); wait > 0 {
+					select {
+					case <-ctx.Done():
+						return ctx.Err()
+					case <-time.After(wait):
+					}
+				}
+			}
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			fn(rec)
+			count++
+			return nil
+		})
+		if err != nil {
+			return count, fmt.Errorf("replaying %s: %w", file, err)
+		}
+	}
+	return count, nil
+}
+
+func readFile(file string, fn func(*Record) error) error {
+	f, err := os.Open(file)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	var r io.Reader = f
+	if strings.HasSuffix(file, ".gz") {
+		gz, err := gzip.NewReader(f)
+		if err != nil {
+			return err
+		}
+		defer gz.Close()
+		r = gz
+	}
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 1048576), maxLineSize)
+	line := 0
+	for scanner.Scan() {
+		line++
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		var rec Record
+		if err := json.Unmarshal(scanner.Bytes(), &rec); err != nil {
+			return fmt.Errorf("line %d: %w", line, err)
+		}
+		if err := fn(&rec); err != nil {
+			return err
+		}
+	}
+	return scanner.Err()
+}
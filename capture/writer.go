@@ -0,0 +1,92 @@
+package capture
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+
+	log "github.com/sirupsen/logrus"
+	"gopkg.in/natefinch/lumberjack.v2"
+)
+
+const (
+	captureFileName  = "raw-capture.jsonl"
+	writerQueueDepth = 1000
+)
+
+// Writer appends records to a rotating capture file. Writes happen on a
+// background goroutine so ingest never waits on disk; when the queue is full
+// records are dropped rather than blocking the caller.
+type Writer struct {
+	out     *lumberjack.Logger
+	queue   chan *Record
+	done    chan struct{}
+	mu      sync.RWMutex
+	closed  bool
+	dropped atomic.Int64
+}
+
+// NewWriter starts a capture writer in directory. maxSize is the size in MB
+// at which the file is rotated, maxBackups the number of rotated files kept.
+func NewWriter(directory string, maxSize, maxBackups int, compress bool) *Writer {
+	w := &Writer{
+		out: &lumberjack.Logger{
+			Filename:   filepath.Join(directory, captureFileName),
+			MaxSize:    maxSize,
+			MaxBackups: maxBackups,
+			Compress:   compress,
+		},
+		queue: make(chan *Record, writerQueueDepth),
+		done:  make(chan struct{}),
+	}
+	go w.run()
+	return w
+}
+
+// Write queues a record for writing. The record must not be modified after
+// it has been handed over.
+func (w *Writer) Write(rec *Record) {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	if w.closed {
+		return
+	}
+	select {
+	case w.queue <- rec:
+	default:
+		if dropped := w.dropped.Add(1); dropped%1000 == 1 {
+			log.Warnf("Capture: write queue full, %d records dropped so far", dropped)
+		}
+	}
+}
+
+// Close flushes queued records and closes the current capture file.
+func (w *Writer) Close() {
+	w.mu.Lock()
+	if w.closed {
+		w.mu.Unlock()
+		return
+	}
+	w.closed = true
+	close(w.queue)
+	w.mu.Unlock()
+	<-w.done
+}
+
+func (w *Writer) run() {
+	defer close(w.done)
+	defer w.out.Close()
+
+	for rec := range w.queue {
+		line, err := json.Marshal(rec)
+		if err != nil {
+			log.Errorf("Capture: failed to encode record: %s", err)
+			continue
+		}
+		line = append(line, '\n')
+		if _, err := w.out.Write(line); err != nil {
+			log.Errorf("Capture: failed to write record: %s", err)
+		}
+	}
+}
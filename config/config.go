@@ -7,33 +7,38 @@ import (
 )
 
 type configDefinition struct {
-	Port                    int            `koanf:"port"`
-	GrpcPort                int            `koanf:"grpc_port"`
-	Webhooks                []Webhook      `koanf:"webhooks"`
-	Database                database       `koanf:"database"`
-	Logging                 logging        `koanf:"logging"`
-	Sentry                  sentry         `koanf:"sentry"`
-	Pyroscope               pyroscope      `koanf:"pyroscope"`
-	Prometheus              Prometheus     `koanf:"prometheus"`
-	PokemonMemoryOnly       bool           `koanf:"pokemon_memory_only"`
-	PokemonInternalToDb     bool           `koanf:"pokemon_internal_to_db"`
-	PreserveInMemoryPokemon bool           `koanf:"preserve_pokemon"` // Save/restore pokemon cache on shutdown/startup
-	Preload                 bool           `koanf:"preload"`          // Pre-load forts, stations, spawnpoints into cache on startup
-	FortInMemory            bool           `koanf:"fort_in_memory"`   // Keep forts in memory with rtree for spatial lookups
-	Cleanup                 cleanup        `koanf:"cleanup"`
-	RawBearer               string         `koanf:"raw_bearer"`
-	ApiSecret               string         `koanf:"api_secret"`
-	ApiDocs                 bool           `koanf:"api_docs"` // Serve /docs, /openapi.json and /schemas (no secret required)
-	Pvp                     pvp            `koanf:"pvp"`
-	Koji                    koji           `koanf:"koji"`
-	Tuning                  tuning         `koanf:"tuning"`
-	Weather                 weather        `koanf:"weather"`
-	ScanRules               []scanRule     `koanf:"scan_rules"`
-	StatsIntervals          statsIntervals `koanf:"stats_intervals"`
-}
-
+	Port                    int             `koanf:"port"`
+	GrpcPort                int             `koanf:"grpc_port"`
+	Webhooks                []Webhook       `koanf:"webhooks"`
+	Database                Database        `koanf:"database"`
+	PokemonDatabase         Database        `koanf:"pokemon_database"` // optional separate database for the pokemon table
+	Logging                 logging         `koanf:"logging"`
+	Sentry                  sentry          `koanf:"sentry"`
+	Pyroscope               pyroscope       `koanf:"pyroscope"`
+	Prometheus              Prometheus      `koanf:"prometheus"`
+	PokemonMemoryOnly       bool            `koanf:"pokemon_memory_only"`
+	PokemonInternalToDb     bool            `koanf:"pokemon_internal_to_db"`
+	PreserveInMemoryPokemon bool            `koanf:"preserve_pokemon"` // Save/restore pokemon cache on shutdown/startup
+	Preload                 bool            `koanf:"preload"`          // Pre-load forts, stations, spawnpoints into cache on startup
+	FortInMemory            bool            `koanf:"fort_in_memory"`   // Keep forts in memory with rtree for spatial lookups
+	Cleanup                 cleanup         `koanf:"cleanup"`
+	RawBearer               string          `koanf:"raw_bearer"`
+	RawTokens               []RawToken      `koanf:"raw_tokens"`
+	ApiSecret               string          `koanf:"api_secret"`
+	ApiDocs                 bool            `koanf:"api_docs"` // Serve /docs, /openapi.json and /schemas (no secret required)
+	Pvp                     pvp             `koanf:"pvp"`
+	Koji                    koji            `koanf:"koji"`
+	Tuning                  tuning          `koanf:"tuning"`
+	Weather                 weather         `koanf:"weather"`
+	ScanRules               []scanRule      `koanf:"scan_rules"`
+	StatsIntervals          statsIntervals  `koanf:"stats_intervals"`
+	Capture                 capture         `koanf:"capture"`
+	DeadLetter              deadLetter      `koanf:"dead_letter"`
+	WebhookDelivery         WebhookDelivery `koanf:"webhook_delivery"`
+}
+
+// GetWebhookInterval is the send interval of webhooks without their own `interval`
 func (configDefinition configDefinition) GetWebhookInterval() time.Duration {
-	// not currently configurable.
 	return time.Second
 }
 
@@ -41,6 +46,10 @@ func (configDefinition configDefinition) GetWebhooks() []Webhook {
 	return configDefinition.Webhooks
 }
 
+func (configDefinition configDefinition) GetWebhookDelivery() WebhookDelivery {
+	return configDefinition.WebhookDelivery
+}
+
 func (configDefinition configDefinition) GetPrometheus() Prometheus {
 	return configDefinition.Prometheus
 }
@@ -69,11 +78,57 @@ type Webhook struct {
 	Areas            []string          `koanf:"areas"`
 	ExcludeAreas     []string          `koanf:"exclude_areas"`
 	Headers          []string          `koanf:"headers"`
+	Secret           string            `koanf:"secret"`          // signs each POST in an X-Golbat-Signature header
+	PokemonFilters   []map[string]any  `koanf:"pokemon_filters"` // clauses shaped like the /api/pokemon/v3/scan filters
+	FortFilters      []map[string]any  `koanf:"fort_filters"`    // clauses shaped like the /api/fort/scan filters
+	Interval         int               `koanf:"interval"`        // ms between POSTs, 0 for the default of one second
+	MaxMessages      int               `koanf:"max_messages"`    // messages per POST, 0 for no limit
+	MaxBytes         int               `koanf:"max_bytes"`       // uncompressed bytes per POST, 0 for no limit
+	Gzip             bool              `koanf:"gzip"`            // gzip request bodies
 	HeaderMap        map[string]string `koanf:"-"`
 	AreaNames        []geo.AreaName    `koanf:"-"`
 	ExcludeAreaNames []geo.AreaName    `koanf:"-"`
 }
 
+// RawToken is a named credential for /raw and the gRPC raw receiver.
+type RawToken struct {
+	Name        string         `koanf:"name"`
+	Token       string         `koanf:"token"`
+	Enabled     *bool          `koanf:"enabled"`      // default true
+	ScanContext string         `koanf:"scan_context"` // used when the device does not send one
+	Areas       []string       `koanf:"areas"`        // if set, submissions must be located inside one of these
+	AreaNames   []geo.AreaName `koanf:"-"`
+}
+
+func (t RawToken) IsEnabled() bool {
+	return t.Enabled == nil || *t.Enabled
+}
+
+type capture struct {
+	Enabled    bool   `koanf:"enabled"`
+	Directory  string `koanf:"directory"`
+	MaxSize    int    `koanf:"max_size"`    // MB per capture file before rotation
+	MaxBackups int    `koanf:"max_backups"` // rotated capture files to keep
+	Compress   bool   `koanf:"compress"`
+}
+
+type deadLetter struct {
+	Enabled       bool   `koanf:"enabled"`
+	MemoryEntries int    `koanf:"memory_entries"` // most recent entries listed by the api
+	Directory     string `koanf:"directory"`      // blank keeps entries in memory only
+	MaxSize       int    `koanf:"max_size"`       // MB per file before rotation
+	MaxBackups    int    `koanf:"max_backups"`    // rotated files to keep
+}
+
+// WebhookDelivery controls how webhook POSTs that fail are retried.
+type WebhookDelivery struct {
+	RetryBase      int    `koanf:"retry_base"`      // ms before the first retry, doubled after each failure
+	RetryMax       int    `koanf:"retry_max"`       // seconds, longest wait between retries
+	SpoolDirectory string `koanf:"spool_directory"` // blank keeps undelivered payloads in memory only
+	SpoolMaxSize   int    `koanf:"spool_max_size"`  // MB per destination; the oldest payloads are dropped beyond this
+	Timeout        int    `koanf:"timeout"`         // seconds to wait for a destination to answer before retrying
+}
+
 type pvp struct {
 	Enabled               bool   `koanf:"enabled"`
 	IncludeHundosUnderCap bool   `koanf:"include_hundos_under_cap"`
@@ -122,12 +177,23 @@ type logging struct {
 	Compress          bool `koanf:"compress"`
 }
 
-type database struct {
-	Addr     string `koanf:"address"`
-	User     string `koanf:"user"`
-	Password string `koanf:"password"`
-	Db       string `koanf:"db"`
-	MaxPool  int    `koanf:"max_pool"`
+type Database struct {
+	Driver   string   `koanf:"driver"` // mysql, sqlite or postgres
+	Addr     string   `koanf:"address"`
+	User     string   `koanf:"user"`
+	Password string   `koanf:"password"`
+	Db       string   `koanf:"db"`
+	Path     string   `koanf:"path"` // sqlite database file
+	MaxPool  int      `koanf:"max_pool"`
+	Stats    bool     `koanf:"stats"`           // pokemon_database only: keep the stats tables there too
+	Replicas []string `koanf:"replicas"`        // database only: read replica connection strings, in the driver's own form
+	MaxLag   int      `koanf:"max_replica_lag"` // seconds a replica may fall behind before reads go to the primary, 0 for no limit
+}
+
+// IsSet reports whether the section names a database, by address or, for
+// sqlite, by path
+func (database Database) IsSet() bool {
+	return database.Addr != "" || database.Path != ""
 }
 
 type tuning struct {
@@ -142,13 +208,19 @@ type tuning struct {
 	WriteBehindWorkerCount         int     `koanf:"write_behind_worker_count"`  // concurrent writers, default: 50
 	WriteBehindBatchSize           int     `koanf:"write_behind_batch_size"`    // entries per batch, default: 50
 	WriteBehindBatchTimeoutMs      int     `koanf:"write_behind_batch_timeout"` // max wait for batch in ms, default: 100
+	WriteBehindJournal             string  `koanf:"write_behind_journal"`       // directory journaling queued writes to replay after a crash, empty disables, default: ""
 	S2CellLookup                   bool    `koanf:"s2_cell_lookup"`             // Pre-compute S2 cell lookup for faster geofence matching. Trades memory (~60x geofence file size) for ~7x faster lookups, default: false
+	DecodeWorkers                  int     `koanf:"decode_workers"`             // concurrent raw batch decoders, default: 50
+	DecodeQueueSize                int     `koanf:"decode_queue_size"`          // raw batches waiting for a decoder before /raw refuses, default: 1000
+	RawBodyLimit                   int     `koanf:"raw_body_limit"`             // MB, /raw body size after decompression, default: 5
+	GmoDedupWindow                 int     `koanf:"gmo_dedup_window"`           // seconds an unchanged GMO cell is skipped for, 0 disables, default: 0
 }
 
 type scanRule struct {
 	Areas                    []string       `koanf:"areas"`
 	AreaNames                []geo.AreaName `koanf:"-"`
 	ScanContext              []string       `koanf:"context"`
+	Tokens                   []string       `koanf:"tokens"`
 	ProcessPokemon           *bool          `koanf:"pokemon"`
 	ProcessWilds             *bool          `koanf:"wild_pokemon"`
 	ProcessNearby            *bool          `koanf:"nearby_pokemon"`
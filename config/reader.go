@@ -1,7 +1,9 @@
 package config
 
 import (
+	"errors"
 	"fmt"
+	"io/fs"
 	"strconv"
 	"strings"
 
@@ -50,7 +52,13 @@ func ReadConfig() (configDefinition, error) {
 			StatsDays:      7,
 			DeviceHours:    24,
 		},
-		Database: database{
+		Database: Database{
+			Driver:  "mysql",
+			Path:    "golbat.db",
+			MaxPool: 100,
+			MaxLag:  30,
+		},
+		PokemonDatabase: Database{
 			MaxPool: 100,
 		},
 		Tuning: tuning{
@@ -62,6 +70,9 @@ func ReadConfig() (configDefinition, error) {
 			WriteBehindWorkerCount:         50,  // concurrent writers
 			WriteBehindBatchSize:           50,  // entries per batch
 			WriteBehindBatchTimeoutMs:      100, // ms to wait for batch to fill
+			DecodeWorkers:                  50,
+			DecodeQueueSize:                1000,
+			RawBodyLimit:                   5,
 		},
 		Weather: weather{
 			ProactiveIVSwitching:     true,
@@ -77,6 +88,25 @@ func ReadConfig() (configDefinition, error) {
 			InvasionStatsIntervalMinutes: 15,
 			QuestStatsIntervalMinutes:    15,
 		},
+		Capture: capture{
+			Directory:  "captures",
+			MaxSize:    100,
+			MaxBackups: 20,
+			Compress:   true,
+		},
+		DeadLetter: deadLetter{
+			MemoryEntries: 500,
+			Directory:     "dead_letters",
+			MaxSize:       10,
+			MaxBackups:    5,
+		},
+		WebhookDelivery: WebhookDelivery{
+			RetryBase:      500,
+			RetryMax:       60,
+			SpoolDirectory: "webhook_spool",
+			SpoolMaxSize:   50,
+			Timeout:        10,
+		},
 	}, "koanf"), nil)
 	if defaultErr != nil {
 		fmt.Println(fmt.Errorf("failed to load default config: %w", defaultErr))
@@ -87,21 +117,7 @@ func ReadConfig() (configDefinition, error) {
 		fmt.Println(fmt.Errorf("failed to read config file: %w", readConfigErr))
 	}
 
-	envLoadingErr := k.Load(ProviderWithValue("GOLBAT.", ".", func(rawKey string, value string, currentMap map[string]interface{}) (string, interface{}) {
-		key := strings.ToLower(strings.TrimPrefix(rawKey, "GOLBAT."))
-
-		if strings.HasPrefix(key, "webhooks") {
-			parseEnvVarToSlice("webhooks", key, value, currentMap)
-
-			return "", nil
-		} else if strings.HasPrefix(key, "scan_rules") {
-			parseEnvVarToSlice("scan_rules", key, value, currentMap)
-
-			return "", nil
-		}
-
-		return key, value
-	}), nil)
+	envLoadingErr := k.Load(envProvider(), nil)
 
 	if envLoadingErr != nil {
 		fmt.Println(fmt.Errorf("%w", envLoadingErr))
@@ -112,23 +128,81 @@ func ReadConfig() (configDefinition, error) {
 		return Config, fmt.Errorf("failed to Unmarshal config: %w", unmarshalError)
 	}
 
-	// translate webhook areas to array of geo.AreaName struct
-	for i := 0; i < len(Config.Webhooks); i++ {
-		hook := &Config.Webhooks[i]
-		hook.AreaNames = splitIntoAreaAndFenceName(hook.Areas)
-		hook.ExcludeAreaNames = splitIntoAreaAndFenceName(hook.ExcludeAreas)
-		hook.HeaderMap = splitIntoHeaderMap(hook.Headers)
+	if Config.PokemonDatabase.Driver == "" {
+		Config.PokemonDatabase.Driver = Config.Database.Driver
 	}
 
+	translateWebhooks(Config.Webhooks)
+
 	// translate scan areas to array of geo.AreaName struct
 	for i := 0; i < len(Config.ScanRules); i++ {
 		rule := &Config.ScanRules[i]
 		rule.AreaNames = splitIntoAreaAndFenceName(rule.Areas)
 	}
 
+	// translate raw token areas to array of geo.AreaName struct
+	for i := 0; i < len(Config.RawTokens); i++ {
+		token := &Config.RawTokens[i]
+		token.AreaNames = splitIntoAreaAndFenceName(token.Areas)
+	}
+
 	return Config, nil
 }
 
+// ReadWebhooks reads the webhooks from config.toml and the environment again,
+// leaving the rest of Config alone, so destinations can be reloaded without a
+// restart.
+func ReadWebhooks() ([]Webhook, error) {
+	wk := koanf.New(".")
+	if err := wk.Load(file.Provider("config.toml"), toml.Parser()); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("failed to read config file: %w", err)
+	}
+	if err := wk.Load(envProvider(), nil); err != nil {
+		return nil, err
+	}
+
+	var webhooks []Webhook
+	if err := wk.Unmarshal("webhooks", &webhooks); err != nil {
+		return nil, fmt.Errorf("failed to Unmarshal webhooks: %w", err)
+	}
+	translateWebhooks(webhooks)
+	return webhooks, nil
+}
+
+// envProvider loads GOLBAT. prefixed environment variables, collecting
+// indexed ones such as GOLBAT.WEBHOOKS.0.URL into slices
+func envProvider() *Env {
+	return ProviderWithValue("GOLBAT.", ".", func(rawKey string, value string, currentMap map[string]interface{}) (string, interface{}) {
+		key := strings.ToLower(strings.TrimPrefix(rawKey, "GOLBAT."))
+
+		if strings.HasPrefix(key, "webhooks") {
+			parseEnvVarToSlice("webhooks", key, value, currentMap)
+
+			return "", nil
+		} else if strings.HasPrefix(key, "scan_rules") {
+			parseEnvVarToSlice("scan_rules", key, value, currentMap)
+
+			return "", nil
+		} else if strings.HasPrefix(key, "raw_tokens") {
+			parseEnvVarToSlice("raw_tokens", key, value, currentMap)
+
+			return "", nil
+		}
+
+		return key, value
+	})
+}
+
+// translateWebhooks translates webhook areas to array of geo.AreaName struct
+func translateWebhooks(webhooks []Webhook) {
+	for i := 0; i < len(webhooks); i++ {
+		hook := &webhooks[i]
+		hook.AreaNames = splitIntoAreaAndFenceName(hook.Areas)
+		hook.ExcludeAreaNames = splitIntoAreaAndFenceName(hook.ExcludeAreas)
+		hook.HeaderMap = splitIntoHeaderMap(hook.Headers)
+	}
+}
+
 func parseEnvVarToSlice(sliceName string, key string, value string, currentMap map[string]interface{}) {
 	splitPath := strings.Split(key, ".")
 	lastPart := splitPath[len(splitPath)-1]
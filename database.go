@@ -0,0 +1,115 @@
+package main
+
+import (
+	"strings"
+	"time"
+
+	"golbat/config"
+	db2 "golbat/db"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/golang-migrate/migrate/v4"
+	_ "github.com/golang-migrate/migrate/v4/database/mysql"
+	_ "github.com/golang-migrate/migrate/v4/database/pgx/v5"
+	_ "github.com/golang-migrate/migrate/v4/database/sqlite"
+	_ "github.com/golang-migrate/migrate/v4/source/file"
+	"github.com/jmoiron/sqlx"
+	log "github.com/sirupsen/logrus"
+)
+
+// openDatabase migrates and connects to the database a config section
+// describes, exiting if it can't. name tells the databases apart in the log.
+func openDatabase(name string, settings config.Database) *sqlx.DB {
+	driver := settings.Driver
+	var dbConnectionString, migrationSource, migrationUrl string
+
+	switch driver {
+	case "mysql":
+		// Capture connection properties.
+		mysqlConfig := mysql.Config{
+			User:                 settings.User,     //"root",     //os.Getenv("DBUSER"),
+			Passwd:               settings.Password, //"transmit", //os.Getenv("DBPASS"),
+			Net:                  "tcp",
+			Addr:                 settings.Addr,
+			DBName:               settings.Db,
+			AllowNativePasswords: true,
+		}
+
+		dbConnectionString = mysqlConfig.FormatDSN()
+		migrationSource = "file://sql"
+		migrationUrl = "mysql://" + dbConnectionString + "&multiStatements=true"
+	case "sqlite":
+		dbConnectionString = db2.SQLiteDSN(settings.Path)
+		migrationSource = "file://sql/sqlite"
+		migrationUrl = "sqlite://" + settings.Path
+	case "postgres":
+		dbConnectionString = db2.PostgresDSN(settings.Addr, settings.User, settings.Password, settings.Db)
+		migrationSource = "file://sql/postgres"
+		migrationUrl = "pgx5://" + strings.TrimPrefix(dbConnectionString, "postgres://")
+	default:
+		log.Fatalf("unknown %s database driver '%s', expected mysql, sqlite or postgres", name, driver)
+	}
+
+	log.Infof("Starting migration of %s database", name)
+
+	m, err := migrate.New(migrationSource, migrationUrl)
+	if err != nil {
+		log.Fatal(err)
+	}
+	err = m.Up()
+	if err != nil && err != migrate.ErrNoChange {
+		log.Fatal(err)
+	}
+
+	log.Infof("Opening %s %s database for processing, max pool = %d", name, driver, settings.MaxPool)
+
+	// Get a database handle.
+	var db *sqlx.DB
+	switch driver {
+	case "sqlite":
+		db = db2.OpenSQLite(dbConnectionString)
+	case "postgres":
+		db, err = db2.OpenPostgres(dbConnectionString)
+	default:
+		db, err = sqlx.Open(driver, dbConnectionString)
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	configurePool(db, settings)
+
+	pingErr := db.Ping()
+	if pingErr != nil {
+		log.Fatal(pingErr)
+	}
+	log.Infof("Connected to %s database", name)
+	return db
+}
+
+// openReplicas connects to the read replicas a config section lists, exiting
+// if it can't. Replicas are not migrated: they follow their primary.
+func openReplicas(name string, settings config.Database) []*db2.Replica {
+	var replicas []*db2.Replica
+	for _, dsn := range settings.Replicas {
+		replica, err := db2.OpenReplica(settings.Driver, dsn)
+		if err != nil {
+			log.Fatalf("%s database replica: %s", name, err)
+		}
+		configurePool(replica.DB, settings)
+
+		if err := replica.Ping(); err != nil {
+			log.Fatalf("%s database replica %s: %s", name, replica.Name, err)
+		}
+		log.Infof("Connected to %s database replica %s", name, replica.Name)
+		replicas = append(replicas, replica)
+	}
+	return replicas
+}
+
+func configurePool(db *sqlx.DB, settings config.Database) {
+	db.SetConnMaxLifetime(time.Minute * 3) // Recommended by go mysql driver
+	db.SetMaxOpenConns(settings.MaxPool)
+	db.SetMaxIdleConns(10)
+	db.SetConnMaxIdleTime(time.Minute)
+}
@@ -10,6 +10,18 @@ type DbDetails struct {
 	PokemonDb       *sqlx.DB
 	UsePokemonCache bool
 	GeneralDb       *sqlx.DB
+	GeneralReplicas []*Replica
+}
+
+// ReadOnly returns the details with the general database swapped for one of
+// its healthy read replicas, for API and stats queries that don't mind
+// replication lag. Without one it returns the details unchanged.
+func (d DbDetails) ReadOnly() DbDetails {
+	return DbDetails{
+		PokemonDb:       d.PokemonDb,
+		UsePokemonCache: d.UsePokemonCache,
+		GeneralDb:       pickReplica(d.GeneralDb, d.GeneralReplicas),
+	}
 }
 
 var statsCollector stats_collector.StatsCollector
@@ -0,0 +1,182 @@
+package db
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// Dialect is the flavour of SQL a database speaks. Queries are written for
+// MySQL; Rebind and the expression helpers adapt them to the other dialects.
+type Dialect int
+
+const (
+	MySQL Dialect = iota
+	SQLite
+	Postgres
+)
+
+// DialectOf returns the dialect of x, going by the driver it was opened with
+func DialectOf(x *sqlx.DB) Dialect {
+	if x == nil {
+		return MySQL
+	}
+	switch x.DriverName() {
+	case sqliteDriverName:
+		return SQLite
+	case postgresDriverName:
+		return Postgres
+	}
+	return MySQL
+}
+
+var (
+	duplicateKeyRegex  = regexp.MustCompile(`(?i)ON DUPLICATE KEY UPDATE`)
+	insertedValueRegex = regexp.MustCompile("(?i)VALUES\\((`?\\w+`?)\\)")
+	unixTimestampRegex = regexp.MustCompile(`(?i)UNIX_TIMESTAMP\(\)`)
+	insertTableRegex   = regexp.MustCompile("(?i)INSERT INTO `?(\\w+)`?")
+	updateTermRegex    = regexp.MustCompile("(?i)VALUES\\(`?\\w+`?\\)|[.:]?`?[a-z_]\\w*`?(\\s*\\()?")
+)
+
+// conflictKeys are the primary keys of the tables upserted into, for the
+// ON CONFLICT target Postgres requires. Tables not listed use id.
+var conflictKeys = map[string]string{
+	"invasion_stats":      `date, area, fence, "character"`,
+	"player":              "name",
+	"pokemon_hundo_stats": "date, area, fence, pokemon_id, form_id",
+	"pokemon_iv_stats":    "date, area, fence, pokemon_id, form_id",
+	"pokemon_nundo_stats": "date, area, fence, pokemon_id, form_id",
+	"pokemon_shiny_stats": "date, area, fence, pokemon_id, form_id",
+	"pokemon_stats":       "date, area, fence, pokemon_id, form_id",
+	"quest_stats":         "date, area, fence, reward_type, pokemon_id, item_id, item_amount",
+	"raid_stats":          "date, area, fence, pokemon_id, form_id, temp_evo_id, level",
+	"station_battle":      "bread_battle_seed",
+}
+
+// words left alone when qualifying the columns of an upsert's update clause
+var sqlKeywords = map[string]bool{
+	"and": true, "case": true, "else": true, "end": true, "false": true, "in": true,
+	"is": true, "not": true, "null": true, "or": true, "then": true, "true": true, "when": true,
+}
+
+// Rebind rewrites query, written for MySQL, for the dialect. It translates
+// INSERT ... ON DUPLICATE KEY UPDATE upserts and UNIX_TIMESTAMP(), and for
+// Postgres also backtick quoting and ? placeholders.
+func (d Dialect) Rebind(query string) string {
+	switch d {
+	case SQLite:
+		query = duplicateKeyRegex.ReplaceAllString(query, "ON CONFLICT DO UPDATE SET")
+		query = insertedValueRegex.ReplaceAllString(query, "excluded.$1")
+		return unixTimestampRegex.ReplaceAllString(query, "unixepoch()")
+	case Postgres:
+		query = postgresUpsert(query)
+		query = unixTimestampRegex.ReplaceAllString(query, "CAST(FLOOR(EXTRACT(EPOCH FROM NOW())) AS BIGINT)")
+		query = strings.ReplaceAll(query, "`", `"`)
+		return sqlx.Rebind(sqlx.DOLLAR, query)
+	}
+	return query
+}
+
+// postgresUpsert turns ON DUPLICATE KEY UPDATE into ON CONFLICT DO UPDATE.
+// Postgres needs the conflict target spelled out, and columns of the existing
+// row qualified with the table name, as EXCLUDED has the same columns.
+func postgresUpsert(query string) string {
+	loc := duplicateKeyRegex.FindStringIndex(query)
+	if loc == nil {
+		return query
+	}
+	table := ""
+	if m := insertTableRegex.FindStringSubmatch(query[:loc[0]]); m != nil {
+		table = m[1]
+	}
+	key, ok := conflictKeys[table]
+	if !ok {
+		key = "id"
+	}
+
+	update, terminator := query[loc[1]:], ""
+	if trimmed := strings.TrimRight(update, "; \t\n"); len(trimmed) < len(update) {
+		update, terminator = trimmed, update[len(trimmed):]
+	}
+	assignments := splitTopLevel(update)
+	for i, assignment := range assignments {
+		column, value, _ := strings.Cut(assignment, "=")
+		assignments[i] = column + "=" + updateTermRegex.ReplaceAllStringFunc(value, func(term string) string {
+			switch {
+			case strings.HasPrefix(strings.ToUpper(term), "VALUES("):
+				return "EXCLUDED." + term[len("VALUES("):len(term)-1]
+			case term[0] == '.' || term[0] == ':' || strings.HasSuffix(term, "("):
+				return term
+			case sqlKeywords[strings.ToLower(strings.Trim(term, "`"))]:
+				return term
+			}
+			return table + "." + term
+		})
+	}
+	return query[:loc[0]] + "ON CONFLICT (" + key + ") DO UPDATE SET" + strings.Join(assignments, ",") + terminator
+}
+
+// splitTopLevel splits s at the commas outside parentheses
+func splitTopLevel(s string) []string {
+	var parts []string
+	depth, start := 0, 0
+	for i, c := range s {
+		switch c {
+		case '(':
+			depth++
+		case ')':
+			depth--
+		case ',':
+			if depth == 0 {
+				parts = append(parts, s[start:i])
+				start = i + 1
+			}
+		}
+	}
+	return append(parts, s[start:])
+}
+
+// Rebind rewrites query, written for MySQL, for the dialect of x
+func Rebind(x *sqlx.DB, query string) string {
+	return DialectOf(x).Rebind(query)
+}
+
+// WithinGeoJSON returns a condition that holds when the lon and lat columns
+// lie inside the geometry of the GeoJSON feature, and the argument to bind to
+// the condition's one placeholder. The feature is bound rather than written
+// into the query, so quotes in its properties can't break the query.
+func (d Dialect) WithinGeoJSON(feature []byte) (string, string) {
+	switch d {
+	case SQLite:
+		return "golbat_within(?, lon, lat)", string(feature)
+	case Postgres:
+		return "ST_Contains(ST_SetSRID(ST_GeomFromGeoJSON(CAST(? AS text)), 4326), " +
+			"ST_SetSRID(ST_MakePoint(lon, lat), 4326))", string(postgresGeometry(feature))
+	}
+	return "ST_CONTAINS(ST_GeomFromGeoJSON(?, 2, 0), POINT(lon, lat))", string(feature)
+}
+
+// DistanceSphere returns an expression for the distance in metres between the
+// lon and lat columns and the point given by the next two (lon, lat) arguments
+func (d Dialect) DistanceSphere() string {
+	switch d {
+	case SQLite:
+		return "golbat_distance(lon, lat, ?, ?)"
+	case Postgres:
+		return "ST_DistanceSphere(ST_MakePoint(lon, lat), ST_MakePoint(?, ?))"
+	}
+	return "ST_Distance_Sphere(POINT(lon, lat), POINT(?, ?))"
+}
+
+// DaysAgo returns an expression for the date the given number of days ago
+func (d Dialect) DaysAgo(days int) string {
+	switch d {
+	case SQLite:
+		return fmt.Sprintf("date('now', 'localtime', '-%d days')", days)
+	case Postgres:
+		return fmt.Sprintf("CURRENT_DATE - %d", days)
+	}
+	return fmt.Sprintf("DATE(NOW() - INTERVAL %d DAY)", days)
+}
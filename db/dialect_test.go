@@ -0,0 +1,181 @@
+package db
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"math"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+func TestRebind(t *testing.T) {
+	query := "INSERT INTO s2cell (id, updated) VALUES (:id, UNIX_TIMESTAMP()) " +
+		"ON DUPLICATE KEY UPDATE updated = VALUES(updated), `count` = `count` + VALUES(`count`)"
+
+	if got := MySQL.Rebind(query); got != query {
+		t.Errorf("mysql: got %q, want the query unchanged", got)
+	}
+	want := "INSERT INTO s2cell (id, updated) VALUES (:id, unixepoch()) " +
+		"ON CONFLICT DO UPDATE SET updated = excluded.updated, `count` = `count` + excluded.`count`"
+	if got := SQLite.Rebind(query); got != want {
+		t.Errorf("sqlite: got %q, want %q", got, want)
+	}
+	want = `INSERT INTO s2cell (id, updated) VALUES (:id, CAST(FLOOR(EXTRACT(EPOCH FROM NOW())) AS BIGINT)) ` +
+		`ON CONFLICT (id) DO UPDATE SET updated = EXCLUDED.updated, "count" = s2cell."count" + EXCLUDED."count"`
+	if got := Postgres.Rebind(query); got != want {
+		t.Errorf("postgres: got %q, want %q", got, want)
+	}
+}
+
+func TestRebindPostgres(t *testing.T) {
+	tests := []struct {
+		query, want string
+	}{
+		{
+			"SELECT id FROM `gym` WHERE id IN (?, ?) AND updated > ?",
+			`SELECT id FROM "gym" WHERE id IN ($1, $2) AND updated > $3`,
+		},
+		{
+			"INSERT INTO pokemon (id, pvp) VALUES (:id, :pvp)\nON DUPLICATE KEY UPDATE\n\tpvp = COALESCE(VALUES(pvp), pvp),\n\tupdated = VALUES(updated)\n",
+			"INSERT INTO pokemon (id, pvp) VALUES (:id, :pvp)\nON CONFLICT (id) DO UPDATE SET\n\tpvp = COALESCE(EXCLUDED.pvp, pokemon.pvp),\n\tupdated = EXCLUDED.updated\n",
+		},
+		{
+			"INSERT INTO pokemon_shiny_stats (date, `count`, total) VALUES (:date, :count, :total)" +
+				" ON DUPLICATE KEY UPDATE `count` = `count` + VALUES(`count`), total = total + VALUES(total);",
+			`INSERT INTO pokemon_shiny_stats (date, "count", total) VALUES (:date, :count, :total)` +
+				` ON CONFLICT (date, area, fence, pokemon_id, form_id) DO UPDATE SET "count" = pokemon_shiny_stats."count" + EXCLUDED."count",` +
+				` total = pokemon_shiny_stats.total + EXCLUDED.total;`,
+		},
+	}
+	for _, test := range tests {
+		if got := Postgres.Rebind(test.query); got != test.want {
+			t.Errorf("got %q, want %q", got, test.want)
+		}
+		if again := Postgres.Rebind(test.want); again != test.want {
+			t.Errorf("rebinding again changed %q to %q", test.want, again)
+		}
+	}
+}
+
+func TestSQLite(t *testing.T) {
+	x := OpenSQLite(SQLiteDSN(t.TempDir() + "/golbat.db"))
+	defer x.Close()
+	if DialectOf(x) != SQLite {
+		t.Fatal("not detected as sqlite")
+	}
+
+	schema, err := os.ReadFile("../sql/sqlite/1_schema.up.sql")
+	if err != nil {
+		t.Fatal(err)
+	}
+	x.MustExec(string(schema))
+
+	upsert := Rebind(x, "INSERT INTO s2cell (id, center_lat, center_lon, level, updated) "+
+		"VALUES (:id, :center_lat, :center_lon, :level, :updated) "+
+		"ON DUPLICATE KEY UPDATE updated = VALUES(updated)")
+	type cell struct {
+		Id        uint64  `db:"id"`
+		Latitude  float64 `db:"center_lat"`
+		Longitude float64 `db:"center_lon"`
+		Level     int     `db:"level"`
+		Updated   int64   `db:"updated"`
+	}
+	cells := []cell{{Id: math.MaxUint64, Level: 15, Updated: 1}, {Id: 42, Level: 15, Updated: 1}}
+	if _, err := x.NamedExec(upsert, cells); err != nil {
+		t.Fatal(err)
+	}
+	cells[0].Updated = 2
+	if _, err := x.NamedExec(upsert, cells[:1]); err != nil {
+		t.Fatal(err)
+	}
+	var stored cell
+	if err := x.Get(&stored, "SELECT id, center_lat, center_lon, level, updated FROM s2cell WHERE id = ?", uint64(math.MaxUint64)); err != nil {
+		t.Fatal(err)
+	}
+	if stored.Id != math.MaxUint64 || stored.Updated != 2 {
+		t.Errorf("got %+v, want the cell updated", stored)
+	}
+
+	x.MustExec("INSERT INTO pokestop (id, lat, lon, updated, enabled, first_seen_timestamp) VALUES " +
+		"('inside', 1, 1, 0, 1, 0), ('outside', 3, 3, 0, 1, 0)")
+	fence := []byte(`{"type":"Feature","properties":{"name":"St Mary's Park?"},"geometry":{"type":"Polygon","coordinates":[[[0,0],[2,0],[2,2],[0,2],[0,0]]]}}`)
+	within, fenceArg := SQLite.WithinGeoJSON(fence)
+	var ids []string
+	if err := x.Select(&ids, "SELECT id FROM pokestop WHERE "+within, fenceArg); err != nil {
+		t.Fatal(err)
+	}
+	if len(ids) != 1 || ids[0] != "inside" {
+		t.Errorf("got %v, want only the pokestop inside the fence", ids)
+	}
+
+	ids = nil
+	if err := x.Select(&ids, "SELECT id FROM pokestop WHERE "+SQLite.DistanceSphere()+" <= ?", 1.0, 1.0, 1000); err != nil {
+		t.Fatal(err)
+	}
+	if len(ids) != 1 || ids[0] != "inside" {
+		t.Errorf("got %v, want only the pokestop within 1km", ids)
+	}
+}
+
+func TestWithinGeoJSONPostgres(t *testing.T) {
+	fence := []byte(`{"type":"Feature","properties":{"name":"St Mary's Park?"},"geometry":{"type":"Polygon","coordinates":[[[0,0],[2,0],[2,2],[0,2],[0,0]]]}}`)
+	want := `{"type":"Polygon","coordinates":[[[0,0],[2,0],[2,2],[0,2],[0,0]]]}`
+	within, fenceArg := Postgres.WithinGeoJSON(fence)
+	if strings.Contains(within, "Mary") {
+		t.Errorf("fence written into the condition %q", within)
+	}
+	if fenceArg != want {
+		t.Errorf("got argument %q, want the bare geometry %q", fenceArg, want)
+	}
+}
+
+func TestPostgresCheckNamedValue(t *testing.T) {
+	tests := []struct {
+		value, want driver.Value
+	}{
+		{uint64(math.MaxUint64), "18446744073709551615"},
+		{uint64(math.MaxInt64), uint64(math.MaxInt64)},
+		{true, true},
+		{int16(-3), int16(-3)},
+		{1.5, 1.5},
+		{"id", "id"},
+		{nil, nil},
+	}
+	for _, test := range tests {
+		nv := driver.NamedValue{Value: test.value}
+		if err := (postgresConn{}).CheckNamedValue(&nv); err != nil || nv.Value != test.want {
+			t.Errorf("%#v: got %#v (%v), want %#v", test.value, nv.Value, err, test.want)
+		}
+	}
+}
+
+func TestPostgresTypes(t *testing.T) {
+	m := pgtype.NewMap()
+	registerPostgresTypes(m)
+
+	tests := []struct {
+		oid   uint32
+		value any
+		want  string
+	}{
+		{pgtype.Int2OID, true, "1"},
+		{pgtype.Int2OID, sql.NullBool{Bool: false, Valid: true}, "0"},
+		{pgtype.Int2OID, int16(7), "7"},
+		{pgtype.VarcharOID, uint64(12345678901234567890), "12345678901234567890"},
+		{pgtype.TextOID, int64(-5), "-5"},
+		{pgtype.VarcharOID, "id", "id"},
+	}
+	for _, test := range tests {
+		got, err := m.Encode(test.oid, pgtype.TextFormatCode, test.value, nil)
+		if err != nil || string(got) != test.want {
+			t.Errorf("%#v: got %q (%v), want %q", test.value, got, err, test.want)
+		}
+	}
+
+	if _, err := m.Encode(pgtype.Int2OID, pgtype.BinaryFormatCode, "1", nil); err == nil {
+		t.Error("string encoded into a smallint, want an error")
+	}
+}
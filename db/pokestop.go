@@ -23,16 +23,18 @@ type QuestStatus struct {
 }
 
 func GetPokestopPositions(db DbDetails, fence *geojson.Feature) ([]QuestLocation, error) {
+	db = db.ReadOnly()
 	bbox := fence.Geometry.Bound()
 	bytes, err := fence.MarshalJSON()
 	if err != nil {
 		return nil, err
 	}
+	within, fenceArg := DialectOf(db.GeneralDb).WithinGeoJSON(bytes)
 	areas := []QuestLocation{}
 	err = db.GeneralDb.Select(&areas, "SELECT id, lat, lon FROM pokestop "+
 		"WHERE lat > ? and lon > ? and lat < ? and lon < ? and enabled = 1 "+
-		"and ST_CONTAINS(ST_GeomFromGeoJSON('"+string(bytes)+"', 2, 0), POINT(lon, lat))",
-		bbox.Min.Lat(), bbox.Min.Lon(), bbox.Max.Lat(), bbox.Max.Lon())
+		"and "+within,
+		bbox.Min.Lat(), bbox.Min.Lon(), bbox.Max.Lat(), bbox.Max.Lon(), fenceArg)
 
 	statsCollector.IncDbQuery("select pokestop-positions", err)
 	if err == sql.ErrNoRows {
@@ -47,6 +49,7 @@ func GetPokestopPositions(db DbDetails, fence *geojson.Feature) ([]QuestLocation
 }
 
 func GetQuestStatus(db DbDetails, fence *geojson.Feature) (QuestStatus, error) {
+	db = db.ReadOnly()
 	bbox := fence.Geometry.Bound()
 	status := QuestStatus{}
 
@@ -55,13 +58,14 @@ func GetQuestStatus(db DbDetails, fence *geojson.Feature) (QuestStatus, error) {
 		return status, err
 	}
 
+	within, fenceArg := DialectOf(db.GeneralDb).WithinGeoJSON(bytes)
 	err = db.GeneralDb.Get(&status,
 		"SELECT COUNT(*) AS total, "+
 			"COUNT(CASE WHEN quest_type IS NOT NULL THEN 1 END) AS ar_quests, "+
 			"COUNT(CASE WHEN alternative_quest_type IS NOT NULL THEN 1 END) AS no_ar_quests FROM pokestop "+
 			"WHERE lat > ? AND lon > ? AND lat < ? AND lon < ? AND enabled = 1 AND deleted = 0 "+
-			"AND ST_CONTAINS(ST_GeomFromGeoJSON('"+string(bytes)+"', 2, 0), POINT(lon, lat)) ",
-		bbox.Min.Lat(), bbox.Min.Lon(), bbox.Max.Lat(), bbox.Max.Lon(),
+			"AND "+within,
+		bbox.Min.Lat(), bbox.Min.Lon(), bbox.Max.Lat(), bbox.Max.Lon(), fenceArg,
 	)
 
 	statsCollector.IncDbQuery("select quest-status", err)
@@ -0,0 +1,162 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"math"
+	"net/url"
+	"reflect"
+	"strconv"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/stdlib"
+	"github.com/jmoiron/sqlx"
+	"github.com/paulmach/orb/geojson"
+)
+
+const postgresDriverName = "pgx"
+
+// PostgresDSN returns the connection URL for the database dbName on the server
+// at addr
+func PostgresDSN(addr, user, password, dbName string) string {
+	dsn := url.URL{Scheme: "postgres", User: url.UserPassword(user, password), Host: addr, Path: "/" + dbName}
+	return dsn.String()
+}
+
+// OpenPostgres opens the PostgreSQL database with the given connection string,
+// either a postgres:// URL or key=value pairs
+func OpenPostgres(dsn string) (*sqlx.DB, error) {
+	config, err := pgx.ParseConfig(dsn)
+	if err != nil {
+		return nil, err
+	}
+	return sqlx.NewDb(sql.OpenDB(postgresConnector{stdlib.GetConnector(*config)}), postgresDriverName), nil
+}
+
+type postgresConnector struct {
+	driver.Connector
+}
+
+func (c postgresConnector) Connect(ctx context.Context) (driver.Conn, error) {
+	conn, err := c.Connector.Connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	pc, ok := conn.(*stdlib.Conn)
+	if !ok {
+		conn.Close()
+		return nil, errors.New("postgres: unexpected connection type")
+	}
+	registerPostgresTypes(pc.Conn().TypeMap())
+	return postgresConn{pc}, nil
+}
+
+// postgresConn rewrites every query for Postgres on its way to the server, so
+// the many plain MySQL queries with ? placeholders work without a Rebind each
+type postgresConn struct {
+	*stdlib.Conn
+}
+
+func (c postgresConn) Prepare(query string) (driver.Stmt, error) {
+	return c.Conn.Prepare(Postgres.Rebind(query))
+}
+
+func (c postgresConn) PrepareContext(ctx context.Context, query string) (driver.Stmt, error) {
+	return c.Conn.PrepareContext(ctx, Postgres.Rebind(query))
+}
+
+func (c postgresConn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	return c.Conn.ExecContext(ctx, Postgres.Rebind(query), args)
+}
+
+func (c postgresConn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	return c.Conn.QueryContext(ctx, Postgres.Rebind(query), args)
+}
+
+// CheckNamedValue sends unsigned integers too large for pgx, such as s2 cell
+// ids, as text for Postgres to convert to the column's numeric type. Everything
+// else is left for pgx to encode.
+func (c postgresConn) CheckNamedValue(nv *driver.NamedValue) error {
+	switch v := reflect.ValueOf(nv.Value); v.Kind() {
+	case reflect.Uint, reflect.Uint64:
+		if v.Uint() > math.MaxInt64 {
+			nv.Value = strconv.FormatUint(v.Uint(), 10)
+		}
+	}
+	return nil
+}
+
+// registerPostgresTypes lets pgx encode the Go types that differ from their
+// columns in the Postgres schema: booleans are kept in smallint columns, as in
+// MySQL, and some ids that are numbers in Go are kept in text columns. Other
+// mismatches still fail to encode.
+func registerPostgresTypes(m *pgtype.Map) {
+	m.RegisterType(&pgtype.Type{Name: "int2", OID: pgtype.Int2OID, Codec: smallintCodec{}})
+	m.RegisterType(&pgtype.Type{Name: "text", OID: pgtype.TextOID, Codec: textCodec{}})
+	m.RegisterType(&pgtype.Type{Name: "varchar", OID: pgtype.VarcharOID, Codec: textCodec{}})
+}
+
+// smallintCodec also encodes booleans, as 0 and 1
+type smallintCodec struct {
+	pgtype.Int2Codec
+}
+
+func (c smallintCodec) PlanEncode(m *pgtype.Map, oid uint32, format int16, value any) pgtype.EncodePlan {
+	if _, ok := value.(bool); ok {
+		return encodePlanBoolAsSmallint{c.Int2Codec.PlanEncode(m, oid, format, int16(0))}
+	}
+	return c.Int2Codec.PlanEncode(m, oid, format, value)
+}
+
+type encodePlanBoolAsSmallint struct {
+	next pgtype.EncodePlan
+}
+
+func (p encodePlanBoolAsSmallint) Encode(value any, buf []byte) ([]byte, error) {
+	n := int16(0)
+	if value.(bool) {
+		n = 1
+	}
+	return p.next.Encode(n, buf)
+}
+
+// textCodec also encodes integers, in decimal
+type textCodec struct {
+	pgtype.TextCodec
+}
+
+func (c textCodec) PlanEncode(m *pgtype.Map, oid uint32, format int16, value any) pgtype.EncodePlan {
+	switch reflect.ValueOf(value).Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return encodePlanIntegerAsText{}
+	}
+	return c.TextCodec.PlanEncode(m, oid, format, value)
+}
+
+type encodePlanIntegerAsText struct{}
+
+func (encodePlanIntegerAsText) Encode(value any, buf []byte) ([]byte, error) {
+	v := reflect.ValueOf(value)
+	if v.CanInt() {
+		return strconv.AppendInt(buf, v.Int(), 10), nil
+	}
+	return strconv.AppendUint(buf, v.Uint(), 10), nil
+}
+
+// postgresGeometry returns the geometry of the GeoJSON feature, as PostGIS
+// reads bare geometries
+func postgresGeometry(feature []byte) []byte {
+	f, err := geojson.UnmarshalFeature(feature)
+	if err != nil {
+		return feature
+	}
+	geometry, err := geojson.NewGeometry(f.Geometry).MarshalJSON()
+	if err != nil {
+		return feature
+	}
+	return geometry
+}
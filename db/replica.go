@@ -0,0 +1,152 @@
+package db
+
+import (
+	"errors"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/jackc/pgx/v5"
+	"github.com/jmoiron/sqlx"
+	log "github.com/sirupsen/logrus"
+)
+
+// Replica is a read-only copy of a database, kept up to date by the database
+// server's own replication
+type Replica struct {
+	Name string // the replica's address, to tell replicas apart in logs and metrics
+	*sqlx.DB
+
+	// whether the last check reached the replica and found it within the
+	// allowed lag; replicas start unhealthy until checked
+	healthy atomic.Bool
+}
+
+// OpenReplica opens the read replica with the given connection string, which
+// is in the form the driver ("mysql" or "postgres") takes
+func OpenReplica(driver, dsn string) (*Replica, error) {
+	switch driver {
+	case "mysql":
+		config, err := mysql.ParseDSN(dsn)
+		if err != nil {
+			return nil, err
+		}
+		x, err := sqlx.Open(driver, dsn)
+		if err != nil {
+			return nil, err
+		}
+		return &Replica{Name: config.Addr, DB: x}, nil
+	case "postgres":
+		config, err := pgx.ParseConfig(dsn)
+		if err != nil {
+			return nil, err
+		}
+		x, err := OpenPostgres(dsn)
+		if err != nil {
+			return nil, err
+		}
+		return &Replica{Name: config.Host + ":" + strconv.Itoa(int(config.Port)), DB: x}, nil
+	}
+	return nil, errors.New("read replicas need a mysql or postgres database")
+}
+
+var nextReplica atomic.Uint64
+
+// pickReplica takes turns between the healthy replicas, or returns primary if
+// there aren't any
+func pickReplica(primary *sqlx.DB, replicas []*Replica) *sqlx.DB {
+	if len(replicas) == 0 {
+		return primary
+	}
+	start := nextReplica.Add(1)
+	for i := range uint64(len(replicas)) {
+		if replica := replicas[(start+i)%uint64(len(replicas))]; replica.healthy.Load() {
+			return replica.DB
+		}
+	}
+	return primary
+}
+
+// StartReplicaMonitor checks each of a database's replicas now and then every
+// 10 seconds, reporting their lag as the replica lag metric. A replica that
+// can't be reached or is more than maxLag seconds behind is left out of
+// ReadOnly until it recovers; maxLag 0 allows any lag.
+func StartReplicaMonitor(database string, replicas []*Replica, maxLag int) {
+	if len(replicas) == 0 {
+		return
+	}
+	check := func() {
+		for _, replica := range replicas {
+			lag, err := replicaLag(replica.DB)
+			if err != nil {
+				if replica.healthy.Swap(false) {
+					log.Warnf("DB - %s replica %s unavailable, reading from the primary: %s", database, replica.Name, err)
+				}
+				continue
+			}
+			statsCollector.SetReplicaLag(database, replica.Name, lag)
+
+			healthy := maxLag <= 0 || lag <= float64(maxLag)
+			if replica.healthy.Swap(healthy) != healthy {
+				if healthy {
+					log.Infof("DB - %s replica %s in use, %.0fs behind", database, replica.Name, lag)
+				} else {
+					log.Warnf("DB - %s replica %s is %.0fs behind, reading from the primary", database, replica.Name, lag)
+				}
+			}
+		}
+	}
+
+	// check before the first reads, so they don't go to a lagging replica
+	check()
+	ticker := time.NewTicker(10 * time.Second)
+	go func() {
+		for {
+			<-ticker.C
+			check()
+		}
+	}()
+}
+
+// replicaLag returns how many seconds the replica is behind its primary
+func replicaLag(x *sqlx.DB) (float64, error) {
+	if DialectOf(x) == Postgres {
+		// the last replayed transaction ages while the primary is idle, so a
+		// replica that has replayed everything it received counts as caught up
+		var lag float64
+		err := x.Get(&lag, "SELECT CASE WHEN pg_last_wal_receive_lsn() = pg_last_wal_replay_lsn() THEN 0 "+
+			"ELSE COALESCE(CAST(EXTRACT(EPOCH FROM NOW() - pg_last_xact_replay_timestamp()) AS DOUBLE PRECISION), 0) END")
+		return lag, err
+	}
+
+	rows, err := x.Queryx("SHOW REPLICA STATUS")
+	if err != nil {
+		return 0, err
+	}
+	defer rows.Close()
+	if !rows.Next() {
+		if err := rows.Err(); err != nil {
+			return 0, err
+		}
+		return 0, errors.New("not a replica")
+	}
+	status := map[string]any{}
+	if err := rows.MapScan(status); err != nil {
+		return 0, err
+	}
+	// MariaDB still calls the source the master
+	for _, column := range []string{"Seconds_Behind_Source", "Seconds_Behind_Master"} {
+		switch value := status[column].(type) {
+		case []byte:
+			return strconv.ParseFloat(string(value), 64)
+		case int64:
+			return float64(value), nil
+		case nil:
+			if _, ok := status[column]; ok {
+				return 0, errors.New("replication is not running")
+			}
+		}
+	}
+	return 0, errors.New("replica status has no lag")
+}
@@ -0,0 +1,35 @@
+package db
+
+import (
+	"testing"
+
+	"github.com/jmoiron/sqlx"
+)
+
+func TestPickReplica(t *testing.T) {
+	primary := &sqlx.DB{}
+	replicas := []*Replica{{Name: "a", DB: &sqlx.DB{}}, {Name: "b", DB: &sqlx.DB{}}}
+
+	if got := pickReplica(primary, nil); got != primary {
+		t.Error("expected the primary without replicas")
+	}
+	if got := pickReplica(primary, replicas); got != primary {
+		t.Error("expected the primary before any replica is checked")
+	}
+
+	replicas[1].healthy.Store(true)
+	for range 3 {
+		if got := pickReplica(primary, replicas); got != replicas[1].DB {
+			t.Error("expected only the healthy replica")
+		}
+	}
+
+	replicas[0].healthy.Store(true)
+	seen := map[*sqlx.DB]bool{}
+	for range 2 {
+		seen[pickReplica(primary, replicas)] = true
+	}
+	if !seen[replicas[0].DB] || !seen[replicas[1].DB] {
+		t.Error("expected healthy replicas to take turns")
+	}
+}
@@ -0,0 +1,159 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"sync"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/paulmach/orb"
+	"github.com/paulmach/orb/geo"
+	"github.com/paulmach/orb/geojson"
+	"github.com/paulmach/orb/planar"
+	"modernc.org/sqlite"
+)
+
+const sqliteDriverName = "sqlite"
+
+// sqliteDriver is the driver modernc.org/sqlite registers, which is the one
+// that adds the functions registered below to its connections
+var sqliteDriver driver.Driver
+
+func init() {
+	sqlx.BindDriver(sqliteDriverName, sqlx.QUESTION)
+	sqlite.MustRegisterDeterministicScalarFunction("golbat_within", 3, sqliteWithin)
+	sqlite.MustRegisterDeterministicScalarFunction("golbat_distance", 4, sqliteDistance)
+
+	registered, _ := sql.Open(sqliteDriverName, "")
+	sqliteDriver = registered.Driver()
+	_ = registered.Close()
+}
+
+// SQLiteDSN returns the data source name for the SQLite database in the file
+// at path. Writers, transactions included, wait on each other rather than
+// failing with SQLITE_BUSY.
+func SQLiteDSN(path string) string {
+	return "file:" + path + "?_txlock=immediate&_pragma=busy_timeout(10000)&_pragma=journal_mode(WAL)&_pragma=synchronous(NORMAL)"
+}
+
+// OpenSQLite opens the SQLite database with the given data source name
+func OpenSQLite(dsn string) *sqlx.DB {
+	return sqlx.NewDb(sql.OpenDB(sqliteConnector{dsn: dsn}), sqliteDriverName)
+}
+
+type sqliteConnector struct {
+	dsn string
+}
+
+func (c sqliteConnector) Connect(context.Context) (driver.Conn, error) {
+	conn, err := c.Driver().Open(c.dsn)
+	if err != nil {
+		return nil, err
+	}
+	sc, ok := conn.(sqliteDriverConn)
+	if !ok {
+		conn.Close()
+		return nil, errors.New("sqlite: unexpected connection type")
+	}
+	return sqliteConn{sc}, nil
+}
+
+func (c sqliteConnector) Driver() driver.Driver {
+	return sqliteDriver
+}
+
+type sqliteDriverConn interface {
+	driver.Conn
+	driver.ConnBeginTx
+	driver.ConnPrepareContext
+	driver.ExecerContext
+	driver.QueryerContext
+	driver.Pinger
+	driver.SessionResetter
+	driver.Validator
+}
+
+// sqliteConn stores uint64 values too large for a SQLite integer, like s2 cell
+// and tappable ids, as text. database/sql refuses them otherwise.
+type sqliteConn struct {
+	sqliteDriverConn
+}
+
+func (c sqliteConn) CheckNamedValue(nv *driver.NamedValue) error {
+	if v, ok := nv.Value.(uint64); ok && v > math.MaxInt64 {
+		nv.Value = strconv.FormatUint(v, 10)
+		return nil
+	}
+	return driver.ErrSkip
+}
+
+// the last geofence seen by golbat_within, as a query calls it once per row
+var withinCache struct {
+	sync.Mutex
+	feature  string
+	geometry orb.Geometry
+}
+
+// sqliteWithin is golbat_within(feature, lon, lat), which reports whether the
+// point lies inside the GeoJSON feature
+func sqliteWithin(_ *sqlite.FunctionContext, args []driver.Value) (driver.Value, error) {
+	feature, ok := args[0].(string)
+	if !ok {
+		return nil, fmt.Errorf("golbat_within: feature is %T, want text", args[0])
+	}
+	lon, lonOk := sqliteFloat(args[1])
+	lat, latOk := sqliteFloat(args[2])
+	if !lonOk || !latOk {
+		return nil, nil
+	}
+
+	withinCache.Lock()
+	if withinCache.feature != feature {
+		f, err := geojson.UnmarshalFeature([]byte(feature))
+		if err != nil {
+			withinCache.Unlock()
+			return nil, fmt.Errorf("golbat_within: %w", err)
+		}
+		withinCache.feature, withinCache.geometry = feature, f.Geometry
+	}
+	geometry := withinCache.geometry
+	withinCache.Unlock()
+
+	point := orb.Point{lon, lat}
+	switch g := geometry.(type) {
+	case orb.Polygon:
+		return planar.PolygonContains(g, point), nil
+	case orb.MultiPolygon:
+		return planar.MultiPolygonContains(g, point), nil
+	}
+	return false, nil
+}
+
+// sqliteDistance is golbat_distance(lon1, lat1, lon2, lat2), the great circle
+// distance between the points in metres
+func sqliteDistance(_ *sqlite.FunctionContext, args []driver.Value) (driver.Value, error) {
+	var values [4]float64
+	for i, arg := range args {
+		v, ok := sqliteFloat(arg)
+		if !ok {
+			return nil, nil
+		}
+		values[i] = v
+	}
+	return geo.DistanceHaversine(orb.Point{values[0], values[1]}, orb.Point{values[2], values[3]}), nil
+}
+
+func sqliteFloat(value driver.Value) (float64, bool) {
+	switch v := value.(type) {
+	case float64:
+		return v, true
+	case int64:
+		return float64(v), true
+	}
+	return 0, false
+}
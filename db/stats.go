@@ -40,8 +40,8 @@ func GetGymStats(db DbDetails) ([]GymStats, error) {
 
 	// fetch counts for gyms updated within last hour
 	err := db.GeneralDb.Select(&stats,
-		"SELECT count(*) as count, team_id, in_battle "+
-			"FROM `gym` WHERE updated > UNIX_TIMESTAMP() - 3600 GROUP BY team_id, in_battle;",
+		Rebind(db.GeneralDb, "SELECT count(*) as count, team_id, in_battle "+
+			"FROM `gym` WHERE updated > UNIX_TIMESTAMP() - 3600 GROUP BY team_id, in_battle;"),
 	)
 
 	if errors.Is(err, sql.ErrNoRows) {
@@ -59,8 +59,8 @@ func GetRaidStats(db DbDetails) ([]RaidStats, error) {
 	stats := []RaidStats{}
 
 	err := db.GeneralDb.Select(&stats,
-		"SELECT count(*) AS count, COALESCE(raid_level, 0) AS raid_level "+
-			"FROM `gym` WHERE raid_end_timestamp > UNIX_TIMESTAMP() GROUP BY raid_level;",
+		Rebind(db.GeneralDb, "SELECT count(*) AS count, COALESCE(raid_level, 0) AS raid_level "+
+			"FROM `gym` WHERE raid_end_timestamp > UNIX_TIMESTAMP() GROUP BY raid_level;"),
 	)
 
 	if errors.Is(err, sql.ErrNoRows) {
@@ -78,9 +78,9 @@ func GetIncidentsStats(db DbDetails) ([]IncidentsStats, error) {
 	stats := []IncidentsStats{}
 
 	err := db.GeneralDb.Select(&stats,
-		"SELECT count(*) as count, display_type, confirmed "+
+		Rebind(db.GeneralDb, "SELECT count(*) as count, display_type, confirmed "+
 			"FROM `incident` WHERE expiration > UNIX_TIMESTAMP() AND display_type != 0 "+
-			"GROUP BY display_type, confirmed;",
+			"GROUP BY display_type, confirmed;"),
 	)
 
 	if errors.Is(err, sql.ErrNoRows) {
@@ -98,8 +98,8 @@ func GetLureStats(db DbDetails) ([]LureStats, error) {
 	stats := []LureStats{}
 
 	err := db.GeneralDb.Select(&stats,
-		"SELECT count(*) as count, lure_id "+
-			"FROM `pokestop` WHERE lure_expire_timestamp > UNIX_TIMESTAMP() GROUP BY lure_id;",
+		Rebind(db.GeneralDb, "SELECT count(*) as count, lure_id "+
+			"FROM `pokestop` WHERE lure_expire_timestamp > UNIX_TIMESTAMP() GROUP BY lure_id;"),
 	)
 
 	if errors.Is(err, sql.ErrNoRows) {
@@ -130,10 +130,11 @@ func GetQuestStats(db DbDetails) (QuestStats, error) {
 	return stats, nil
 }
 
-func PromLiveStatsUpdater(dbDetails DbDetails, sleepTime int) {
+func PromLiveStatsUpdater(primary DbDetails, sleepTime int) {
 	log.Infof("[Prometheus] LiveStats loop started with %d seconds of sleep", sleepTime)
 	for {
 		start := time.Now()
+		dbDetails := primary.ReadOnly()
 
 		gymStats, err := GetGymStats(dbDetails)
 		if err == nil {
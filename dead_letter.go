@@ -0,0 +1,75 @@
+package main
+
+import (
+	"context"
+
+	"golbat/config"
+	"golbat/deadletter"
+	"golbat/pogo"
+
+	log "github.com/sirupsen/logrus"
+)
+
+var deadLetterStore *deadletter.Store
+
+// InitDeadLetter starts keeping failed payloads when the dead-letter store is
+// enabled in config.
+func InitDeadLetter() {
+	cfg := config.Config.DeadLetter
+	if !cfg.Enabled {
+		return
+	}
+	if cfg.Directory != "" {
+		log.Infof("Keeping dead-letter payloads in memory and in %s", cfg.Directory)
+	} else {
+		log.Infof("Keeping dead-letter payloads in memory")
+	}
+	deadLetterStore = deadletter.NewStore(cfg.MemoryEntries, cfg.Directory, cfg.MaxSize, cfg.MaxBackups)
+}
+
+// CloseDeadLetter closes the dead-letter file, if the store is enabled.
+func CloseDeadLetter() {
+	if deadLetterStore != nil {
+		deadLetterStore.Close()
+	}
+}
+
+// recordDeadLetter keeps a proto's payload in the dead-letter store. It is a
+// no-op when the store is disabled.
+func recordDeadLetter(protoData *ProtoData, method int, reason string) {
+	if deadLetterStore == nil {
+		return
+	}
+	deadLetterStore.Add(&deadletter.Entry{
+		Method:     method,
+		MethodName: pogo.Method(method).String(),
+		Reason:     reason,
+		Uuid:       protoData.Uuid,
+		Account:    protoData.Account,
+		Data:       protoData.Data,
+		Request:    protoData.Request,
+	})
+}
+
+type deadLetterKey struct{}
+
+type deadLetterSource struct {
+	protoData *ProtoData
+	method    int
+}
+
+// withDeadLetter returns a context from which decoders can dead-letter the
+// proto they are decoding.
+func withDeadLetter(ctx context.Context, protoData *ProtoData, method int) context.Context {
+	if deadLetterStore == nil {
+		return ctx
+	}
+	return context.WithValue(ctx, deadLetterKey{}, &deadLetterSource{protoData: protoData, method: method})
+}
+
+// deadLetterFromContext dead-letters the proto carried by ctx, if any.
+func deadLetterFromContext(ctx context.Context, reason string) {
+	if source, ok := ctx.Value(deadLetterKey{}).(*deadLetterSource); ok {
+		recordDeadLetter(source.protoData, source.method, reason)
+	}
+}
@@ -0,0 +1,276 @@
+// Package deadletter keeps the payloads of protos Golbat failed to decode, so
+// the exact bytes can be retrieved when a proto definition changes.
+package deadletter
+
+import (
+	"bufio"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"gopkg.in/natefinch/lumberjack.v2"
+)
+
+const (
+	fileName    = "deadletter.jsonl"
+	maxLineSize = 64 * 1048576
+)
+
+var errFound = errors.New("found")
+
+// Entry is one payload that failed to decode, together with where it came
+// from. Data and Request are base64 encoded by encoding/json.
+type Entry struct {
+	Id         string `json:"id"`
+	ReceivedMs int64  `json:"received_ms"`
+	Method     int    `json:"method"`
+	MethodName string `json:"method_name"`
+	Reason     string `json:"reason"`
+	Uuid       string `json:"uuid,omitempty"`
+	Account    string `json:"account,omitempty"`
+	Data       []byte `json:"data,omitempty"`
+	Request    []byte `json:"request,omitempty"`
+}
+
+// Summary describes an entry without its payload.
+type Summary struct {
+	Id          string `json:"id"`
+	ReceivedMs  int64  `json:"received_ms"`
+	Method      int    `json:"method"`
+	MethodName  string `json:"method_name"`
+	Reason      string `json:"reason"`
+	Uuid        string `json:"uuid,omitempty"`
+	Account     string `json:"account,omitempty"`
+	DataSize    int    `json:"data_size"`
+	RequestSize int    `json:"request_size"`
+}
+
+func (e *Entry) Summary() Summary {
+	return Summary{
+		Id:          e.Id,
+		ReceivedMs:  e.ReceivedMs,
+		Method:      e.Method,
+		MethodName:  e.MethodName,
+		Reason:      e.Reason,
+		Uuid:        e.Uuid,
+		Account:     e.Account,
+		DataSize:    len(e.Data),
+		RequestSize: len(e.Request),
+	}
+}
+
+// Store holds the most recent entries in memory and, when given a directory,
+// appends every entry to rotating files there. The files form a ring: once
+// maxBackups rotated files exist the oldest is removed.
+type Store struct {
+	mu        sync.Mutex
+	ring      []*Entry
+	next      int
+	count     int
+	seq       uint64
+	directory string
+	out       *lumberjack.Logger
+}
+
+// NewStore creates a store keeping memoryEntries entries in memory. If
+// directory is not blank entries are also written there, rotating at maxSize
+// MB and keeping maxBackups rotated files, and the most recent entries already
+// on disk are loaded into memory.
+func NewStore(memoryEntries int, directory string, maxSize, maxBackups int) *Store {
+	if memoryEntries <= 0 {
+		memoryEntries = 1
+	}
+	s := &Store{ring: make([]*Entry, memoryEntries), directory: directory}
+	if directory == "" {
+		return s
+	}
+
+	files, err := s.files()
+	if err != nil {
+		log.Warnf("DeadLetter: unable to read existing entries: %s", err)
+	}
+	for _, file := range files {
+		err := readFile(file, func(e *Entry) error {
+			s.push(e)
+			return nil
+		})
+		if err != nil {
+			log.Warnf("DeadLetter: unable to read %s: %s", file, err)
+		}
+	}
+
+	s.out = &lumberjack.Logger{
+		Filename:   filepath.Join(directory, fileName),
+		MaxSize:    maxSize,
+		MaxBackups: maxBackups,
+	}
+	return s
+}
+
+// Add records an entry, assigning its id and received time.
+func (s *Store) Add(e *Entry) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.seq++
+	if e.ReceivedMs == 0 {
+		e.ReceivedMs = time.Now().UnixMilli()
+	}
+	e.Id = fmt.Sprintf("%d-%d", e.ReceivedMs, s.seq)
+	s.push(e)
+
+	if s.out != nil {
+		line, err := json.Marshal(e)
+		if err != nil {
+			log.Errorf("DeadLetter: failed to encode entry: %s", err)
+			return
+		}
+		if _, err := s.out.Write(append(line, '\n')); err != nil {
+			log.Errorf("DeadLetter: failed to write entry: %s", err)
+		}
+	}
+}
+
+func (s *Store) push(e *Entry) {
+	s.ring[s.next] = e
+	s.next = (s.next + 1) % len(s.ring)
+	if s.count < len(s.ring) {
+		s.count++
+	}
+}
+
+// List returns the entries held in memory, newest first.
+func (s *Store) List() []Summary {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	result := make([]Summary, 0, s.count)
+	for i := 1; i <= s.count; i++ {
+		e := s.ring[(s.next-i+len(s.ring))%len(s.ring)]
+		result = append(result, e.Summary())
+	}
+	return result
+}
+
+// Get returns the entry with the given id, looking on disk if it is no longer
+// held in memory.
+func (s *Store) Get(id string) (*Entry, error) {
+	s.mu.Lock()
+	for i := 0; i < s.count; i++ {
+		if e := s.ring[i]; e.Id == id {
+			s.mu.Unlock()
+			return e, nil
+		}
+	}
+	s.mu.Unlock()
+
+	if s.directory == "" {
+		return nil, nil
+	}
+	files, err := s.files()
+	if err != nil {
+		return nil, err
+	}
+	var found *Entry
+	for _, file := range files {
+		err := readFile(file, func(e *Entry) error {
+			if e.Id == id {
+				found = e
+				return errFound
+			}
+			return nil
+		})
+		if errors.Is(err, errFound) {
+			return found, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	return nil, nil
+}
+
+// Close closes the current file, if any.
+func (s *Store) Close() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.out != nil {
+		if err := s.out.Close(); err != nil {
+			log.Errorf("DeadLetter: failed to close: %s", err)
+		}
+	}
+}
+
+// files returns the dead-letter files in the directory, oldest first.
+func (s *Store) files() ([]string, error) {
+	entries, err := os.ReadDir(s.directory)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	prefix := strings.TrimSuffix(fileName, ".jsonl")
+	modTimes := make(map[string]time.Time)
+	var files []string
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasPrefix(name, prefix) || !(strings.HasSuffix(name, ".jsonl") || strings.HasSuffix(name, ".jsonl.gz")) {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			continue
+		}
+		file := filepath.Join(s.directory, name)
+		files = append(files, file)
+		modTimes[file] = info.ModTime()
+	}
+	sort.SliceStable(files, func(i, j int) bool { return modTimes[files[i]].Before(modTimes[files[j]]) })
+	return files, nil
+}
+
+func readFile(file string, fn func(*Entry) error) error {
+	f, err := os.Open(file)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	var r io.Reader = f
+	if strings.HasSuffix(file, ".gz") {
+		gz, err := gzip.NewReader(f)
+		if err != nil {
+			return err
+		}
+		defer gz.Close()
+		r = gz
+	}
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 1048576), maxLineSize)
+	for scanner.Scan() {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		var e Entry
+		if err := json.Unmarshal(scanner.Bytes(), &e); err != nil {
+			// a partially written last line is expected after a crash
+			continue
+		}
+		if err := fn(&e); err != nil {
+			return err
+		}
+	}
+	return scanner.Err()
+}
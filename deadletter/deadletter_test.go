@@ -0,0 +1,58 @@
+package deadletter
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestStoreKeepsMostRecentInMemory(t *testing.T) {
+	s := NewStore(2, "", 0, 0)
+	for _, method := range []int{1, 2, 3} {
+		s.Add(&Entry{Method: method, Reason: "parse", Data: []byte{byte(method)}})
+	}
+
+	list := s.List()
+	if len(list) != 2 {
+		t.Fatalf("listed %d entries, want 2", len(list))
+	}
+	if list[0].Method != 3 || list[1].Method != 2 {
+		t.Errorf("listed methods %d, %d, want newest first 3, 2", list[0].Method, list[1].Method)
+	}
+	if list[0].DataSize != 1 {
+		t.Errorf("data size = %d, want 1", list[0].DataSize)
+	}
+
+	e, err := s.Get(list[1].Id)
+	if err != nil || e == nil || e.Method != 2 {
+		t.Errorf("Get(%s) = %+v, %v", list[1].Id, e, err)
+	}
+}
+
+func TestStoreFallsBackToDisk(t *testing.T) {
+	dir := t.TempDir()
+	s := NewStore(1, dir, 10, 1)
+	s.Add(&Entry{Method: 106, Reason: "parse", Data: []byte("first"), Request: []byte("req")})
+	firstId := s.List()[0].Id
+	s.Add(&Entry{Method: 102, Reason: "unprocessed", Data: []byte("second")})
+
+	// The first entry has been pushed out of memory but is still on disk.
+	e, err := s.Get(firstId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if e == nil || !bytes.Equal(e.Data, []byte("first")) || !bytes.Equal(e.Request, []byte("req")) {
+		t.Fatalf("Get(%s) = %+v, want the first entry from disk", firstId, e)
+	}
+	if e, _ := s.Get("missing"); e != nil {
+		t.Errorf("Get(missing) = %+v, want nil", e)
+	}
+	s.Close()
+
+	// A new store picks up the most recent entries from disk.
+	reopened := NewStore(5, dir, 10, 1)
+	defer reopened.Close()
+	list := reopened.List()
+	if len(list) != 2 || list[0].Method != 102 || list[1].Id != firstId {
+		t.Errorf("reopened store lists %+v", list)
+	}
+}
@@ -24,8 +24,12 @@ func decode(ctx context.Context, method int, protoData *ProtoData) {
 		return fmt.Sprintf("#%d", method)
 	}
 
+	ctx = withIngestHealth(ctx, protoData, getMethodName(method, true))
+	ctx = withDeadLetter(ctx, protoData, method)
+
 	if method != int(pogo.InternalPlatformClientAction_INTERNAL_PROXY_SOCIAL_ACTION) && protoData.Level < 30 {
 		statsCollector.IncDecodeMethods("error", "low_level", getMethodName(method, true))
+		noteLowLevel(ctx)
 		log.Debugf("Insufficient Level %d Did not process hook type %s", protoData.Level, pogo.Method(method))
 		return
 	}
@@ -72,7 +76,7 @@ func decode(ctx context.Context, method int, protoData *ProtoData) {
 		ignore = true
 	case pogo.Method(pogo.InternalPlatformClientAction_INTERNAL_PROXY_SOCIAL_ACTION):
 		if protoData.Request != nil {
-			result = decodeSocialActionWithRequest(protoData.Request, protoData.Data)
+			result = decodeSocialActionWithRequest(ctx, protoData.Request, protoData.Data)
 			processed = true
 		}
 	case pogo.Method_METHOD_GET_MAP_FORTS:
@@ -119,6 +123,7 @@ func decode(ctx context.Context, method int, protoData *ProtoData) {
 		processed = true
 	default:
 		log.Debugf("Did not know hook type %s", pogo.Method(method))
+		recordDeadLetter(protoData, method, "unprocessed")
 	}
 	if !ignore {
 		elapsed := time.Since(start)
@@ -132,13 +137,22 @@ func decode(ctx context.Context, method int, protoData *ProtoData) {
 	}
 }
 
+// decodeFailed records a proto that could not be decoded against the health of
+// the device and account that sent it, and keeps its payload in the dead-letter
+// store.
+func decodeFailed(ctx context.Context, reason string) {
+	noteDecodeError(ctx)
+	deadLetterFromContext(ctx, reason)
+}
+
 func getScanParameters(protoData *ProtoData) decoder.ScanParameters {
-	return decoder.FindScanConfiguration(protoData.ScanContext, protoData.Lat, protoData.Lon)
+	return decoder.FindScanConfiguration(protoData.ScanContext, protoData.Token, protoData.Lat, protoData.Lon)
 }
 
 func decodeQuest(ctx context.Context, sDec []byte, haveAr *bool) string {
 	if haveAr == nil {
 		statsCollector.IncDecodeQuest("error", "missing_ar_info")
+		decodeFailed(ctx, "missing_ar_info")
 		log.Infoln("Cannot determine AR quest - ignoring")
 		// We should either assume AR quest, or trace inventory like RDM probably
 		return "No AR quest info"
@@ -147,11 +161,13 @@ func decodeQuest(ctx context.Context, sDec []byte, haveAr *bool) string {
 	if err := proto.Unmarshal(sDec, decodedQuest); err != nil {
 		log.Errorf("Failed to parse %s", err)
 		statsCollector.IncDecodeQuest("error", "parse")
+		decodeFailed(ctx, "parse")
 		return "Parse failure"
 	}
 
 	if decodedQuest.Result != pogo.FortSearchOutProto_SUCCESS {
 		statsCollector.IncDecodeQuest("error", "non_success")
+		noteDecodeError(ctx)
 		res := fmt.Sprintf(`GymGetInfoOutProto: Ignored non-success value %d:%s`, decodedQuest.Result,
 			pogo.FortSearchOutProto_Result_name[int32(decodedQuest.Result)])
 		return res
@@ -161,12 +177,13 @@ func decodeQuest(ctx context.Context, sDec []byte, haveAr *bool) string {
 
 }
 
-func decodeSocialActionWithRequest(request []byte, payload []byte) string {
+func decodeSocialActionWithRequest(ctx context.Context, request []byte, payload []byte) string {
 	var proxyRequestProto pogo.ProxyRequestProto
 
 	if err := proto.Unmarshal(request, &proxyRequestProto); err != nil {
 		log.Errorf("Failed to parse %s", err)
 		statsCollector.IncDecodeSocialActionWithRequest("error", "request_parse")
+		decodeFailed(ctx, "request_parse")
 		return fmt.Sprintf("Failed to parse %s", err)
 	}
 
@@ -175,21 +192,23 @@ func decodeSocialActionWithRequest(request []byte, payload []byte) string {
 	if err := proto.Unmarshal(payload, &proxyResponseProto); err != nil {
 		log.Errorf("Failed to parse %s", err)
 		statsCollector.IncDecodeSocialActionWithRequest("error", "response_parse")
+		decodeFailed(ctx, "response_parse")
 		return fmt.Sprintf("Failed to parse %s", err)
 	}
 
 	if proxyResponseProto.Status != pogo.ProxyResponseProto_COMPLETED && proxyResponseProto.Status != pogo.ProxyResponseProto_COMPLETED_AND_REASSIGNED {
 		statsCollector.IncDecodeSocialActionWithRequest("error", "non_success")
+		noteDecodeError(ctx)
 		return fmt.Sprintf("unsuccessful proxyResponseProto response %d %s", int(proxyResponseProto.Status), proxyResponseProto.Status)
 	}
 
 	switch pogo.InternalSocialAction(proxyRequestProto.GetAction()) {
 	case pogo.InternalSocialAction_SOCIAL_ACTION_LIST_FRIEND_STATUS:
 		statsCollector.IncDecodeSocialActionWithRequest("ok", "list_friend_status")
-		return decodeGetFriendDetails(proxyResponseProto.Payload)
+		return decodeGetFriendDetails(ctx, proxyResponseProto.Payload)
 	case pogo.InternalSocialAction_SOCIAL_ACTION_SEARCH_PLAYER:
 		statsCollector.IncDecodeSocialActionWithRequest("ok", "search_player")
-		return decodeSearchPlayer(&proxyRequestProto, proxyResponseProto.Payload)
+		return decodeSearchPlayer(ctx, &proxyRequestProto, proxyResponseProto.Payload)
 
 	}
 
@@ -197,18 +216,20 @@ func decodeSocialActionWithRequest(request []byte, payload []byte) string {
 	return fmt.Sprintf("Did not process %s", pogo.InternalSocialAction(proxyRequestProto.GetAction()).String())
 }
 
-func decodeGetFriendDetails(payload []byte) string {
+func decodeGetFriendDetails(ctx context.Context, payload []byte) string {
 	var getFriendDetailsOutProto pogo.InternalGetFriendDetailsOutProto
 	getFriendDetailsError := proto.Unmarshal(payload, &getFriendDetailsOutProto)
 
 	if getFriendDetailsError != nil {
 		statsCollector.IncDecodeGetFriendDetails("error", "parse")
+		decodeFailed(ctx, "parse")
 		log.Errorf("Failed to parse %s", getFriendDetailsError)
 		return fmt.Sprintf("Failed to parse %s", getFriendDetailsError)
 	}
 
 	if getFriendDetailsOutProto.GetResult() != pogo.InternalGetFriendDetailsOutProto_SUCCESS || getFriendDetailsOutProto.GetFriend() == nil {
 		statsCollector.IncDecodeGetFriendDetails("error", "non_success")
+		noteDecodeError(ctx)
 		return "unsuccessful get friends details"
 	}
 
@@ -227,18 +248,20 @@ func decodeGetFriendDetails(payload []byte) string {
 	return fmt.Sprintf("%d players decoded on %d", len(getFriendDetailsOutProto.GetFriend())-failures, len(getFriendDetailsOutProto.GetFriend()))
 }
 
-func decodeSearchPlayer(proxyRequestProto *pogo.ProxyRequestProto, payload []byte) string {
+func decodeSearchPlayer(ctx context.Context, proxyRequestProto *pogo.ProxyRequestProto, payload []byte) string {
 	var searchPlayerOutProto pogo.InternalSearchPlayerOutProto
 	searchPlayerOutError := proto.Unmarshal(payload, &searchPlayerOutProto)
 
 	if searchPlayerOutError != nil {
 		log.Errorf("Failed to parse %s", searchPlayerOutError)
 		statsCollector.IncDecodeSearchPlayer("error", "parse")
+		decodeFailed(ctx, "parse")
 		return fmt.Sprintf("Failed to parse %s", searchPlayerOutError)
 	}
 
 	if searchPlayerOutProto.GetResult() != pogo.InternalSearchPlayerOutProto_SUCCESS || searchPlayerOutProto.GetPlayer() == nil {
 		statsCollector.IncDecodeSearchPlayer("error", "non_success")
+		noteDecodeError(ctx)
 		return "unsuccessful search player response"
 	}
 
@@ -247,6 +270,7 @@ func decodeSearchPlayer(proxyRequestProto *pogo.ProxyRequestProto, payload []byt
 
 	if searchPlayerError != nil || searchPlayerProto.GetFriendCode() == "" {
 		statsCollector.IncDecodeSearchPlayer("error", "parse")
+		decodeFailed(ctx, "parse")
 		return fmt.Sprintf("Failed to parse %s", searchPlayerError)
 	}
 
@@ -254,6 +278,7 @@ func decodeSearchPlayer(proxyRequestProto *pogo.ProxyRequestProto, payload []byt
 	updatePlayerError := decoder.UpdatePlayerRecordWithPlayerSummary(dbDetails, player, player.PublicData, searchPlayerProto.GetFriendCode(), "")
 	if updatePlayerError != nil {
 		statsCollector.IncDecodeSearchPlayer("error", "update")
+		decodeFailed(ctx, "update")
 		return fmt.Sprintf("Failed update player %s", updatePlayerError)
 	}
 
@@ -266,6 +291,7 @@ func decodeFortDetails(ctx context.Context, sDec []byte) string {
 	if err := proto.Unmarshal(sDec, decodedFort); err != nil {
 		log.Errorf("Failed to parse %s", err)
 		statsCollector.IncDecodeFortDetails("error", "parse")
+		decodeFailed(ctx, "parse")
 		return fmt.Sprintf("Failed to parse %s", err)
 	}
 
@@ -287,11 +313,13 @@ func decodeGetMapForts(ctx context.Context, sDec []byte) string {
 	if err := proto.Unmarshal(sDec, decodedMapForts); err != nil {
 		log.Errorf("Failed to parse %s", err)
 		statsCollector.IncDecodeGetMapForts("error", "parse")
+		decodeFailed(ctx, "parse")
 		return fmt.Sprintf("Failed to parse %s", err)
 	}
 
 	if decodedMapForts.Status != pogo.GetMapFortsOutProto_SUCCESS {
 		statsCollector.IncDecodeGetMapForts("error", "non_success")
+		noteDecodeError(ctx)
 		res := fmt.Sprintf(`GetMapFortsOutProto: Ignored non-success value %d:%s`, decodedMapForts.Status,
 			pogo.GetMapFortsOutProto_Status_name[int32(decodedMapForts.Status)])
 		return res
@@ -361,11 +389,13 @@ func decodeGetGymInfo(ctx context.Context, sDec []byte) string {
 	if err := proto.Unmarshal(sDec, decodedGymInfo); err != nil {
 		log.Errorf("Failed to parse %s", err)
 		statsCollector.IncDecodeGetGymInfo("error", "parse")
+		decodeFailed(ctx, "parse")
 		return fmt.Sprintf("Failed to parse %s", err)
 	}
 
 	if decodedGymInfo.Result != pogo.GymGetInfoOutProto_SUCCESS {
 		statsCollector.IncDecodeGetGymInfo("error", "non_success")
+		noteDecodeError(ctx)
 		res := fmt.Sprintf(`GymGetInfoOutProto: Ignored non-success value %d:%s`, decodedGymInfo.Result,
 			pogo.GymGetInfoOutProto_Result_name[int32(decodedGymInfo.Result)])
 		return res
@@ -380,11 +410,13 @@ func decodeEncounter(ctx context.Context, sDec []byte, username string, timestam
 	if err := proto.Unmarshal(sDec, decodedEncounterInfo); err != nil {
 		log.Errorf("Failed to parse %s", err)
 		statsCollector.IncDecodeEncounter("error", "parse")
+		decodeFailed(ctx, "parse")
 		return fmt.Sprintf("Failed to parse %s", err)
 	}
 
 	if decodedEncounterInfo.Status != pogo.EncounterOutProto_ENCOUNTER_SUCCESS {
 		statsCollector.IncDecodeEncounter("error", "non_success")
+		noteDecodeError(ctx)
 		res := fmt.Sprintf(`EncounterOutProto: Ignored non-success value %d:%s`, decodedEncounterInfo.Status,
 			pogo.EncounterOutProto_Status_name[int32(decodedEncounterInfo.Status)])
 		return res
@@ -399,11 +431,13 @@ func decodeDiskEncounter(ctx context.Context, sDec []byte, username string) stri
 	if err := proto.Unmarshal(sDec, decodedEncounterInfo); err != nil {
 		log.Errorf("Failed to parse %s", err)
 		statsCollector.IncDecodeDiskEncounter("error", "parse")
+		decodeFailed(ctx, "parse")
 		return fmt.Sprintf("Failed to parse %s", err)
 	}
 
 	if decodedEncounterInfo.Result != pogo.DiskEncounterOutProto_SUCCESS {
 		statsCollector.IncDecodeDiskEncounter("error", "non_success")
+		noteDecodeError(ctx)
 		res := fmt.Sprintf(`DiskEncounterOutProto: Ignored non-success value %d:%s`, decodedEncounterInfo.Result,
 			pogo.DiskEncounterOutProto_Result_name[int32(decodedEncounterInfo.Result)])
 		return res
@@ -418,11 +452,13 @@ func decodeStartIncident(ctx context.Context, sDec []byte) string {
 	if err := proto.Unmarshal(sDec, decodedIncident); err != nil {
 		log.Errorf("Failed to parse %s", err)
 		statsCollector.IncDecodeStartIncident("error", "parse")
+		decodeFailed(ctx, "parse")
 		return fmt.Sprintf("Failed to parse %s", err)
 	}
 
 	if decodedIncident.Status != pogo.StartIncidentOutProto_SUCCESS {
 		statsCollector.IncDecodeStartIncident("error", "non_success")
+		noteDecodeError(ctx)
 		res := fmt.Sprintf(`GiovanniOutProto: Ignored non-success value %d:%s`, decodedIncident.Status,
 			pogo.StartIncidentOutProto_Status_name[int32(decodedIncident.Status)])
 		return res
@@ -438,6 +474,7 @@ func decodeOpenInvasion(ctx context.Context, request []byte, payload []byte) str
 	if err := proto.Unmarshal(request, decodeOpenInvasionRequest); err != nil {
 		log.Errorf("Failed to parse %s", err)
 		statsCollector.IncDecodeOpenInvasion("error", "parse")
+		decodeFailed(ctx, "parse")
 		return fmt.Sprintf("Failed to parse %s", err)
 	}
 	if decodeOpenInvasionRequest.IncidentLookup == nil {
@@ -448,11 +485,13 @@ func decodeOpenInvasion(ctx context.Context, request []byte, payload []byte) str
 	if err := proto.Unmarshal(payload, decodedOpenInvasionResponse); err != nil {
 		log.Errorf("Failed to parse %s", err)
 		statsCollector.IncDecodeOpenInvasion("error", "parse")
+		decodeFailed(ctx, "parse")
 		return fmt.Sprintf("Failed to parse %s", err)
 	}
 
 	if decodedOpenInvasionResponse.Status != pogo.InvasionStatus_SUCCESS {
 		statsCollector.IncDecodeOpenInvasion("error", "non_success")
+		noteDecodeError(ctx)
 		res := fmt.Sprintf(`InvasionLineupOutProto: Ignored non-success value %d:%s`, decodedOpenInvasionResponse.Status,
 			pogo.InvasionStatus_Status_name[int32(decodedOpenInvasionResponse.Status)])
 		return res
@@ -468,10 +507,13 @@ func decodeGMO(ctx context.Context, protoData *ProtoData, scanParameters decoder
 	if err := proto.Unmarshal(protoData.Data, decodedGmo); err != nil {
 		statsCollector.IncDecodeGMO("error", "parse")
 		log.Errorf("Failed to parse %s", err)
+		decodeFailed(ctx, "parse")
+		return fmt.Sprintf("Failed to parse %s", err)
 	}
 
 	if decodedGmo.Status != pogo.GetMapObjectsOutProto_SUCCESS {
 		statsCollector.IncDecodeGMO("error", "non_success")
+		noteDecodeError(ctx)
 		res := fmt.Sprintf(`GetMapObjectsOutProto: Ignored non-success value %d:%s`, decodedGmo.Status,
 			pogo.GetMapObjectsOutProto_Status_name[int32(decodedGmo.Status)])
 		return res
@@ -489,19 +531,35 @@ func decodeGMO(ctx context.Context, protoData *ProtoData, scanParameters decoder
 	cellForts := make(map[uint64]*decoder.FortTrackerGMOContents)
 
 	if len(decodedGmo.MapCell) == 0 {
+		noteGmo(ctx, true)
 		return "Skipping GetMapObjectsOutProto: No map cells found"
 	}
+	var cellWeather map[int64]*pogo.ClientWeatherProto
+	if gmoCellHashes != nil {
+		cellWeather = make(map[int64]*pogo.ClientWeatherProto, len(decodedGmo.ClientWeather))
+		for _, weather := range decodedGmo.ClientWeather {
+			cellWeather[weather.S2CellId] = weather
+		}
+	}
+	skippedCells := 0
+
 	for _, mapCell := range decodedGmo.MapCell {
+		if isCellNotEmpty(mapCell) {
+			newMapCells = append(newMapCells, mapCell.S2CellId)
+		}
+
+		// another device sent this cell with the same content moments ago
+		if gmoCellUnchanged(mapCell, cellWeather, scanParameters) {
+			skippedCells++
+			continue
+		}
+
 		cellForts[mapCell.S2CellId] = &decoder.FortTrackerGMOContents{
 			Pokestops: make([]string, 0),
 			Gyms:      make([]string, 0),
 			Timestamp: mapCell.AsOfTimeMs,
 		}
 
-		if isCellNotEmpty(mapCell) {
-			newMapCells = append(newMapCells, mapCell.S2CellId)
-		}
-
 		for _, fort := range mapCell.Fort {
 			newForts = append(newForts, decoder.RawFortData{Cell: mapCell.S2CellId, Data: fort, Timestamp: mapCell.AsOfTimeMs})
 
@@ -570,6 +628,7 @@ func decodeGMO(ctx context.Context, protoData *ProtoData, scanParameters decoder
 	newMapCellsLen := len(newMapCells)
 
 	statsCollector.IncDecodeGMO("ok", "")
+	noteGmo(ctx, newMapCellsLen == 0)
 	statsCollector.AddDecodeGMOType("fort", float64(newFortsLen))
 	statsCollector.AddDecodeGMOType("station", float64(newStationsLen))
 	statsCollector.AddDecodeGMOType("wild_pokemon", float64(newWildPokemonLen))
@@ -577,8 +636,12 @@ func decodeGMO(ctx context.Context, protoData *ProtoData, scanParameters decoder
 	statsCollector.AddDecodeGMOType("map_pokemon", float64(newMapPokemonLen))
 	statsCollector.AddDecodeGMOType("weather", float64(newClientWeatherLen))
 	statsCollector.AddDecodeGMOType("cell", float64(newMapCellsLen))
+	if skippedCells > 0 {
+		statsCollector.AddGmoCellsDeduplicated(float64(skippedCells))
+		noteGmoCellsSkipped(ctx, skippedCells)
+	}
 
-	return fmt.Sprintf("%d cells containing %d forts %d stations %d mon %d nearby", newMapCellsLen, newFortsLen, newStationsLen, newWildPokemonLen, newNearbyPokemonLen)
+	return fmt.Sprintf("%d cells (%d unchanged) containing %d forts %d stations %d mon %d nearby", newMapCellsLen, skippedCells, newFortsLen, newStationsLen, newWildPokemonLen, newNearbyPokemonLen)
 }
 
 func isCellNotEmpty(mapCell *pogo.ClientMapCellProto) bool {
@@ -710,6 +773,11 @@ func decodeGetEventRsvpCount(ctx context.Context, data []byte) string {
 		return fmt.Sprintf("Failed to parse GetEventRsvpCountOutProto %s", err)
 	}
 
+	return clearEmptyRsvps(ctx, &rsvp)
+}
+
+// clearEmptyRsvps clears the RSVPs of the gyms in rsvp that nobody is going to.
+func clearEmptyRsvps(ctx context.Context, rsvp *pogo.GetEventRsvpCountOutProto) string {
 	if rsvp.Status != pogo.GetEventRsvpCountOutProto_SUCCESS {
 		return fmt.Sprintf("Ignored GetEventRsvpCountOutProto non-success status %s", rsvp.Status)
 	}
@@ -27,6 +27,26 @@ func decodeNebula(ctx context.Context, endpoint string, nd *NebulaData) string {
 			result = "unknown endpoint"
 		}
 		log.Debugf("Nebula invasion/%s %s - %s - %s", endpoint, nd.BattleId, time.Since(start), result)
+	case nd.Raid != nil:
+		switch endpoint {
+		case "get-state":
+			result = decodeNebulaRaidState(ctx, nd.Raid.FortId, nd.Raid.RaidSeed, nd.Data)
+		case "get-time", "send-player-event":
+			result = "ignored (not needed for boss)"
+		default:
+			result = "unknown endpoint"
+		}
+		log.Debugf("Nebula raid/%s %s - %s - %s", endpoint, nd.BattleId, time.Since(start), result)
+	case nd.MaxBattle != nil:
+		switch endpoint {
+		case "get-state":
+			result = decodeNebulaMaxBattleState(ctx, nd.MaxBattle.StationId, nd.MaxBattle.BreadBattleSeed, nd.Data)
+		case "get-time", "send-player-event":
+			result = "ignored (not needed for boss)"
+		default:
+			result = "unknown endpoint"
+		}
+		log.Debugf("Nebula max_battle/%s %s - %s - %s", endpoint, nd.BattleId, time.Since(start), result)
 	default:
 		log.Warnf("Nebula: no recognised context (endpoint %s, battle %s)", endpoint, nd.BattleId)
 		result = "no context"
@@ -41,3 +61,19 @@ func decodeNebulaInvasionState(ctx context.Context, fortId, incidentId string, p
 	}
 	return decoder.UpdateIncidentLineupFromBattleState(ctx, dbDetails, fortId, incidentId, &out)
 }
+
+func decodeNebulaRaidState(ctx context.Context, fortId string, raidSeed int64, payload []byte) string {
+	var out pogo.BattleStateOutProto
+	if err := proto.Unmarshal(payload, &out); err != nil {
+		return "failed to parse BattleStateOutProto"
+	}
+	return decoder.UpdateGymRaidFromBattleState(ctx, dbDetails, fortId, raidSeed, &out)
+}
+
+func decodeNebulaMaxBattleState(ctx context.Context, stationId string, battleSeed int64, payload []byte) string {
+	var out pogo.BattleStateOutProto
+	if err := proto.Unmarshal(payload, &out); err != nil {
+		return "failed to parse BattleStateOutProto"
+	}
+	return decoder.UpdateStationBattleFromBattleState(ctx, dbDetails, stationId, battleSeed, &out)
+}
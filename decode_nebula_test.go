@@ -25,3 +25,17 @@ func TestDecodeNebula_NoContextIsRejected(t *testing.T) {
 		t.Errorf("got %q, want \"no context\"", got)
 	}
 }
+
+func TestDecodeNebula_RoutesRaidAndMaxBattle(t *testing.T) {
+	raid := &NebulaData{Endpoint: "get-time", Raid: &nebulaRaidContext{FortId: "G", RaidSeed: 1}}
+	if got := decodeNebula(context.Background(), "get-time", raid); got != "ignored (not needed for boss)" {
+		t.Errorf("raid get-time = %q, want ignored", got)
+	}
+	maxBattle := &NebulaData{Endpoint: "get-time", MaxBattle: &nebulaMaxBattleContext{StationId: "S", BreadBattleSeed: 2}}
+	if got := decodeNebula(context.Background(), "get-time", maxBattle); got != "ignored (not needed for boss)" {
+		t.Errorf("max battle get-time = %q, want ignored", got)
+	}
+	if got := decodeNebula(context.Background(), "get-state", &NebulaData{MaxBattle: &nebulaMaxBattleContext{}, Data: []byte{0xff}}); got != "failed to parse BattleStateOutProto" {
+		t.Errorf("malformed max battle state = %q", got)
+	}
+}
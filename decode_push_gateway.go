@@ -2,38 +2,185 @@ package main
 
 import (
 	"context"
+	"fmt"
 
 	"golbat/decoder"
 	"golbat/pogo"
 
+	"github.com/golang/geo/s2"
 	log "github.com/sirupsen/logrus"
 	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
 )
 
+// pushGatewayMessage is the content of one PushGatewayMessage case, with the
+// batch it was forwarded in.
+type pushGatewayMessage struct {
+	Content        proto.Message
+	PubTimestampMs int64
+	Batch          *RawBatch
+}
+
+// scanParameters returns the scan rule for content at lat, lon.
+func (m *pushGatewayMessage) scanParameters(lat, lon float64) decoder.ScanParameters {
+	if m.Batch == nil {
+		return decoder.FindScanConfiguration("", "", lat, lon)
+	}
+	return decoder.FindScanConfiguration(m.Batch.ScanContext, m.Batch.Token, lat, lon)
+}
+
+type pushGatewayHandler func(ctx context.Context, m *pushGatewayMessage) string
+
+var (
+	// pushGatewayContentHandlers holds the registered handlers by the proto type
+	// they accept.
+	pushGatewayContentHandlers = make(map[protoreflect.FullName]pushGatewayHandler)
+	// pushGatewayHandlers holds the handlers by message_type, which is the name
+	// of the PushGatewayMessage case. Every case carrying a registered type is
+	// routed, so new cases reusing a known type need no code here.
+	pushGatewayHandlers map[string]pushGatewayHandler
+	// pushGatewayMessageTypes is every message_type PushGatewayMessage defines;
+	// anything else is counted as unknown to bound the metric labels.
+	pushGatewayMessageTypes map[string]bool
+)
+
+func registerPushGatewayHandler(content proto.Message, handler pushGatewayHandler) {
+	pushGatewayContentHandlers[content.ProtoReflect().Descriptor().FullName()] = handler
+}
+
+func init() {
+	registerPushGatewayHandler(&pogo.RaidLobbyCounterData{}, handlePushRaidLobby)
+	registerPushGatewayHandler(&pogo.BreadLobbyCounterData{}, handlePushBreadLobby)
+	registerPushGatewayHandler(&pogo.PokemonFortProto{}, handlePushFort)
+	registerPushGatewayHandler(&pogo.StationProto{}, handlePushStation)
+	registerPushGatewayHandler(&pogo.GetEventRsvpCountOutProto{}, handlePushRsvpCount)
+
+	pushGatewayHandlers = make(map[string]pushGatewayHandler)
+	pushGatewayMessageTypes = make(map[string]bool)
+	oneofs := (&pogo.PushGatewayMessage{}).ProtoReflect().Descriptor().Oneofs()
+	for i := 0; i < oneofs.Len(); i++ {
+		fields := oneofs.Get(i).Fields()
+		for j := 0; j < fields.Len(); j++ {
+			field := fields.Get(j)
+			messageType := string(field.Name())
+			pushGatewayMessageTypes[messageType] = true
+			if field.Message() == nil {
+				continue
+			}
+			if handler, ok := pushGatewayContentHandlers[field.Message().FullName()]; ok {
+				pushGatewayHandlers[messageType] = handler
+			}
+		}
+	}
+}
+
 // decodePushGateway classifies a push-gateway message by message_type, unmarshals
-// the PushGatewayMessage, and dispatches to the appropriate decoder update path.
-// Unknown message types are gated before unmarshal to avoid unnecessary work.
-func decodePushGateway(ctx context.Context, messageType string, payload []byte) {
-	switch messageType {
-	case "raid_lobby_player_count", "bread_lobby_player_count":
-		// handled below
-	default:
+// the PushGatewayMessage, and dispatches its content to the registered handler.
+// Message types without a handler are gated before unmarshal to avoid
+// unnecessary work.
+func decodePushGateway(ctx context.Context, batch *RawBatch, messageType string, payload []byte) {
+	handler, ok := pushGatewayHandlers[messageType]
+	if !ok {
+		label := messageType
+		if !pushGatewayMessageTypes[messageType] {
+			label = "unknown"
+		}
+		statsCollector.IncDecodePushGateway(label, "ignored")
 		return // gate before unmarshal
 	}
 
 	var msg pogo.PushGatewayMessage
 	if err := proto.Unmarshal(payload, &msg); err != nil {
+		statsCollector.IncDecodePushGateway(messageType, "error")
 		log.Warnf("PushGateway: failed to parse %s: %v", messageType, err)
 		return
 	}
-	switch m := msg.GetMessage().(type) {
-	case *pogo.PushGatewayMessage_RaidLobbyPlayerCount:
-		d := m.RaidLobbyPlayerCount
-		log.Infof("PushGateway: received raid_lobby gym=%s players=%d joinEnd=%d", d.GetGymId(), d.GetPlayerCount(), d.GetLobbyJoinEndMs())
-		decoder.UpdateGymRaidLobby(ctx, dbDetails, d.GetGymId(), d.GetPlayerCount(), d.GetLobbyJoinEndMs())
-	case *pogo.PushGatewayMessage_BreadLobbyPlayerCount:
-		d := m.BreadLobbyPlayerCount
-		log.Infof("PushGateway: received bread_lobby station=%s players=%d joinEnd=%d", d.GetStationId(), d.GetPlayerCount(), d.GetBreadLobbyJoinEndMs())
-		decoder.UpdateStationBattleLobby(ctx, dbDetails, d.GetStationId(), d.GetPlayerCount(), d.GetBreadLobbyJoinEndMs())
+	content := pushGatewayContent(&msg)
+	if content == nil || string(content.field.Name()) != messageType {
+		// the message_type did not describe the payload
+		statsCollector.IncDecodePushGateway(messageType, "error")
+		log.Warnf("PushGateway: %s payload does not contain a %s", messageType, messageType)
+		return
+	}
+
+	result := handler(ctx, &pushGatewayMessage{
+		Content:        content.message,
+		PubTimestampMs: int64(msg.GetMessagePubTimestampMs()),
+		Batch:          batch,
+	})
+	statsCollector.IncDecodePushGateway(messageType, "handled")
+	log.Debugf("PushGateway: %s - %s", messageType, result)
+}
+
+type pushGatewayCase struct {
+	field   protoreflect.FieldDescriptor
+	message proto.Message
+}
+
+// pushGatewayContent returns the case set in msg, or nil if none is.
+func pushGatewayContent(msg *pogo.PushGatewayMessage) *pushGatewayCase {
+	m := msg.ProtoReflect()
+	oneofs := m.Descriptor().Oneofs()
+	for i := 0; i < oneofs.Len(); i++ {
+		field := m.WhichOneof(oneofs.Get(i))
+		if field == nil || field.Message() == nil {
+			continue
+		}
+		return &pushGatewayCase{field: field, message: m.Get(field).Message().Interface()}
+	}
+	return nil
+}
+
+func handlePushRaidLobby(ctx context.Context, m *pushGatewayMessage) string {
+	d := m.Content.(*pogo.RaidLobbyCounterData)
+	log.Infof("PushGateway: received raid_lobby gym=%s players=%d joinEnd=%d", d.GetGymId(), d.GetPlayerCount(), d.GetLobbyJoinEndMs())
+	decoder.UpdateGymRaidLobby(ctx, dbDetails, d.GetGymId(), d.GetPlayerCount(), d.GetLobbyJoinEndMs())
+	return fmt.Sprintf("raid lobby %s", d.GetGymId())
+}
+
+func handlePushBreadLobby(ctx context.Context, m *pushGatewayMessage) string {
+	d := m.Content.(*pogo.BreadLobbyCounterData)
+	log.Infof("PushGateway: received bread_lobby station=%s players=%d joinEnd=%d", d.GetStationId(), d.GetPlayerCount(), d.GetBreadLobbyJoinEndMs())
+	decoder.UpdateStationBattleLobby(ctx, dbDetails, d.GetStationId(), d.GetPlayerCount(), d.GetBreadLobbyJoinEndMs())
+	return fmt.Sprintf("bread lobby %s", d.GetStationId())
+}
+
+// handlePushFort updates a gym or pokestop, including its raid, the same way
+// as a fort seen in a GMO.
+func handlePushFort(ctx context.Context, m *pushGatewayMessage) string {
+	fort := m.Content.(*pogo.PokemonFortProto)
+	scanParameters := m.scanParameters(fort.Latitude, fort.Longitude)
+	if !scanParameters.ProcessGyms && !scanParameters.ProcessPokestops {
+		return "fort processing disabled"
+	}
+	cell := uint64(s2.CellIDFromLatLng(s2.LatLngFromDegrees(fort.Latitude, fort.Longitude)).Parent(15))
+	decoder.UpdateFortBatch(ctx, dbDetails, scanParameters, []decoder.RawFortData{{Cell: cell, Data: fort, Timestamp: m.PubTimestampMs}})
+	return fmt.Sprintf("fort %s", fort.FortId)
+}
+
+// handlePushStation updates a station and its battles the same way as a
+// station seen in a GMO.
+func handlePushStation(ctx context.Context, m *pushGatewayMessage) string {
+	station := m.Content.(*pogo.StationProto)
+	scanParameters := m.scanParameters(station.Lat, station.Lng)
+	if !scanParameters.ProcessStations {
+		return "station processing disabled"
+	}
+	cell := uint64(s2.CellIDFromLatLng(s2.LatLngFromDegrees(station.Lat, station.Lng)).Parent(15))
+	decoder.UpdateStationBatch(ctx, dbDetails, scanParameters, []decoder.RawStationData{{Cell: cell, Data: station}})
+	return fmt.Sprintf("station %s", station.Id)
+}
+
+// handlePushRsvpCount clears the RSVPs of gyms nobody is going to any more,
+// as a GetEventRsvpCount response does.
+func handlePushRsvpCount(ctx context.Context, m *pushGatewayMessage) string {
+	rsvp := m.Content.(*pogo.GetEventRsvpCountOutProto)
+	var lat, lon float64
+	if m.Batch != nil {
+		lat, lon = m.Batch.Lat, m.Batch.Lon
+	}
+	if !m.scanParameters(lat, lon).ProcessGyms {
+		return "gym processing disabled"
 	}
+	return clearEmptyRsvps(ctx, rsvp)
 }
@@ -5,23 +5,80 @@ import (
 	"testing"
 
 	"golbat/pogo"
+	"golbat/stats_collector"
 
 	"google.golang.org/protobuf/proto"
 )
 
+// pushGatewayStats records the push-gateway counters.
+type pushGatewayStats struct {
+	stats_collector.StatsCollector
+	counts map[[2]string]int
+}
+
+func (s *pushGatewayStats) IncDecodePushGateway(messageType, status string) {
+	s.counts[[2]string{messageType, status}]++
+}
+
+func usePushGatewayStats(t *testing.T) *pushGatewayStats {
+	t.Helper()
+	stats := &pushGatewayStats{StatsCollector: stats_collector.NewNoopStatsCollector(), counts: make(map[[2]string]int)}
+	previous := statsCollector
+	statsCollector = stats
+	t.Cleanup(func() { statsCollector = previous })
+	return stats
+}
+
 func TestDecodePushGateway_GatesUnknownType(t *testing.T) {
+	stats := usePushGatewayStats(t)
 	// Unhandled type -> early return before unmarshal, no panic.
-	decodePushGateway(context.Background(), "map_objects_update", []byte{0xff})
+	decodePushGateway(context.Background(), nil, "map_objects_update", []byte{0xff})
+	decodePushGateway(context.Background(), nil, "not_a_message_type", []byte{0xff})
+	if stats.counts[[2]string{"map_objects_update", "ignored"}] != 1 {
+		t.Errorf("map_objects_update not counted as ignored: %v", stats.counts)
+	}
+	if stats.counts[[2]string{"unknown", "ignored"}] != 1 {
+		t.Errorf("undefined message type not counted as unknown: %v", stats.counts)
+	}
+}
+
+func TestDecodePushGateway_Registry(t *testing.T) {
+	for _, messageType := range []string{"raid_lobby_player_count", "bread_lobby_player_count"} {
+		if pushGatewayHandlers[messageType] == nil {
+			t.Errorf("no handler registered for %s", messageType)
+		}
+	}
+}
+
+func TestDecodePushGateway_MismatchedMessageType(t *testing.T) {
+	stats := usePushGatewayStats(t)
+	msg := &pogo.PushGatewayMessage{
+		Message: &pogo.PushGatewayMessage_RaidLobbyPlayerCount{
+			RaidLobbyPlayerCount: &pogo.RaidLobbyCounterData{GymId: "G", PlayerCount: 3},
+		},
+	}
+	raw, err := proto.Marshal(msg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// A raid lobby labelled as a bread lobby is refused rather than dispatched
+	// to the wrong handler.
+	decodePushGateway(context.Background(), nil, "bread_lobby_player_count", raw)
+	if stats.counts[[2]string{"bread_lobby_player_count", "error"}] != 1 {
+		t.Errorf("mismatched payload not counted as an error: %v", stats.counts)
+	}
 }
 
 func TestDecodePushGateway_MalformedPayloadForKnownType(t *testing.T) {
+	usePushGatewayStats(t)
 	// Known type but garbage payload -> logs a warning, no panic.
-	decodePushGateway(context.Background(), "raid_lobby_player_count", []byte{0xff, 0xfe})
+	decodePushGateway(context.Background(), nil, "raid_lobby_player_count", []byte{0xff, 0xfe})
 }
 
 func TestDecodePushGateway_MalformedBreadPayload(t *testing.T) {
+	usePushGatewayStats(t)
 	// Same malformed-payload guard for the bread path.
-	decodePushGateway(context.Background(), "bread_lobby_player_count", []byte{0xfe, 0xfd})
+	decodePushGateway(context.Background(), nil, "bread_lobby_player_count", []byte{0xfe, 0xfd})
 }
 
 // TestDecodePushGateway_ClassifiesRaidLobby verifies that a valid raid-lobby proto
@@ -32,6 +89,7 @@ func TestDecodePushGateway_MalformedBreadPayload(t *testing.T) {
 // unmarshal+dispatch path is exercised. The DB-layer dedup behaviour is covered by
 // decoder.TestUpdateGymRaidLobby_DedupOlder.
 func TestDecodePushGateway_ClassifiesRaidLobby(t *testing.T) {
+	usePushGatewayStats(t)
 	msg := &pogo.PushGatewayMessage{
 		MessagePubTimestampMs: 5000,
 		Message: &pogo.PushGatewayMessage_RaidLobbyPlayerCount{
@@ -47,7 +105,7 @@ func TestDecodePushGateway_ClassifiesRaidLobby(t *testing.T) {
 	// the decoder package caches are not initialised (no DB/cache in unit tests).
 	func() {
 		defer func() { recover() }() //nolint:errcheck
-		decodePushGateway(context.Background(), "raid_lobby_player_count", raw)
+		decodePushGateway(context.Background(), nil, "raid_lobby_player_count", raw)
 	}()
 }
 
@@ -55,6 +113,7 @@ func TestDecodePushGateway_ClassifiesRaidLobby(t *testing.T) {
 // passes the gate, is unmarshalled, and dispatches into UpdateStationBattleLobby.
 // Same nil-stationCache caveat as TestDecodePushGateway_ClassifiesRaidLobby applies.
 func TestDecodePushGateway_ClassifiesBreadLobby(t *testing.T) {
+	usePushGatewayStats(t)
 	msg := &pogo.PushGatewayMessage{
 		MessagePubTimestampMs: 6000,
 		Message: &pogo.PushGatewayMessage_BreadLobbyPlayerCount{
@@ -69,6 +128,6 @@ func TestDecodePushGateway_ClassifiesBreadLobby(t *testing.T) {
 	// Recover from nil-stationCache panic inside UpdateStationBattleLobby.
 	func() {
 		defer func() { recover() }() //nolint:errcheck
-		decodePushGateway(context.Background(), "bread_lobby_player_count", raw)
+		decodePushGateway(context.Background(), nil, "bread_lobby_player_count", raw)
 	}()
 }
@@ -0,0 +1,129 @@
+package main
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+
+	"golbat/config"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// decodeQueue bounds the number of raw batches being decoded at once. Batches
+// are accepted into a fixed-size buffer and decoded by a fixed number of
+// workers; when the buffer is full new batches are refused so the caller can
+// tell the controller to back off. At shutdown the queue stops accepting
+// batches and the workers decode those already queued before exiting.
+type decodeQueue struct {
+	jobs    chan *RawBatch
+	workers int
+	busy    atomic.Int64
+
+	// closed is set under mu once shutdown starts, so no batch can be queued
+	// after the workers have found the queue empty
+	mu     sync.RWMutex
+	closed bool
+}
+
+var rawDecodeQueue *decodeQueue
+
+func newDecodeQueue(workers, size int) *decodeQueue {
+	if workers <= 0 {
+		workers = 50
+	}
+	if size < 0 {
+		size = 0
+	}
+	return &decodeQueue{
+		jobs:    make(chan *RawBatch, size),
+		workers: workers,
+	}
+}
+
+// StartDecodeQueue creates the raw decode queue from config and starts its
+// workers. Once ctx is cancelled new batches are refused, and the workers
+// decode the batches still queued before they stop.
+func StartDecodeQueue(ctx context.Context, wg *sync.WaitGroup) {
+	cfg := config.Config.Tuning
+	rawDecodeQueue = newDecodeQueue(cfg.DecodeWorkers, cfg.DecodeQueueSize)
+	log.Infof("Decode queue started with %d workers and depth %d", rawDecodeQueue.workers, cap(rawDecodeQueue.jobs))
+	rawDecodeQueue.start(ctx, wg)
+}
+
+func (q *decodeQueue) start(ctx context.Context, wg *sync.WaitGroup) {
+	for i := 0; i < q.workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			q.work(ctx)
+		}()
+	}
+
+	go func() {
+		<-ctx.Done()
+		q.close()
+		if pending := len(q.jobs); pending > 0 {
+			log.Infof("Decode queue: decoding %d queued batches before shutdown", pending)
+		}
+	}()
+}
+
+func (q *decodeQueue) work(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			q.drain()
+			return
+		case batch := <-q.jobs:
+			q.decode(batch)
+		}
+	}
+}
+
+func (q *decodeQueue) decode(batch *RawBatch) {
+	statsCollector.SetDecodeQueueDepth(float64(len(q.jobs)))
+	statsCollector.SetDecodeWorkersBusy(float64(q.busy.Add(1)))
+	decodeRawBatch(batch)
+	statsCollector.SetDecodeWorkersBusy(float64(q.busy.Add(-1)))
+}
+
+// drain closes the queue and decodes whatever is left in it
+func (q *decodeQueue) drain() {
+	q.close()
+	for {
+		select {
+		case batch := <-q.jobs:
+			q.decode(batch)
+		default:
+			return
+		}
+	}
+}
+
+// close stops the queue accepting batches
+func (q *decodeQueue) close() {
+	q.mu.Lock()
+	q.closed = true
+	q.mu.Unlock()
+}
+
+// submit queues batch for decoding. It returns false, without blocking, if the
+// queue is full or shutting down.
+func (q *decodeQueue) submit(batch *RawBatch) bool {
+	q.mu.RLock()
+	defer q.mu.RUnlock()
+	if q.closed {
+		statsCollector.IncDecodeQueueRejected(batch.Source)
+		return false
+	}
+
+	select {
+	case q.jobs <- batch:
+		statsCollector.SetDecodeQueueDepth(float64(len(q.jobs)))
+		return true
+	default:
+		statsCollector.IncDecodeQueueRejected(batch.Source)
+		return false
+	}
+}
@@ -0,0 +1,76 @@
+package main
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"golbat/stats_collector"
+)
+
+func TestDecodeQueue_RefusesWhenFull(t *testing.T) {
+	statsCollector = stats_collector.NewNoopStatsCollector()
+	q := newDecodeQueue(1, 2)
+
+	for i := 0; i < 2; i++ {
+		if !q.submit(&RawBatch{}) {
+			t.Fatalf("submit %d refused, want accepted", i)
+		}
+	}
+	if q.submit(&RawBatch{}) {
+		t.Error("submit to a full queue accepted, want refused")
+	}
+}
+
+func TestDecodeQueue_WorkersDrain(t *testing.T) {
+	statsCollector = stats_collector.NewNoopStatsCollector()
+	q := newDecodeQueue(2, 2)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	var wg sync.WaitGroup
+	q.start(ctx, &wg)
+
+	// Empty batches decode immediately, so the workers keep freeing space.
+	deadline := time.Now().Add(2 * time.Second)
+	for accepted := 0; accepted < 20; {
+		if q.submit(&RawBatch{}) {
+			accepted++
+			continue
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("queue stuck after %d batches", accepted)
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	cancel()
+	wg.Wait()
+	if busy := q.busy.Load(); busy != 0 {
+		t.Errorf("%d workers still busy after shutdown", busy)
+	}
+}
+
+func TestDecodeQueue_DecodesQueuedBatchesAtShutdown(t *testing.T) {
+	statsCollector = stats_collector.NewNoopStatsCollector()
+	q := newDecodeQueue(1, 5)
+
+	for i := 0; i < 5; i++ {
+		if !q.submit(&RawBatch{}) {
+			t.Fatalf("submit %d refused, want accepted", i)
+		}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	var wg sync.WaitGroup
+	q.start(ctx, &wg)
+	wg.Wait()
+
+	if pending := len(q.jobs); pending != 0 {
+		t.Errorf("%d batches left queued after shutdown, want 0", pending)
+	}
+	if q.submit(&RawBatch{}) {
+		t.Error("submit after shutdown accepted, want refused")
+	}
+}
@@ -191,7 +191,7 @@ func SearchGymsAPI(
 			}
 
 			// Add precise distance condition
-			whereConditions = append(whereConditions, "ST_Distance_Sphere(POINT(lon, lat), POINT(?, ?)) <= ?")
+			whereConditions = append(whereConditions, db.DialectOf(dbDetails.GeneralDb).DistanceSphere()+" <= ?")
 			args = append(args, lon, lat, distance)
 		}
 
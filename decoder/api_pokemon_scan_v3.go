@@ -72,27 +72,29 @@ func internalGetPokemonInArea3(retrieveParameters ApiPokemonScan3) ([]uint64, in
 		}
 	}
 
-	isPokemonDnfMatch := func(pokemonLookup *PokemonLookup, pvpLookup *PokemonPvpLookup, filter *ApiPokemonDnfFilter3) bool {
-		if filter.Iv != nil && (int16(pokemonLookup.Iv) < filter.Iv.Min || int16(pokemonLookup.Iv) > filter.Iv.Max) ||
-			filter.StaIv != nil && (int16(pokemonLookup.Sta) < filter.StaIv.Min || int16(pokemonLookup.Sta) > filter.StaIv.Max) ||
-			filter.AtkIv != nil && (int16(pokemonLookup.Atk) < filter.AtkIv.Min || int16(pokemonLookup.Atk) > filter.AtkIv.Max) ||
-			filter.DefIv != nil && (int16(pokemonLookup.Def) < filter.DefIv.Min || int16(pokemonLookup.Def) > filter.DefIv.Max) ||
-			filter.Level != nil && (int16(pokemonLookup.Level) < filter.Level.Min || int16(pokemonLookup.Level) > filter.Level.Max) ||
-			filter.Cp != nil && (pokemonLookup.Cp < filter.Cp.Min || pokemonLookup.Cp > filter.Cp.Max) ||
-			(len(filter.Gender) > 0 && !contains(filter.Gender, pokemonLookup.Gender)) ||
-			filter.Size != nil && (int16(pokemonLookup.Size) < filter.Size.Min || int16(pokemonLookup.Size) > filter.Size.Max) {
-			return false
-		}
+	return internalGetPokemonInArea[ApiPokemonDnfFilter3](retrieveParameters, dnfFilters, isPokemonDnfMatch3)
+}
 
-		if filter.Little != nil && (pvpLookup == nil || pvpLookup.Little < filter.Little.Min || pvpLookup.Little > filter.Little.Max) ||
-			filter.Great != nil && (pvpLookup == nil || pvpLookup.Great < filter.Great.Min || pvpLookup.Great > filter.Great.Max) ||
-			filter.Ultra != nil && (pvpLookup == nil || pvpLookup.Ultra < filter.Ultra.Min || pvpLookup.Ultra > filter.Ultra.Max) {
-			return false
-		}
-		return true
+// isPokemonDnfMatch3 checks a pokemon against the conditions of a clause other
+// than its pokemon/form, which the scan matches by lookup key.
+func isPokemonDnfMatch3(pokemonLookup *PokemonLookup, pvpLookup *PokemonPvpLookup, filter *ApiPokemonDnfFilter3) bool {
+	if filter.Iv != nil && (int16(pokemonLookup.Iv) < filter.Iv.Min || int16(pokemonLookup.Iv) > filter.Iv.Max) ||
+		filter.StaIv != nil && (int16(pokemonLookup.Sta) < filter.StaIv.Min || int16(pokemonLookup.Sta) > filter.StaIv.Max) ||
+		filter.AtkIv != nil && (int16(pokemonLookup.Atk) < filter.AtkIv.Min || int16(pokemonLookup.Atk) > filter.AtkIv.Max) ||
+		filter.DefIv != nil && (int16(pokemonLookup.Def) < filter.DefIv.Min || int16(pokemonLookup.Def) > filter.DefIv.Max) ||
+		filter.Level != nil && (int16(pokemonLookup.Level) < filter.Level.Min || int16(pokemonLookup.Level) > filter.Level.Max) ||
+		filter.Cp != nil && (pokemonLookup.Cp < filter.Cp.Min || pokemonLookup.Cp > filter.Cp.Max) ||
+		(len(filter.Gender) > 0 && !contains(filter.Gender, pokemonLookup.Gender)) ||
+		filter.Size != nil && (int16(pokemonLookup.Size) < filter.Size.Min || int16(pokemonLookup.Size) > filter.Size.Max) {
+		return false
 	}
 
-	return internalGetPokemonInArea[ApiPokemonDnfFilter3](retrieveParameters, dnfFilters, isPokemonDnfMatch)
+	if filter.Little != nil && (pvpLookup == nil || pvpLookup.Little < filter.Little.Min || pvpLookup.Little > filter.Little.Max) ||
+		filter.Great != nil && (pvpLookup == nil || pvpLookup.Great < filter.Great.Min || pvpLookup.Great > filter.Great.Max) ||
+		filter.Ultra != nil && (pvpLookup == nil || pvpLookup.Ultra < filter.Ultra.Min || pvpLookup.Ultra > filter.Ultra.Max) {
+		return false
+	}
+	return true
 }
 
 func GrpcGetPokemonInArea3(retrieveParameters *pb.PokemonScanRequestV3) ([]*pb.PokemonDetails, int, int, int) {
@@ -0,0 +1,142 @@
+package decoder
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/guregu/null/v6"
+	log "github.com/sirupsen/logrus"
+
+	"golbat/db"
+	"golbat/pogo"
+)
+
+// battleStateOpponent returns the NPC actor of a nebula battle state: the grunt
+// or leader of an invasion, or the boss of a raid or max battle.
+func battleStateOpponent(state *pogo.BattleStateProto) *pogo.BattleActorProto {
+	var opponent *pogo.BattleActorProto
+	for _, a := range state.GetActors() {
+		log.Debugf("Nebula battlestate actor id=%s type=%s team=%s active=%d roster=%v",
+			a.GetId(), a.GetType(), a.GetTeam(), a.GetActivePokemonId(), a.GetPokemonRoster())
+		if a.GetType() == pogo.BattleActorProto_NPC || a.GetType() == pogo.BattleActorProto_NPC_BOSS {
+			opponent = a
+		}
+	}
+	return opponent
+}
+
+// battleStateBoss returns the opponent's active pokemon, or nil if there is no
+// opponent or its species has not been revealed.
+func battleStateBoss(out *pogo.BattleStateOutProto) *pogo.BattlePokemonProto {
+	state := out.GetBattleState()
+	opponent := battleStateOpponent(state)
+	if opponent == nil {
+		log.Warnf("Nebula battlestate: no opponent actor found")
+		return nil
+	}
+	boss := state.GetPokemon()[opponent.GetActivePokemonId()]
+	if boss.GetPokedexId() == 0 {
+		return nil
+	}
+	return boss
+}
+
+// UpdateGymRaidFromBattleState fills in the raid boss of a gym from the state
+// of a raid battle there. A raidSeed other than zero must match the raid the
+// gym is known to have, so a battle state from an earlier raid is ignored.
+func UpdateGymRaidFromBattleState(ctx context.Context, db db.DbDetails, fortId string, raidSeed int64, out *pogo.BattleStateOutProto) string {
+	boss := battleStateBoss(out)
+	if boss == nil {
+		return "no boss in battle state"
+	}
+
+	gym, unlock, err := getGymRecordForUpdate(ctx, db, fortId, "UpdateGymRaidFromBattleState")
+	if err != nil {
+		return err.Error()
+	}
+	if gym == nil {
+		// Do not add raid details to unknown gyms
+		return fmt.Sprintf("%s Gym not present", fortId)
+	}
+	defer unlock()
+
+	if gym.RaidEndTimestamp.ValueOrZero() <= time.Now().Unix() {
+		return fmt.Sprintf("%s has no active raid", fortId)
+	}
+	if raidSeed != 0 && gym.RaidSeed.Valid && gym.RaidSeed.Int64 != raidSeed {
+		return fmt.Sprintf("%s raid seed %d does not match %d", fortId, raidSeed, gym.RaidSeed.Int64)
+	}
+
+	gym.updateRaidFromBattleState(boss)
+	saveGymRecord(ctx, db, gym)
+	return fmt.Sprintf("%s raid boss %d", gym.Id, boss.GetPokedexId())
+}
+
+// UpdateStationBattleFromBattleState fills in the boss of a known max battle
+// at a station from the state of that battle. Battles are only updated, not
+// created, as the battle state does not carry the battle window.
+func UpdateStationBattleFromBattleState(ctx context.Context, db db.DbDetails, stationId string, battleSeed int64, out *pogo.BattleStateOutProto) string {
+	boss := battleStateBoss(out)
+	if boss == nil {
+		return "no boss in battle state"
+	}
+
+	station, unlock, err := getStationRecordForUpdate(ctx, db, stationId, "UpdateStationBattleFromBattleState")
+	if err != nil {
+		return err.Error()
+	}
+	if station == nil {
+		return fmt.Sprintf("%s Station not present", stationId)
+	}
+	defer unlock()
+
+	now := time.Now().Unix()
+	var battle *StationBattleData
+	for _, known := range getKnownStationBattles(stationId, now) {
+		if known.BreadBattleSeed == battleSeed {
+			battle = &known
+			break
+		}
+	}
+	if battle == nil {
+		return fmt.Sprintf("%s battle %d not known", stationId, battleSeed)
+	}
+
+	battle.updateFromBattleState(boss)
+	battle.Updated = now
+	upsertCachedStationBattle(*battle, now)
+
+	saveStationRecord(ctx, db, station)
+	return fmt.Sprintf("%s battle %d boss %d", station.Id, battleSeed, boss.GetPokedexId())
+}
+
+// updateRaidFromBattleState sets the raid boss, its stats and moves from the
+// boss of a raid battle state
+func (gym *Gym) updateRaidFromBattleState(boss *pogo.BattlePokemonProto) {
+	display := boss.GetDisplay()
+	gym.SetRaidPokemonId(null.IntFrom(int64(boss.GetPokedexId())))
+	gym.SetRaidPokemonForm(null.IntFrom(int64(display.GetForm())))
+	gym.SetRaidPokemonCostume(null.IntFrom(int64(display.GetCostume())))
+	gym.SetRaidPokemonGender(null.IntFrom(int64(display.GetGender())))
+	gym.SetRaidPokemonAlignment(null.IntFrom(int64(display.GetAlignment())))
+	gym.SetRaidPokemonCp(null.IntFrom(int64(boss.GetCp())))
+	gym.SetRaidPokemonMove1(null.IntFrom(int64(boss.GetMove1())))
+	gym.SetRaidPokemonMove2(null.IntFrom(int64(boss.GetMove2())))
+}
+
+// updateFromBattleState sets the battle pokemon, its stats and moves from the
+// boss of a max battle state
+func (battle *StationBattleData) updateFromBattleState(boss *pogo.BattlePokemonProto) {
+	display := boss.GetDisplay()
+	battle.BattlePokemonId = null.IntFrom(int64(boss.GetPokedexId()))
+	battle.BattlePokemonForm = null.IntFrom(int64(display.GetForm()))
+	battle.BattlePokemonCostume = null.IntFrom(int64(display.GetCostume()))
+	battle.BattlePokemonGender = null.IntFrom(int64(display.GetGender()))
+	battle.BattlePokemonAlignment = null.IntFrom(int64(display.GetAlignment()))
+	battle.BattlePokemonBreadMode = null.IntFrom(int64(display.GetBreadModeEnum()))
+	battle.BattlePokemonMove1 = null.IntFrom(int64(boss.GetMove1()))
+	battle.BattlePokemonMove2 = null.IntFrom(int64(boss.GetMove2()))
+	battle.BattlePokemonStamina = null.IntFrom(int64(boss.GetStamina()))
+	battle.BattlePokemonCpMultiplier = null.FloatFrom(float64(boss.GetCpMultiplier()))
+}
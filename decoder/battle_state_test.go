@@ -0,0 +1,87 @@
+package decoder
+
+import (
+	"testing"
+
+	"golbat/pogo"
+)
+
+// raidBattleState is a battle state with a player and a raid boss, as sent
+// while fighting a raid or max battle
+func raidBattleState() *pogo.BattleStateOutProto {
+	return &pogo.BattleStateOutProto{
+		BattleState: &pogo.BattleStateProto{
+			Actors: map[string]*pogo.BattleActorProto{
+				"player": {Id: "player", ActivePokemonId: 1},
+				"boss":   {Id: "boss", Type: pogo.BattleActorProto_NPC_BOSS, ActivePokemonId: 200},
+			},
+			Pokemon: map[uint64]*pogo.BattlePokemonProto{
+				1: {PokedexId: pogo.HoloPokemonId(25)},
+				200: {
+					PokedexId: pogo.HoloPokemonId(150),
+					Display: &pogo.PokemonDisplayProto{
+						Form:          pogo.PokemonDisplayProto_Form(135),
+						BreadModeEnum: pogo.BreadModeEnum_BREAD_DOUGH_MODE,
+					},
+					Cp:           54000,
+					Move1:        pogo.HoloPokemonMove(234),
+					Move2:        pogo.HoloPokemonMove(108),
+					Stamina:      15000,
+					CpMultiplier: 0.79,
+				},
+			},
+		},
+	}
+}
+
+func TestBattleStateBoss(t *testing.T) {
+	out := raidBattleState()
+	boss := battleStateBoss(out)
+	if boss == nil || boss.GetPokedexId() != 150 || boss.GetDisplay().GetForm() != 135 {
+		t.Fatalf("boss = %+v, want 150 form 135", boss)
+	}
+
+	// A boss whose species is still hidden is not reported.
+	out.BattleState.Pokemon[200] = &pogo.BattlePokemonProto{}
+	if boss := battleStateBoss(out); boss != nil {
+		t.Errorf("hidden boss = %+v, want nil", boss)
+	}
+
+	if boss := battleStateBoss(&pogo.BattleStateOutProto{}); boss != nil {
+		t.Errorf("empty state boss = %+v, want nil", boss)
+	}
+}
+
+func TestUpdateRaidFromBattleState(t *testing.T) {
+	gym := &Gym{GymData: GymData{Id: "gym"}}
+	gym.updateRaidFromBattleState(battleStateBoss(raidBattleState()))
+
+	if gym.RaidPokemonId.Int64 != 150 || gym.RaidPokemonForm.Int64 != 135 {
+		t.Errorf("raid boss = %v form %v, want 150 form 135", gym.RaidPokemonId, gym.RaidPokemonForm)
+	}
+	if gym.RaidPokemonCp.Int64 != 54000 || gym.RaidPokemonMove1.Int64 != 234 || gym.RaidPokemonMove2.Int64 != 108 {
+		t.Errorf("raid boss cp %v moves %v %v, want 54000 moves 234 108",
+			gym.RaidPokemonCp, gym.RaidPokemonMove1, gym.RaidPokemonMove2)
+	}
+	if !gym.IsDirty() {
+		t.Error("expected the gym to be saved")
+	}
+}
+
+func TestStationBattleUpdateFromBattleState(t *testing.T) {
+	battle := &StationBattleData{BreadBattleSeed: 1, StationId: "station"}
+	battle.updateFromBattleState(battleStateBoss(raidBattleState()))
+
+	if battle.BattlePokemonId.Int64 != 150 || battle.BattlePokemonForm.Int64 != 135 || battle.BattlePokemonBreadMode.Int64 != int64(pogo.BreadModeEnum_BREAD_DOUGH_MODE) {
+		t.Errorf("battle pokemon = %v form %v bread mode %v, want 150 form 135 in dough mode",
+			battle.BattlePokemonId, battle.BattlePokemonForm, battle.BattlePokemonBreadMode)
+	}
+	if battle.BattlePokemonMove1.Int64 != 234 || battle.BattlePokemonMove2.Int64 != 108 {
+		t.Errorf("battle moves %v %v, want 234 108", battle.BattlePokemonMove1, battle.BattlePokemonMove2)
+	}
+	if battle.BattlePokemonStamina.Int64 != 15000 || !battle.BattlePokemonCpMultiplier.Valid ||
+		battle.BattlePokemonCpMultiplier.Float64 < 0.789 || battle.BattlePokemonCpMultiplier.Float64 > 0.791 {
+		t.Errorf("battle stamina %v cp multiplier %v, want 15000 and 0.79",
+			battle.BattlePokemonStamina, battle.BattlePokemonCpMultiplier)
+	}
+}
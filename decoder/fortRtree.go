@@ -143,21 +143,21 @@ func fortRtreeUpdateStationOnGet(station *Station) {
 }
 
 func updatePokestopLookup(pokestop *Pokestop) {
+	lookup := pokestopFortLookup(pokestop)
 	// Preserve existing incident fields if present
-	var incidentDisplayType int8
-	var incidentStyle int8
-	var incidentCharacter int16
-	var incidentPokemonId int16
-	var incidentPokemonForm int16
 	if existing, ok := fortLookupCache.Load(pokestop.Id); ok {
-		incidentDisplayType = existing.IncidentDisplayType
-		incidentStyle = existing.IncidentStyle
-		incidentCharacter = existing.IncidentCharacter
-		incidentPokemonId = existing.IncidentPokemonId
-		incidentPokemonForm = existing.IncidentPokemonForm
+		lookup.IncidentDisplayType = existing.IncidentDisplayType
+		lookup.IncidentStyle = existing.IncidentStyle
+		lookup.IncidentCharacter = existing.IncidentCharacter
+		lookup.IncidentPokemonId = existing.IncidentPokemonId
+		lookup.IncidentPokemonForm = existing.IncidentPokemonForm
 	}
+	fortLookupCache.Store(pokestop.Id, lookup)
+}
 
-	fortLookupCache.Store(pokestop.Id, FortLookup{
+// pokestopFortLookup builds the lookup for a pokestop, without its incident
+func pokestopFortLookup(pokestop *Pokestop) FortLookup {
+	return FortLookup{
 		FortType:                   POKESTOP,
 		Lat:                        pokestop.Lat,
 		Lon:                        pokestop.Lon,
@@ -174,20 +174,19 @@ func updatePokestopLookup(pokestop *Pokestop) {
 		QuestArRewardItemId:        int16(pokestop.AlternativeQuestItemId.ValueOrZero()),
 		QuestArRewardPokemonId:     int16(pokestop.AlternativeQuestPokemonId.ValueOrZero()),
 		QuestArRewardPokemonForm:   int16(pokestop.AlternativeQuestPokemonFormId.ValueOrZero()),
-		IncidentDisplayType:        incidentDisplayType,
-		IncidentStyle:              incidentStyle,
-		IncidentCharacter:          incidentCharacter,
-		IncidentPokemonId:          incidentPokemonId,
-		IncidentPokemonForm:        incidentPokemonForm,
 		ContestPokemonId:           int16(pokestop.ShowcasePokemon.ValueOrZero()),
 		ContestPokemonForm:         int16(pokestop.ShowcasePokemonForm.ValueOrZero()),
 		ContestPokemonType:         int8(pokestop.ShowcasePokemonType.ValueOrZero()),
 		ContestTotalEntries:        getContestTotalEntries(pokestop.ShowcaseRankings),
-	})
+	}
 }
 
 func updateGymLookup(gym *Gym) {
-	fortLookupCache.Store(gym.Id, FortLookup{
+	fortLookupCache.Store(gym.Id, gymFortLookup(gym))
+}
+
+func gymFortLookup(gym *Gym) FortLookup {
+	return FortLookup{
 		FortType:            GYM,
 		Lat:                 gym.Lat,
 		Lon:                 gym.Lon,
@@ -200,7 +199,7 @@ func updateGymLookup(gym *Gym) {
 		RaidLevel:           int8(gym.RaidLevel.ValueOrZero()),
 		RaidPokemonId:       int16(gym.RaidPokemonId.ValueOrZero()),
 		RaidPokemonForm:     int16(gym.RaidPokemonForm.ValueOrZero()),
-	})
+	}
 }
 
 func updateStationLookup(station *Station) {
@@ -208,15 +207,18 @@ func updateStationLookup(station *Station) {
 }
 
 func updateStationLookupWithBattles(station *Station, stationBattles []StationBattleData) {
-	battles := buildFortLookupStationBattlesFromSlice(stationBattles)
+	fortLookupCache.Store(station.Id, stationFortLookup(station, stationBattles))
+}
+
+func stationFortLookup(station *Station, stationBattles []StationBattleData) FortLookup {
 	lookup := FortLookup{
 		FortType:       STATION,
 		Lat:            station.Lat,
 		Lon:            station.Lon,
-		StationBattles: battles,
+		StationBattles: buildFortLookupStationBattlesFromSlice(stationBattles),
 	}
 	applyTopStationBattleToFortLookup(&lookup, stationBattles)
-	fortLookupCache.Store(station.Id, lookup)
+	return lookup
 }
 
 // updatePokestopIncidentLookup updates the incident fields on a pokestop's FortLookup entry
@@ -226,15 +228,18 @@ func updatePokestopIncidentLookup(pokestopId string, incident *Incident) {
 		return
 	}
 
-	existing.IncidentDisplayType = int8(incident.DisplayType)
-	existing.IncidentStyle = int8(incident.Style)
-	existing.IncidentCharacter = incident.Character
-	existing.IncidentPokemonId = int16(incident.Slot1PokemonId.ValueOrZero())
-	existing.IncidentPokemonForm = int16(incident.Slot1Form.ValueOrZero())
-
+	applyIncidentToFortLookup(&existing, incident)
 	fortLookupCache.Store(pokestopId, existing)
 }
 
+func applyIncidentToFortLookup(lookup *FortLookup, incident *Incident) {
+	lookup.IncidentDisplayType = int8(incident.DisplayType)
+	lookup.IncidentStyle = int8(incident.Style)
+	lookup.IncidentCharacter = incident.Character
+	lookup.IncidentPokemonId = int16(incident.Slot1PokemonId.ValueOrZero())
+	lookup.IncidentPokemonForm = int16(incident.Slot1Form.ValueOrZero())
+}
+
 // getContestTotalEntries parses showcase rankings JSON to get total entries
 func getContestTotalEntries(rankingsString null.String) int16 {
 	if !rankingsString.Valid {
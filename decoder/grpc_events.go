@@ -0,0 +1,317 @@
+package decoder
+
+import (
+	"encoding/json"
+
+	"github.com/guregu/null/v6"
+
+	pb "golbat/grpc"
+)
+
+// GrpcEventSchemaVersion is sent with every grpc event. Raise it when a
+// change to the event messages is not backwards compatible.
+const GrpcEventSchemaVersion = 1
+
+// GrpcEventFromWebhook converts a webhook message to its grpc event, or
+// returns nil if the message has no grpc counterpart.
+func GrpcEventFromWebhook(message any) *pb.Event {
+	event := &pb.Event{SchemaVersion: GrpcEventSchemaVersion}
+	switch hook := message.(type) {
+	case PokemonWebhook:
+		event.Event = &pb.Event_Pokemon{Pokemon: pokemonEventFromWebhook(&hook)}
+	case GymDetailsWebhook:
+		event.Event = &pb.Event_Gym{Gym: gymEventFromWebhook(&hook)}
+	case RaidWebhook:
+		event.Event = &pb.Event_Raid{Raid: raidEventFromWebhook(&hook)}
+	case PokestopWebhook:
+		event.Event = &pb.Event_Pokestop{Pokestop: pokestopEventFromWebhook(&hook)}
+	case QuestWebhook:
+		event.Event = &pb.Event_Quest{Quest: questEventFromWebhook(&hook)}
+	case IncidentWebhook:
+		event.Event = &pb.Event_Invasion{Invasion: invasionEventFromWebhook(&hook)}
+	case StationWebhook:
+		event.Event = &pb.Event_MaxBattle{MaxBattle: maxBattleEventFromWebhook(&hook)}
+	case StationDetailsWebhook:
+		event.Event = &pb.Event_Station{Station: stationEventFromWebhook(&hook)}
+	case WeatherWebhook:
+		event.Event = &pb.Event_Weather{Weather: weatherEventFromWebhook(&hook)}
+	default:
+		return nil
+	}
+	return event
+}
+
+func pokemonEventFromWebhook(hook *PokemonWebhook) *pb.PokemonEvent {
+	return &pb.PokemonEvent{
+		EncounterId:           hook.EncounterId,
+		SpawnpointId:          hook.SpawnpointId,
+		PokestopId:            hook.PokestopId,
+		PokestopName:          hook.PokestopName,
+		PokemonId:             int32(hook.PokemonId),
+		Latitude:              hook.Latitude,
+		Longitude:             hook.Longitude,
+		DisappearTime:         hook.DisappearTime,
+		DisappearTimeVerified: hook.DisappearTimeVerified,
+		FirstSeen:             hook.FirstSeen,
+		LastModifiedTime:      hook.LastModifiedTime.Ptr(),
+		Gender:                nullInt32(hook.Gender),
+		Cp:                    nullInt32(hook.Cp),
+		Form:                  nullInt32(hook.Form),
+		Costume:               nullInt32(hook.Costume),
+		IndividualAttack:      nullInt32(hook.IndividualAttack),
+		IndividualDefense:     nullInt32(hook.IndividualDefense),
+		IndividualStamina:     nullInt32(hook.IndividualStamina),
+		PokemonLevel:          nullInt32(hook.PokemonLevel),
+		Move_1:                nullInt32(hook.Move1),
+		Move_2:                nullInt32(hook.Move2),
+		Weight:                hook.Weight.Ptr(),
+		Size:                  nullInt32(hook.Size),
+		Height:                hook.Height.Ptr(),
+		Weather:               nullInt32(hook.Weather),
+		Capture_1:             hook.Capture1,
+		Capture_2:             hook.Capture2,
+		Capture_3:             hook.Capture3,
+		Shiny:                 hook.Shiny.Ptr(),
+		Username:              hook.Username.Ptr(),
+		DisplayPokemonId:      nullInt32(hook.DisplayPokemonId),
+		DisplayPokemonForm:    nullInt32(hook.DisplayPokemonForm),
+		IsEvent:               hook.IsEvent != 0,
+		SeenType:              hook.SeenType.Ptr(),
+		PvpJson:               rawJsonString(hook.Pvp),
+	}
+}
+
+func gymEventFromWebhook(hook *GymDetailsWebhook) *pb.GymEvent {
+	event := &pb.GymEvent{
+		Id:                  hook.Id,
+		Name:                hook.Name,
+		Url:                 hook.Url,
+		Latitude:            hook.Latitude,
+		Longitude:           hook.Longitude,
+		Team:                int32(hook.Team),
+		GuardPokemonId:      int32(hook.GuardPokemonId),
+		SlotsAvailable:      int32(hook.SlotsAvailable),
+		ExRaidEligible:      hook.ExRaidEligible != 0,
+		InBattle:            hook.InBattle,
+		SponsorId:           int32(hook.SponsorId),
+		PartnerId:           int32(hook.PartnerId),
+		PowerUpPoints:       int32(hook.PowerUpPoints),
+		PowerUpLevel:        int32(hook.PowerUpLevel),
+		PowerUpEndTimestamp: hook.PowerUpEndTimestamp,
+		ArScanEligible:      hook.ArScanEligible != 0,
+	}
+	if defenders, ok := hook.Defenders.(json.RawMessage); ok {
+		event.DefendersJson = rawJsonString(defenders)
+	}
+	return event
+}
+
+func raidEventFromWebhook(hook *RaidWebhook) *pb.RaidEvent {
+	return &pb.RaidEvent{
+		GymId:               hook.GymId,
+		GymName:             hook.GymName,
+		GymUrl:              hook.GymUrl,
+		Latitude:            hook.Latitude,
+		Longitude:           hook.Longitude,
+		TeamId:              int32(hook.TeamId),
+		Spawn:               hook.Spawn,
+		Start:               hook.Start,
+		End:                 hook.End,
+		Level:               int32(hook.Level),
+		PokemonId:           int32(hook.PokemonId),
+		Cp:                  int32(hook.Cp),
+		Gender:              int32(hook.Gender),
+		Form:                int32(hook.Form),
+		Alignment:           int32(hook.Alignment),
+		Costume:             int32(hook.Costume),
+		Evolution:           int32(hook.Evolution),
+		Move_1:              int32(hook.Move1),
+		Move_2:              int32(hook.Move2),
+		ExRaidEligible:      hook.ExRaidEligible != 0,
+		IsExclusive:         hook.IsExclusive != 0,
+		SponsorId:           int32(hook.SponsorId),
+		PartnerId:           hook.PartnerId,
+		PowerUpPoints:       int32(hook.PowerUpPoints),
+		PowerUpLevel:        int32(hook.PowerUpLevel),
+		PowerUpEndTimestamp: hook.PowerUpEndTimestamp,
+		ArScanEligible:      hook.ArScanEligible != 0,
+		RsvpsJson:           rawJsonString(hook.Rsvps),
+		RaidSeed:            hook.RaidSeed.Ptr(),
+	}
+}
+
+func pokestopEventFromWebhook(hook *PokestopWebhook) *pb.PokestopEvent {
+	return &pb.PokestopEvent{
+		PokestopId:              hook.PokestopId,
+		Latitude:                hook.Latitude,
+		Longitude:               hook.Longitude,
+		Name:                    hook.Name,
+		Url:                     hook.Url,
+		LureExpiration:          hook.LureExpiration,
+		LastModified:            hook.LastModified,
+		Enabled:                 hook.Enabled,
+		LureId:                  int32(hook.LureId),
+		ArScanEligible:          hook.ArScanEligible != 0,
+		PowerUpLevel:            int32(hook.PowerUpLevel),
+		PowerUpPoints:           int32(hook.PowerUpPoints),
+		PowerUpEndTimestamp:     hook.PowerUpEndTimestamp,
+		Updated:                 hook.Updated,
+		ShowcaseFocusJson:       rawJsonString(hook.ShowcaseFocus),
+		ShowcasePokemonId:       nullInt32(hook.ShowcasePokemonId),
+		ShowcasePokemonFormId:   nullInt32(hook.ShowcasePokemonFormId),
+		ShowcasePokemonTypeId:   nullInt32(hook.ShowcasePokemonTypeId),
+		ShowcaseRankingStandard: nullInt32(hook.ShowcaseRankingStandard),
+		ShowcaseExpiry:          hook.ShowcaseExpiry.Ptr(),
+		ShowcaseRankingsJson:    rawJsonString(hook.ShowcaseRankings),
+	}
+}
+
+func questEventFromWebhook(hook *QuestWebhook) *pb.QuestEvent {
+	return &pb.QuestEvent{
+		PokestopId:     hook.PokestopId,
+		Latitude:       hook.Latitude,
+		Longitude:      hook.Longitude,
+		PokestopName:   hook.PokestopName,
+		PokestopUrl:    hook.PokestopUrl,
+		Type:           nullInt32(hook.Type),
+		Target:         nullInt32(hook.Target),
+		Template:       hook.Template.Ptr(),
+		Title:          hook.Title.Ptr(),
+		ConditionsJson: rawJsonString(hook.Conditions),
+		RewardsJson:    rawJsonString(hook.Rewards),
+		Updated:        hook.Updated,
+		ArScanEligible: hook.ArScanEligible != 0,
+		WithAr:         hook.WithAr,
+		QuestSeed:      hook.QuestSeed.Ptr(),
+	}
+}
+
+func invasionEventFromWebhook(hook *IncidentWebhook) *pb.InvasionEvent {
+	event := &pb.InvasionEvent{
+		Id:                      hook.Id,
+		PokestopId:              hook.PokestopId,
+		Latitude:                hook.Latitude,
+		Longitude:               hook.Longitude,
+		PokestopName:            hook.PokestopName,
+		Url:                     hook.Url,
+		Enabled:                 hook.Enabled,
+		Start:                   hook.Start,
+		IncidentExpireTimestamp: hook.IncidentExpireTimestamp,
+		DisplayType:             int32(hook.DisplayType),
+		Style:                   int32(hook.Style),
+		GruntType:               int32(hook.GruntType),
+		Character:               int32(hook.Character),
+		Updated:                 hook.Updated,
+		Confirmed:               hook.Confirmed,
+	}
+	for _, lineup := range hook.Lineup {
+		event.Lineup = append(event.Lineup, &pb.InvasionLineup{
+			Slot:      int32(lineup.Slot),
+			PokemonId: nullInt32(lineup.PokemonId),
+			Form:      nullInt32(lineup.Form),
+		})
+	}
+	return event
+}
+
+func maxBattleEventFromWebhook(hook *StationWebhook) *pb.MaxBattleEvent {
+	event := &pb.MaxBattleEvent{
+		Id:                     hook.Id,
+		Latitude:               hook.Latitude,
+		Longitude:              hook.Longitude,
+		Name:                   hook.Name,
+		StartTime:              hook.StartTime,
+		EndTime:                hook.EndTime,
+		IsBattleAvailable:      hook.IsBattleAvailable,
+		BattleLevel:            nullInt32(hook.BattleLevel),
+		BattleStart:            hook.BattleStart.Ptr(),
+		BattleEnd:              hook.BattleEnd.Ptr(),
+		BattlePokemonId:        nullInt32(hook.BattlePokemonId),
+		BattlePokemonForm:      nullInt32(hook.BattlePokemonForm),
+		BattlePokemonCostume:   nullInt32(hook.BattlePokemonCostume),
+		BattlePokemonGender:    nullInt32(hook.BattlePokemonGender),
+		BattlePokemonAlignment: nullInt32(hook.BattlePokemonAlignment),
+		BattlePokemonBreadMode: nullInt32(hook.BattlePokemonBreadMode),
+		BattlePokemonMove_1:    nullInt32(hook.BattlePokemonMove1),
+		BattlePokemonMove_2:    nullInt32(hook.BattlePokemonMove2),
+		TotalStationedPokemon:  nullInt32(hook.TotalStationedPokemon),
+		TotalStationedGmax:     nullInt32(hook.TotalStationedGmax),
+		Updated:                hook.Updated,
+	}
+	for _, battle := range hook.Battles {
+		event.Battles = append(event.Battles, &pb.StationBattle{
+			BreadBattleSeed:           battle.BreadBattleSeed,
+			BattleLevel:               int32(battle.BattleLevel),
+			BattleStart:               battle.BattleStart,
+			BattleEnd:                 battle.BattleEnd,
+			BattlePokemonId:           nullInt32(battle.BattlePokemonId),
+			BattlePokemonForm:         nullInt32(battle.BattlePokemonForm),
+			BattlePokemonCostume:      nullInt32(battle.BattlePokemonCostume),
+			BattlePokemonGender:       nullInt32(battle.BattlePokemonGender),
+			BattlePokemonAlignment:    nullInt32(battle.BattlePokemonAlignment),
+			BattlePokemonBreadMode:    nullInt32(battle.BattlePokemonBreadMode),
+			BattlePokemonMove_1:       nullInt32(battle.BattlePokemonMove1),
+			BattlePokemonMove_2:       nullInt32(battle.BattlePokemonMove2),
+			BattlePokemonStamina:      nullInt32(battle.BattlePokemonStamina),
+			BattlePokemonCpMultiplier: battle.BattlePokemonCpMultiplier.Ptr(),
+		})
+	}
+	return event
+}
+
+func stationEventFromWebhook(hook *StationDetailsWebhook) *pb.StationEvent {
+	return &pb.StationEvent{
+		Id:                    hook.Id,
+		Latitude:              hook.Latitude,
+		Longitude:             hook.Longitude,
+		Name:                  hook.Name,
+		StartTime:             hook.StartTime,
+		EndTime:               hook.EndTime,
+		IsBattleAvailable:     hook.IsBattleAvailable,
+		IsInactive:            hook.IsInactive,
+		TotalStationedPokemon: nullInt32(hook.TotalStationedPokemon),
+		TotalStationedGmax:    nullInt32(hook.TotalStationedGmax),
+		Updated:               hook.Updated,
+	}
+}
+
+func weatherEventFromWebhook(hook *WeatherWebhook) *pb.WeatherEvent {
+	event := &pb.WeatherEvent{
+		S2CellId:           hook.S2CellId,
+		Latitude:           hook.Latitude,
+		Longitude:          hook.Longitude,
+		GameplayCondition:  int32(hook.GameplayCondition),
+		WindDirection:      int32(hook.WindDirection),
+		CloudLevel:         int32(hook.CloudLevel),
+		RainLevel:          int32(hook.RainLevel),
+		WindLevel:          int32(hook.WindLevel),
+		SnowLevel:          int32(hook.SnowLevel),
+		FogLevel:           int32(hook.FogLevel),
+		SpecialEffectLevel: int32(hook.SpecialEffectLevel),
+		Severity:           int32(hook.Severity),
+		WarnWeather:        hook.WarnWeather,
+		Updated:            hook.Updated,
+	}
+	for _, corner := range hook.Polygon {
+		event.Polygon = append(event.Polygon, corner[0], corner[1])
+	}
+	return event
+}
+
+func nullInt32(value null.Int) *int32 {
+	if !value.Valid {
+		return nil
+	}
+	v := int32(value.Int64)
+	return &v
+}
+
+// rawJsonString returns the json as a string, or nil for a missing or null
+// value
+func rawJsonString(raw json.RawMessage) *string {
+	if len(raw) == 0 || string(raw) == "null" {
+		return nil
+	}
+	s := string(raw)
+	return &s
+}
@@ -0,0 +1,36 @@
+package decoder
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/guregu/null/v6"
+)
+
+func TestGrpcEventFromWebhook(t *testing.T) {
+	event := GrpcEventFromWebhook(PokemonWebhook{
+		EncounterId: "123",
+		PokemonId:   25,
+		Cp:          null.IntFrom(500),
+		Pvp:         json.RawMessage(`{"great":[]}`),
+	})
+	pokemon := event.GetPokemon()
+	if pokemon == nil || pokemon.EncounterId != "123" || pokemon.PokemonId != 25 {
+		t.Fatalf("got %v, want the pokemon", event)
+	}
+	if pokemon.Cp == nil || *pokemon.Cp != 500 || pokemon.IndividualAttack != nil {
+		t.Errorf("cp %v, attack %v: nullable fields not carried over", pokemon.Cp, pokemon.IndividualAttack)
+	}
+	if pokemon.PvpJson == nil || *pokemon.PvpJson != `{"great":[]}` {
+		t.Errorf("pvp %v", pokemon.PvpJson)
+	}
+
+	weather := GrpcEventFromWebhook(WeatherWebhook{Polygon: [4][2]float64{{1, 2}, {3, 4}, {5, 6}, {7, 8}}}).GetWeather()
+	if weather == nil || len(weather.Polygon) != 8 || weather.Polygon[7] != 8 {
+		t.Errorf("got %v, want the weather polygon flattened", weather)
+	}
+
+	if GrpcEventFromWebhook(TappableWebhook{}) != nil {
+		t.Error("tappable has no grpc event")
+	}
+}
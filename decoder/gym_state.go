@@ -161,6 +161,8 @@ type GymDetailsWebhook struct {
 	PowerUpEndTimestamp int64   `json:"power_up_end_timestamp"`
 	ArScanEligible      int64   `json:"ar_scan_eligible"`
 	Defenders           any     `json:"defenders"`
+
+	filterLookup *FortLookup
 }
 
 type RaidWebhook struct {
@@ -193,6 +195,8 @@ type RaidWebhook struct {
 	ArScanEligible      int64           `json:"ar_scan_eligible"`
 	Rsvps               json.RawMessage `json:"rsvps"`
 	RaidSeed            null.String     `json:"raid_seed"`
+
+	filterLookup *FortLookup
 }
 
 func createGymFortWebhooks(gym *Gym) {
@@ -214,6 +218,8 @@ func createGymFortWebhooks(gym *Gym) {
 }
 
 func createGymWebhooks(gym *Gym, areas []geo.AreaName) {
+	filterLookup := gymFortLookup(gym)
+
 	if gym.newRecord ||
 		(gym.oldValues.AvailableSlots != gym.AvailableSlots || gym.oldValues.TeamId != gym.TeamId || gym.oldValues.InBattle != gym.InBattle) {
 		gymDetails := GymDetailsWebhook{
@@ -240,6 +246,7 @@ func createGymWebhooks(gym *Gym, areas []geo.AreaName) {
 					return nil
 				}
 			}(),
+			filterLookup: &filterLookup,
 		}
 
 		webhooksSender.AddMessage(webhooks.GymDetails, gymDetails, areas)
@@ -300,6 +307,7 @@ func createGymWebhooks(gym *Gym, areas []geo.AreaName) {
 					}
 					return null.String{}
 				}(),
+				filterLookup: &filterLookup,
 			}
 
 			webhooksSender.AddMessage(webhooks.Raid, raidHook, areas)
@@ -369,8 +377,8 @@ func gymWriteDB(db db.DbDetails, gym *Gym, isNewRecord bool) error {
 	ctx := context.Background()
 
 	if isNewRecord {
-		res, err := db.GeneralDb.NamedExecContext(ctx, "INSERT INTO gym (id,lat,lon,name,url,last_modified_timestamp,raid_end_timestamp,raid_spawn_timestamp,raid_battle_timestamp,updated,raid_pokemon_id,guarding_pokemon_id,guarding_pokemon_display,available_slots,team_id,raid_level,enabled,ex_raid_eligible,in_battle,raid_pokemon_move_1,raid_pokemon_move_2,raid_pokemon_form,raid_pokemon_alignment,raid_pokemon_cp,raid_is_exclusive,cell_id,deleted,total_cp,first_seen_timestamp,raid_pokemon_gender,sponsor_id,partner_id,raid_pokemon_costume,raid_pokemon_evolution,ar_scan_eligible,power_up_level,power_up_points,power_up_end_timestamp,description, defenders, rsvps) "+
-			"VALUES (:id,:lat,:lon,:name,:url,UNIX_TIMESTAMP(),:raid_end_timestamp,:raid_spawn_timestamp,:raid_battle_timestamp,:updated,:raid_pokemon_id,:guarding_pokemon_id,:guarding_pokemon_display,:available_slots,:team_id,:raid_level,:enabled,:ex_raid_eligible,:in_battle,:raid_pokemon_move_1,:raid_pokemon_move_2,:raid_pokemon_form,:raid_pokemon_alignment,:raid_pokemon_cp,:raid_is_exclusive,:cell_id,0,:total_cp,UNIX_TIMESTAMP(),:raid_pokemon_gender,:sponsor_id,:partner_id,:raid_pokemon_costume,:raid_pokemon_evolution,:ar_scan_eligible,:power_up_level,:power_up_points,:power_up_end_timestamp,:description, :defenders, :rsvps)", gym)
+		res, err := db.GeneralDb.NamedExecContext(ctx, rebind(db.GeneralDb, "INSERT INTO gym (id,lat,lon,name,url,last_modified_timestamp,raid_end_timestamp,raid_spawn_timestamp,raid_battle_timestamp,updated,raid_pokemon_id,guarding_pokemon_id,guarding_pokemon_display,available_slots,team_id,raid_level,enabled,ex_raid_eligible,in_battle,raid_pokemon_move_1,raid_pokemon_move_2,raid_pokemon_form,raid_pokemon_alignment,raid_pokemon_cp,raid_is_exclusive,cell_id,deleted,total_cp,first_seen_timestamp,raid_pokemon_gender,sponsor_id,partner_id,raid_pokemon_costume,raid_pokemon_evolution,ar_scan_eligible,power_up_level,power_up_points,power_up_end_timestamp,description, defenders, rsvps) "+
+			"VALUES (:id,:lat,:lon,:name,:url,UNIX_TIMESTAMP(),:raid_end_timestamp,:raid_spawn_timestamp,:raid_battle_timestamp,:updated,:raid_pokemon_id,:guarding_pokemon_id,:guarding_pokemon_display,:available_slots,:team_id,:raid_level,:enabled,:ex_raid_eligible,:in_battle,:raid_pokemon_move_1,:raid_pokemon_move_2,:raid_pokemon_form,:raid_pokemon_alignment,:raid_pokemon_cp,:raid_is_exclusive,:cell_id,0,:total_cp,UNIX_TIMESTAMP(),:raid_pokemon_gender,:sponsor_id,:partner_id,:raid_pokemon_costume,:raid_pokemon_evolution,:ar_scan_eligible,:power_up_level,:power_up_points,:power_up_end_timestamp,:description, :defenders, :rsvps)"), gym)
 
 		statsCollector.IncDbQuery("insert gym", err)
 		if err != nil {
@@ -90,6 +90,8 @@ type IncidentWebhook struct {
 	Updated                 int64           `json:"updated"`
 	Confirmed               bool            `json:"confirmed"`
 	Lineup                  []webhookLineup `json:"lineup"`
+
+	filterLookup *FortLookup
 }
 
 //->   `id` varchar(35) NOT NULL,
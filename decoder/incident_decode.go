@@ -66,15 +66,8 @@ func (incident *Incident) updateFromBattleState(out *pogo.BattleStateOutProto) {
 		return
 	}
 
-	// Identify the opponent actor (NPC or NPC_BOSS). Verbose debug for payload capture.
-	var opponent *pogo.BattleActorProto
-	for _, a := range state.GetActors() {
-		log.Debugf("Nebula battlestate actor id=%s type=%s team=%s active=%d roster=%v",
-			a.GetId(), a.GetType(), a.GetTeam(), a.GetActivePokemonId(), a.GetPokemonRoster())
-		if a.GetType() == pogo.BattleActorProto_NPC || a.GetType() == pogo.BattleActorProto_NPC_BOSS {
-			opponent = a
-		}
-	}
+	// Identify the opponent actor (NPC or NPC_BOSS).
+	opponent := battleStateOpponent(state)
 	if opponent == nil {
 		log.Warnf("Nebula battlestate: no opponent actor found")
 		return
@@ -220,8 +220,12 @@ func createIncidentWebhooks(ctx context.Context, db db.DbDetails, incident *Inci
 		var stopLat, stopLon float64
 		var stopEnabled bool
 		var stopCellId uint64
+		var filterLookup *FortLookup
 		stop, unlock, _ := getPokestopRecordReadOnly(ctx, db, incident.PokestopId, "createIncidentWebhooks")
 		if stop != nil {
+			lookup := pokestopFortLookup(stop)
+			applyIncidentToFortLookup(&lookup, incident)
+			filterLookup = &lookup
 			pokestopName = stop.Name.ValueOrZero()
 			stopLat, stopLon = stop.Lat, stop.Lon
 			stopUrl = stop.Url.ValueOrZero()
@@ -253,6 +257,7 @@ func createIncidentWebhooks(ctx context.Context, db db.DbDetails, incident *Inci
 			Updated:                 incident.Updated,
 			Confirmed:               incident.Confirmed,
 			Lineup:                  lineup,
+			filterLookup:            filterLookup,
 		}
 
 		areas := MatchStatsGeofenceWithCell(stopLat, stopLon, stopCellId)
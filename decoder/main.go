@@ -331,3 +331,6 @@ func GetUpdateThreshold(defaultSeconds int64) int64 {
 	}
 	return defaultSeconds
 }
+
+// rebind is db.Rebind, for the writers whose db parameter shadows the package
+var rebind = db.Rebind
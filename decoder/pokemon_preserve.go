@@ -44,7 +44,7 @@ func PreservePokemonToDatabase(dbDetails db.DbDetails) {
 		if len(batch) == 0 {
 			return
 		}
-		_, err := dbDetails.PokemonDb.NamedExecContext(ctx, pokemonBatchUpsertQuery, batch)
+		_, err := dbDetails.PokemonDb.NamedExecContext(ctx, db.Rebind(dbDetails.PokemonDb, pokemonBatchUpsertQuery), batch)
 		if err != nil {
 			log.Errorf("PreservePokemon: batch write error - %s", err)
 			errored += len(batch)
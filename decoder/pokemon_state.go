@@ -290,7 +290,7 @@ func pokemonWriteDB(db db.DbDetails, pokemon *Pokemon, isNewRecord bool) error {
 			"gender, form, cp, level, strong, weather, costume, weight, height, size,"+
 			"display_pokemon_id, display_pokemon_form, is_ditto, pokestop_id, updated, first_seen_timestamp, changed, cell_id,"+
 			"expire_timestamp_verified, shiny, username, %s is_event, seen_type) "+
-			"VALUES (\"%d\", :pokemon_id, :lat, :lon, :spawn_id, :expire_timestamp, :atk_iv, :def_iv, :sta_iv,"+
+			"VALUES ('%d', :pokemon_id, :lat, :lon, :spawn_id, :expire_timestamp, :atk_iv, :def_iv, :sta_iv,"+
 			":golbat_internal, :iv, :move_1, :move_2, :gender, :form, :cp, :level, :strong, :weather, :costume,"+
 			":weight, :height, :size, :display_pokemon_id, :display_pokemon_form, :is_ditto, :pokestop_id, :updated,"+
 			":first_seen_timestamp, :changed, :cell_id, :expire_timestamp_verified, :shiny, :username, %s :is_event,"+
@@ -345,7 +345,7 @@ func pokemonWriteDB(db db.DbDetails, pokemon *Pokemon, isNewRecord bool) error {
 			"username = :username, "+
 			"%s"+
 			"is_event = :is_event "+
-			"WHERE id = \"%d\"", pvpUpdate, pokemon.Id), pokemon,
+			"WHERE id = '%d'", pvpUpdate, pokemon.Id), pokemon,
 		)
 		statsCollector.IncDbQuery("update pokemon", err)
 		if err != nil {
@@ -393,6 +393,8 @@ type PokemonWebhook struct {
 	IsEvent               int8            `json:"is_event"`
 	SeenType              null.String     `json:"seen_type"`
 	Pvp                   json.RawMessage `json:"pvp"`
+
+	filterLookup PokemonLookupCacheItem
 }
 
 func createPokemonWebhooks(ctx context.Context, db db.DbDetails, pokemon *Pokemon, areas []geo.AreaName) {
@@ -427,6 +429,9 @@ func createPokemonWebhooks(ctx context.Context, db db.DbDetails, pokemon *Pokemo
 			pvp = json.RawMessage(pokemon.Pvp.ValueOrZero())
 		}
 
+		// the lookup has been updated for this save already
+		filterLookup, _ := pokemonLookupCache.Load(uint64(pokemon.Id))
+
 		pokemonHook := PokemonWebhook{
 			SpawnpointId:          spawnpointId,
 			PokestopId:            pokestopId,
@@ -463,6 +468,7 @@ func createPokemonWebhooks(ctx context.Context, db db.DbDetails, pokemon *Pokemo
 			IsEvent:               pokemon.IsEvent,
 			SeenType:              pokemon.SeenType,
 			Pvp:                   pvp,
+			filterLookup:          filterLookup,
 		}
 
 		if pokemon.AtkIv.Valid && pokemon.DefIv.Valid && pokemon.StaIv.Valid {
@@ -80,6 +80,7 @@ type Pokestop struct {
 	internalDirty           bool     `db:"-"` // Not persisted - tracks if object needs saving (in memory only)
 	newRecord               bool     `db:"-"` // Not persisted - tracks if this is a new record
 	pokestopWebhookRequired bool     `db:"-"` // Set when the pokestop webhook should fire on next save; cleared after fire
+	contestWebhookRequired  bool     `db:"-"` // Set when the showcase/contest webhook should fire on next save; cleared after fire
 	changedFields           []string `db:"-"` // Track which fields changed (only when dbDebugEnabled)
 
 	oldValues PokestopOldValues `db:"-"` // Old values for webhook comparison
@@ -682,6 +683,7 @@ func (p *Pokestop) SetShowcaseFocus(v null.String) {
 		p.ShowcaseFocus = v
 		p.dirty = true
 		p.pokestopWebhookRequired = true
+		p.contestWebhookRequired = true
 	}
 }
 
@@ -733,6 +735,7 @@ func (p *Pokestop) SetShowcaseExpiry(v null.Int) {
 		p.ShowcaseExpiry = v
 		p.dirty = true
 		p.pokestopWebhookRequired = true
+		p.contestWebhookRequired = true
 	}
 }
 
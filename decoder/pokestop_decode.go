@@ -553,6 +553,7 @@ func (stop *Pokestop) updatePokestopFromGetPokemonSizeContestEntryOutProto(conte
 	// no further JSON re-parsing in any hot path.
 	if newTopScore != stop.oldValues.ShowcaseTopScore || newTopPokemonId != stop.oldValues.ShowcaseTopPokemonId {
 		stop.pokestopWebhookRequired = true
+		stop.contestWebhookRequired = true
 		stop.oldValues.ShowcaseTopScore = newTopScore
 		stop.oldValues.ShowcaseTopPokemonId = newTopPokemonId
 	}
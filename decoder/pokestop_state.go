@@ -170,6 +170,8 @@ type QuestWebhook struct {
 	PokestopUrl    string          `json:"pokestop_url"`
 	WithAr         bool            `json:"with_ar"`
 	QuestSeed      null.String     `json:"quest_seed"`
+
+	filterLookup *FortLookup
 }
 
 type PokestopWebhook struct {
@@ -194,6 +196,30 @@ type PokestopWebhook struct {
 	ShowcaseRankingStandard null.Int        `json:"showcase_ranking_standard"`
 	ShowcaseExpiry          null.Int        `json:"showcase_expiry"`
 	ShowcaseRankings        json.RawMessage `json:"showcase_rankings"`
+
+	filterLookup *FortLookup
+}
+
+// ContestWebhook is sent when a showcase starts, changes focus or expiry, or
+// gets a new leader
+type ContestWebhook struct {
+	PokestopId              string          `json:"pokestop_id"`
+	Latitude                float64         `json:"latitude"`
+	Longitude               float64         `json:"longitude"`
+	Name                    string          `json:"name"`
+	Url                     string          `json:"url"`
+	ShowcaseFocus           json.RawMessage `json:"showcase_focus"`
+	ShowcasePokemonId       null.Int        `json:"showcase_pokemon_id"`
+	ShowcasePokemonFormId   null.Int        `json:"showcase_pokemon_form_id"`
+	ShowcasePokemonTypeId   null.Int        `json:"showcase_pokemon_type_id"`
+	ShowcaseRankingStandard null.Int        `json:"showcase_ranking_standard"`
+	ShowcaseExpiry          null.Int        `json:"showcase_expiry"`
+	ShowcaseRankings        json.RawMessage `json:"showcase_rankings"`
+	TopScore                null.Float      `json:"top_score"`
+	TopPokemonId            null.Int        `json:"top_pokemon_id"`
+	Updated                 int64           `json:"updated"`
+
+	filterLookup *FortLookup
 }
 
 func createPokestopFortWebhooks(stop *Pokestop) {
@@ -223,6 +249,15 @@ func createPokestopWebhooks(stop *Pokestop) {
 		pokestopName = stop.Name.String
 	}
 
+	var lookup *FortLookup
+	filterLookup := func() *FortLookup {
+		if lookup == nil {
+			l := pokestopFortLookup(stop)
+			lookup = &l
+		}
+		return lookup
+	}
+
 	if stop.AlternativeQuestType.Valid && (stop.newRecord || stop.AlternativeQuestType != stop.oldValues.AlternativeQuestType) {
 		questHook := QuestWebhook{
 			PokestopId:     stop.Id,
@@ -245,6 +280,7 @@ func createPokestopWebhooks(stop *Pokestop) {
 				}
 				return null.String{}
 			}(),
+			filterLookup: filterLookup(),
 		}
 		webhooksSender.AddMessage(webhooks.Quest, questHook, areas)
 	}
@@ -271,19 +307,21 @@ func createPokestopWebhooks(stop *Pokestop) {
 				}
 				return null.String{}
 			}(),
+			filterLookup: filterLookup(),
 		}
 		webhooksSender.AddMessage(webhooks.Quest, questHook, areas)
 	}
-	if stop.pokestopWebhookRequired {
-		var showcaseRankings json.RawMessage
-		if stop.ShowcaseRankings.Valid {
-			showcaseRankings = json.RawMessage(stop.ShowcaseRankings.ValueOrZero())
-		}
-		var showcaseFocus json.RawMessage
-		if stop.ShowcaseFocus.Valid {
-			showcaseFocus = json.RawMessage(stop.ShowcaseFocus.ValueOrZero())
-		}
 
+	var showcaseRankings json.RawMessage
+	if stop.ShowcaseRankings.Valid {
+		showcaseRankings = json.RawMessage(stop.ShowcaseRankings.ValueOrZero())
+	}
+	var showcaseFocus json.RawMessage
+	if stop.ShowcaseFocus.Valid {
+		showcaseFocus = json.RawMessage(stop.ShowcaseFocus.ValueOrZero())
+	}
+
+	if stop.pokestopWebhookRequired {
 		pokestopHook := PokestopWebhook{
 			PokestopId:              stop.Id,
 			Latitude:                stop.Lat,
@@ -306,11 +344,37 @@ func createPokestopWebhooks(stop *Pokestop) {
 			ShowcaseRankingStandard: stop.ShowcaseRankingStandard,
 			ShowcaseExpiry:          stop.ShowcaseExpiry,
 			ShowcaseRankings:        showcaseRankings,
+			filterLookup:            filterLookup(),
 		}
 
 		webhooksSender.AddMessage(webhooks.Pokestop, pokestopHook, areas)
 		stop.pokestopWebhookRequired = false
 	}
+
+	if stop.contestWebhookRequired {
+		if stop.ShowcaseExpiry.Valid {
+			contestHook := ContestWebhook{
+				PokestopId:              stop.Id,
+				Latitude:                stop.Lat,
+				Longitude:               stop.Lon,
+				Name:                    pokestopName,
+				Url:                     stop.Url.ValueOrZero(),
+				ShowcaseFocus:           showcaseFocus,
+				ShowcasePokemonId:       stop.ShowcasePokemon,
+				ShowcasePokemonFormId:   stop.ShowcasePokemonForm,
+				ShowcasePokemonTypeId:   stop.ShowcasePokemonType,
+				ShowcaseRankingStandard: stop.ShowcaseRankingStandard,
+				ShowcaseExpiry:          stop.ShowcaseExpiry,
+				ShowcaseRankings:        showcaseRankings,
+				TopScore:                stop.oldValues.ShowcaseTopScore,
+				TopPokemonId:            stop.oldValues.ShowcaseTopPokemonId,
+				Updated:                 stop.Updated,
+				filterLookup:            filterLookup(),
+			}
+			webhooksSender.AddMessage(webhooks.Contest, contestHook, areas)
+		}
+		stop.contestWebhookRequired = false
+	}
 }
 
 func savePokestopRecord(ctx context.Context, db db.DbDetails, pokestop *Pokestop) {
@@ -376,7 +440,7 @@ func pokestopWriteDB(db db.DbDetails, pokestop *Pokestop, isNewRecord bool) erro
 	ctx := context.Background()
 
 	if isNewRecord {
-		_, err := db.GeneralDb.NamedExecContext(ctx, `
+		_, err := db.GeneralDb.NamedExecContext(ctx, rebind(db.GeneralDb, `
 			INSERT INTO pokestop (
 				id, lat, lon, name, url, enabled, lure_expire_timestamp, last_modified_timestamp, quest_type,
 				quest_timestamp, quest_target, quest_conditions, quest_rewards, quest_template, quest_title,
@@ -402,7 +466,7 @@ func pokestopWriteDB(db db.DbDetails, pokestop *Pokestop, isNewRecord bool) erro
 				:power_up_points, :power_up_level, :power_up_end_timestamp,
 				UNIX_TIMESTAMP(), UNIX_TIMESTAMP(),
 				:description, :showcase_focus, :showcase_pokemon_id,
-				:showcase_pokemon_form_id, :showcase_pokemon_type_id, :showcase_ranking_standard, :showcase_expiry, :showcase_rankings)`,
+				:showcase_pokemon_form_id, :showcase_pokemon_type_id, :showcase_ranking_standard, :showcase_expiry, :showcase_rankings)`),
 			pokestop)
 
 		statsCollector.IncDbQuery("insert pokestop", err)
@@ -497,11 +561,12 @@ func RemoveQuestsWithinGeofence(ctx context.Context, dbDetails db.DbDetails, geo
 
 	// Query for pokestop IDs within the geofence
 	var pokestopIds []string
+	within, fenceArg := db.DialectOf(dbDetails.GeneralDb).WithinGeoJSON(bytes)
 	err = dbDetails.GeneralDb.SelectContext(ctx, &pokestopIds,
 		"SELECT id FROM pokestop "+
 			"WHERE lat >= ? AND lon >= ? AND lat <= ? AND lon <= ? AND enabled = 1 "+
-			"AND ST_CONTAINS(ST_GeomFromGeoJSON('"+string(bytes)+"', 2, 0), POINT(lon, lat))",
-		bbox.Min.Lat(), bbox.Min.Lon(), bbox.Max.Lat(), bbox.Max.Lon())
+			"AND "+within,
+		bbox.Min.Lat(), bbox.Min.Lon(), bbox.Max.Lat(), bbox.Max.Lon(), fenceArg)
 	statsCollector.IncDbQuery("select pokestops for quest removal", err)
 	if err != nil {
 		return 0, err
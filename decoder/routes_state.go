@@ -3,6 +3,7 @@ package decoder
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"time"
@@ -10,6 +11,7 @@ import (
 	"github.com/jellydator/ttlcache/v3"
 
 	"golbat/db"
+	"golbat/webhooks"
 )
 
 func loadRouteFromDatabase(ctx context.Context, db db.DbDetails, routeId string, route *Route) error {
@@ -140,6 +142,9 @@ func saveRouteRecord(ctx context.Context, db db.DbDetails, route *Route) error {
 		route.changedFields = route.changedFields[:0]
 	}
 	route.ClearDirty()
+	if isNewRecord || route.oldValues.Version != route.Version {
+		createRouteWebhooks(route)
+	}
 	if isNewRecord {
 		routeCache.Set(route.Id, route, ttlcache.DefaultTTL)
 		route.newRecord = false
@@ -147,6 +152,69 @@ func saveRouteRecord(ctx context.Context, db db.DbDetails, route *Route) error {
 	return nil
 }
 
+type RouteWebhook struct {
+	Id               string          `json:"id"`
+	Name             string          `json:"name"`
+	Shortcode        string          `json:"shortcode"`
+	Description      string          `json:"description"`
+	DistanceMeters   int64           `json:"distance_meters"`
+	DurationSeconds  int64           `json:"duration_seconds"`
+	StartFortId      string          `json:"start_fort_id"`
+	StartLatitude    float64         `json:"start_latitude"`
+	StartLongitude   float64         `json:"start_longitude"`
+	StartImage       string          `json:"start_image"`
+	EndFortId        string          `json:"end_fort_id"`
+	EndLatitude      float64         `json:"end_latitude"`
+	EndLongitude     float64         `json:"end_longitude"`
+	EndImage         string          `json:"end_image"`
+	Image            string          `json:"image"`
+	ImageBorderColor string          `json:"image_border_color"`
+	Reversible       bool            `json:"reversible"`
+	Tags             json.RawMessage `json:"tags"`
+	Type             int8            `json:"type"`
+	Version          int64           `json:"version"`
+	Waypoints        json.RawMessage `json:"waypoints"`
+	Updated          int64           `json:"updated"`
+}
+
+// createRouteWebhooks announces a new route or a new version of one. The
+// route is matched to areas by where it starts.
+func createRouteWebhooks(route *Route) {
+	var tags json.RawMessage
+	if route.Tags.Valid {
+		tags = json.RawMessage(route.Tags.String)
+	}
+	var waypoints json.RawMessage
+	if route.Waypoints != "" {
+		waypoints = json.RawMessage(route.Waypoints)
+	}
+	routeHook := RouteWebhook{
+		Id:               route.Id,
+		Name:             route.Name,
+		Shortcode:        route.Shortcode,
+		Description:      route.Description,
+		DistanceMeters:   route.DistanceMeters,
+		DurationSeconds:  route.DurationSeconds,
+		StartFortId:      route.StartFortId,
+		StartLatitude:    route.StartLat,
+		StartLongitude:   route.StartLon,
+		StartImage:       route.StartImage,
+		EndFortId:        route.EndFortId,
+		EndLatitude:      route.EndLat,
+		EndLongitude:     route.EndLon,
+		EndImage:         route.EndImage,
+		Image:            route.Image,
+		ImageBorderColor: route.ImageBorderColor,
+		Reversible:       route.Reversible,
+		Tags:             tags,
+		Type:             route.Type,
+		Version:          route.Version,
+		Waypoints:        waypoints,
+		Updated:          route.Updated,
+	}
+	webhooksSender.AddMessage(webhooks.Route, routeHook, MatchStatsGeofence(route.StartLat, route.StartLon))
+}
+
 // routeWriteDB performs the actual database INSERT/UPDATE for a Route
 // This is called by both direct writes and the write-behind queue
 func routeWriteDB(db db.DbDetails, route *Route, isNewRecord bool) error {
@@ -22,7 +22,10 @@ type ScanParameters struct {
 	ProactiveIVSwitchingToDB bool
 }
 
-func FindScanConfiguration(scanContext string, lat, lon float64) ScanParameters {
+// FindScanConfiguration returns the parameters of the first scan rule matching
+// the submission's area, scan context and the name of the raw token it was
+// received with.
+func FindScanConfiguration(scanContext string, token string, lat, lon float64) ScanParameters {
 	var areas []geo.AreaName
 	areaLookedUp := false
 
@@ -48,6 +51,18 @@ func FindScanConfiguration(scanContext string, lat, lon float64) ScanParameters
 				continue
 			}
 		}
+		if len(rule.Tokens) > 0 {
+			found := false
+			for _, name := range rule.Tokens {
+				if strings.EqualFold(name, token) {
+					found = true
+					break
+				}
+			}
+			if !found {
+				continue
+			}
+		}
 
 		// We have a match
 
@@ -332,14 +332,14 @@ func spawnpointUpdate(ctx context.Context, db db.DbDetails, spawnpoint *Spawnpoi
 func spawnpointWriteDB(db db.DbDetails, spawnpoint *Spawnpoint) error {
 	ctx := context.Background()
 
-	_, err := db.GeneralDb.NamedExecContext(ctx, "INSERT INTO spawnpoint (id, lat, lon, updated, last_seen, despawn_sec)"+
+	_, err := db.GeneralDb.NamedExecContext(ctx, rebind(db.GeneralDb, "INSERT INTO spawnpoint (id, lat, lon, updated, last_seen, despawn_sec)"+
 		"VALUES (:id, :lat, :lon, :updated, :last_seen, :despawn_sec)"+
 		"ON DUPLICATE KEY UPDATE "+
 		"lat=VALUES(lat),"+
 		"lon=VALUES(lon),"+
 		"updated=VALUES(updated),"+
 		"last_seen=VALUES(last_seen),"+
-		"despawn_sec=VALUES(despawn_sec)", spawnpoint)
+		"despawn_sec=VALUES(despawn_sec)"), spawnpoint)
 
 	statsCollector.IncDbQuery("insert spawnpoint", err)
 	if err != nil {
@@ -62,8 +62,14 @@ type Station struct {
 
 // StationOldValues holds old field values for webhook comparison
 type StationOldValues struct {
-	EndTime        int64
-	BattleSnapshot stationBattleSnapshot
+	Name                  string
+	StartTime             int64
+	EndTime               int64
+	IsBattleAvailable     bool
+	IsInactive            bool
+	TotalStationedPokemon null.Int
+	TotalStationedGmax    null.Int
+	BattleSnapshot        stationBattleSnapshot
 }
 
 // IsDirty returns true if any field has been modified
@@ -101,8 +107,14 @@ func (station *Station) Unlock() {
 // Call this after loading from cache/DB but before modifications
 func (station *Station) snapshotOldValues() {
 	station.oldValues = StationOldValues{
-		EndTime:        station.EndTime,
-		BattleSnapshot: snapshotStationBattles(getKnownStationBattles(station.Id, time.Now().Unix())),
+		Name:                  station.Name,
+		StartTime:             station.StartTime,
+		EndTime:               station.EndTime,
+		IsBattleAvailable:     station.IsBattleAvailable,
+		IsInactive:            station.IsInactive,
+		TotalStationedPokemon: station.TotalStationedPokemon,
+		TotalStationedGmax:    station.TotalStationedGmax,
+		BattleSnapshot:        snapshotStationBattles(getKnownStationBattles(station.Id, time.Now().Unix())),
 	}
 }
 
@@ -581,7 +581,7 @@ func flushStationBattleBatch(ctx context.Context, dbDetails db.DbDetails, snapsh
 	}
 
 	if len(battles) > 0 {
-		if _, err = tx.NamedExecContext(ctx, stationBattleBatchUpsertQuery, battles); err != nil {
+		if _, err = tx.NamedExecContext(ctx, db.Rebind(dbDetails.GeneralDb, stationBattleBatchUpsertQuery), battles); err != nil {
 			_ = tx.Rollback()
 			statsCollector.IncDbQuery("upsert station_battle", err)
 			return err
@@ -47,6 +47,26 @@ type StationWebhook struct {
 	TotalStationedGmax     null.Int               `json:"total_stationed_gmax"`
 	Battles                []StationBattleWebhook `json:"battles,omitempty"`
 	Updated                int64                  `json:"updated"`
+
+	filterLookup *FortLookup
+}
+
+// StationDetailsWebhook describes the station itself, and is sent whether or
+// not it has a battle
+type StationDetailsWebhook struct {
+	Id                    string   `json:"id"`
+	Latitude              float64  `json:"latitude"`
+	Longitude             float64  `json:"longitude"`
+	Name                  string   `json:"name"`
+	StartTime             int64    `json:"start_time"`
+	EndTime               int64    `json:"end_time"`
+	IsBattleAvailable     bool     `json:"is_battle_available"`
+	IsInactive            bool     `json:"is_inactive"`
+	TotalStationedPokemon null.Int `json:"total_stationed_pokemon"`
+	TotalStationedGmax    null.Int `json:"total_stationed_gmax"`
+	Updated               int64    `json:"updated"`
+
+	filterLookup *FortLookup
 }
 
 type StationBattleWebhook struct {
@@ -236,6 +256,7 @@ func saveStationRecord(ctx context.Context, db db.DbDetails, station *Station) {
 		station.ClearDirty()
 	}
 	createStationWebhooksWithBattles(station, battles, battleSnapshot, isNewRecord, now)
+	createStationDetailsWebhooks(station, battles, isNewRecord, now)
 	if isNewRecord {
 		stationCache.Set(station.Id, station, ttlcache.DefaultTTL)
 		station.newRecord = false
@@ -363,6 +384,7 @@ func createStationWebhooksWithBattles(station *Station, battles []StationBattleD
 	}
 
 	if isNew || old.EndTime != station.EndTime || old.BattleSnapshot != battleSnapshot {
+		filterLookup := stationFortLookup(station, battles)
 		stationHook := StationWebhook{
 			Id:                    station.Id,
 			Latitude:              station.Lat,
@@ -375,6 +397,7 @@ func createStationWebhooksWithBattles(station *Station, battles []StationBattleD
 			TotalStationedGmax:    station.TotalStationedGmax,
 			Battles:               buildStationBattleWebhooks(battles),
 			Updated:               updated,
+			filterLookup:          &filterLookup,
 		}
 		applyTopStationBattleToStationWebhook(&stationHook, battles)
 		areas := MatchStatsGeofenceWithCell(station.Lat, station.Lon, uint64(station.CellId))
@@ -386,3 +409,29 @@ func createStationWebhooksWithBattles(station *Station, battles []StationBattleD
 		}
 	}
 }
+
+func createStationDetailsWebhooks(station *Station, battles []StationBattleData, isNew bool, updated int64) {
+	old := &station.oldValues
+
+	if isNew || old.Name != station.Name || old.StartTime != station.StartTime || old.EndTime != station.EndTime ||
+		old.IsBattleAvailable != station.IsBattleAvailable || old.IsInactive != station.IsInactive ||
+		old.TotalStationedPokemon != station.TotalStationedPokemon || old.TotalStationedGmax != station.TotalStationedGmax {
+		filterLookup := stationFortLookup(station, battles)
+		stationHook := StationDetailsWebhook{
+			Id:                    station.Id,
+			Latitude:              station.Lat,
+			Longitude:             station.Lon,
+			Name:                  station.Name,
+			StartTime:             station.StartTime,
+			EndTime:               station.EndTime,
+			IsBattleAvailable:     station.IsBattleAvailable,
+			IsInactive:            station.IsInactive,
+			TotalStationedPokemon: station.TotalStationedPokemon,
+			TotalStationedGmax:    station.TotalStationedGmax,
+			Updated:               updated,
+			filterLookup:          &filterLookup,
+		}
+		areas := MatchStatsGeofenceWithCell(station.Lat, station.Lon, uint64(station.CellId))
+		webhooksSender.AddMessage(webhooks.Station, stationHook, areas)
+	}
+}
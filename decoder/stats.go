@@ -13,6 +13,7 @@ import (
 	log "github.com/sirupsen/logrus"
 
 	"golbat/config"
+	"golbat/db"
 	"golbat/encounter_cache"
 	"golbat/geo"
 )
@@ -813,9 +814,9 @@ func logPokemonCount(statsDb *sqlx.DB) {
 					rowsToWrite := rows[i:end]
 
 					_, err := statsDb.NamedExec(
-						fmt.Sprintf("INSERT INTO %s (date, area, fence, pokemon_id, form_id, `count`)"+
+						db.Rebind(statsDb, fmt.Sprintf("INSERT INTO %s (date, area, fence, pokemon_id, form_id, `count`)"+
 							" VALUES (:date, :area, :fence, :pokemon_id, :form_id, :count)"+
-							" ON DUPLICATE KEY UPDATE `count` = `count` + VALUES(`count`);", table),
+							" ON DUPLICATE KEY UPDATE `count` = `count` + VALUES(`count`);", table)),
 						rowsToWrite,
 					)
 					if err != nil {
@@ -844,9 +845,9 @@ func logPokemonCount(statsDb *sqlx.DB) {
 				rowsToWrite := rows[i:end]
 
 				_, err := statsDb.NamedExec(
-					"INSERT INTO pokemon_shiny_stats (date, area, fence, pokemon_id, form_id, `count`, total)"+
+					db.Rebind(statsDb, "INSERT INTO pokemon_shiny_stats (date, area, fence, pokemon_id, form_id, `count`, total)"+
 						" VALUES (:date, :area, :fence, :pokemon_id, :form_id, :count, :total)"+
-						" ON DUPLICATE KEY UPDATE `count` = `count` + VALUES(`count`), total = total + VALUES(total);",
+						" ON DUPLICATE KEY UPDATE `count` = `count` + VALUES(`count`), total = total + VALUES(total);"),
 					rowsToWrite,
 				)
 				if err != nil {
@@ -917,10 +918,10 @@ func logRaidStats(statsDb *sqlx.DB) {
 
 			batchRows := rows[i:end]
 			_, err := statsDb.NamedExec(
-				"INSERT INTO raid_stats "+
+				db.Rebind(statsDb, "INSERT INTO raid_stats "+
 					"(date, area, fence, level, pokemon_id, form_id, temp_evo_id, `count`)"+
 					" VALUES (:date, :area, :fence, :level, :pokemon_id, :form_id, :temp_evo_id, :count)"+
-					" ON DUPLICATE KEY UPDATE `count` = `count` + VALUES(`count`);", batchRows)
+					" ON DUPLICATE KEY UPDATE `count` = `count` + VALUES(`count`);"), batchRows)
 			if err != nil {
 				log.Errorf("Error inserting raid_stats: %v", err)
 			}
@@ -976,10 +977,10 @@ func logInvasionStats(statsDb *sqlx.DB) {
 
 			batchRows := rows[i:end]
 			_, err := statsDb.NamedExec(
-				"INSERT INTO invasion_stats "+
+				db.Rebind(statsDb, "INSERT INTO invasion_stats "+
 					"(date, area, fence, `character`, `count`)"+
 					" VALUES (:date, :area, :fence, :character, :count)"+
-					" ON DUPLICATE KEY UPDATE `count` = `count` + VALUES(`count`);", batchRows)
+					" ON DUPLICATE KEY UPDATE `count` = `count` + VALUES(`count`);"), batchRows)
 			if err != nil {
 				log.Errorf("Error inserting invasion_stats: %v", err)
 			}
@@ -1060,10 +1061,10 @@ func logQuestStats(statsDb *sqlx.DB) {
 
 			batchRows := rows[i:end]
 			_, err := statsDb.NamedExec(
-				"INSERT INTO quest_stats "+
+				db.Rebind(statsDb, "INSERT INTO quest_stats "+
 					"(date, area, fence, reward_type, pokemon_id, item_id, item_amount, `count`) "+
 					"VALUES (:date, :area, :fence, :reward_type, :pokemon_id, :item_id, :item_amount, :count) "+
-					"ON DUPLICATE KEY UPDATE `count` = `count` + VALUES(`count`);",
+					"ON DUPLICATE KEY UPDATE `count` = `count` + VALUES(`count`);"),
 				batchRows,
 			)
 			if err != nil {
@@ -8,10 +8,12 @@ import (
 	"strconv"
 	"time"
 
+	"github.com/guregu/null/v6"
 	"github.com/jellydator/ttlcache/v3"
 	log "github.com/sirupsen/logrus"
 
 	"golbat/db"
+	"golbat/webhooks"
 )
 
 func loadTappableFromDatabase(ctx context.Context, db db.DbDetails, id uint64, tappable *Tappable) error {
@@ -128,11 +130,47 @@ func saveTappableRecord(ctx context.Context, details db.DbDetails, tappable *Tap
 	}
 	tappable.ClearDirty()
 	if isNewRecord {
+		createTappableWebhooks(tappable)
 		tappableCache.Set(tappable.Id, tappable, ttlcache.DefaultTTL)
 		tappable.newRecord = false
 	}
 }
 
+type TappableWebhook struct {
+	Id                      string      `json:"id"`
+	Latitude                float64     `json:"latitude"`
+	Longitude               float64     `json:"longitude"`
+	FortId                  null.String `json:"fort_id"`
+	SpawnId                 null.Int    `json:"spawn_id"`
+	Type                    string      `json:"type"`
+	PokemonId               null.Int    `json:"pokemon_id"`
+	ItemId                  null.Int    `json:"item_id"`
+	Count                   null.Int    `json:"count"`
+	ExpireTimestamp         null.Int    `json:"expire_timestamp"`
+	ExpireTimestampVerified bool        `json:"expire_timestamp_verified"`
+	Updated                 int64       `json:"updated"`
+}
+
+// createTappableWebhooks announces a newly seen tappable. Tappables do not
+// change once seen, so there is nothing to send on later saves.
+func createTappableWebhooks(tappable *Tappable) {
+	tappableHook := TappableWebhook{
+		Id:                      strconv.FormatUint(tappable.Id, 10),
+		Latitude:                tappable.Lat,
+		Longitude:               tappable.Lon,
+		FortId:                  tappable.FortId,
+		SpawnId:                 tappable.SpawnId,
+		Type:                    tappable.Type,
+		PokemonId:               tappable.Encounter,
+		ItemId:                  tappable.ItemId,
+		Count:                   tappable.Count,
+		ExpireTimestamp:         tappable.ExpireTimestamp,
+		ExpireTimestampVerified: tappable.ExpireTimestampVerified,
+		Updated:                 tappable.Updated,
+	}
+	webhooksSender.AddMessage(webhooks.Tappable, tappableHook, MatchStatsGeofence(tappable.Lat, tappable.Lon))
+}
+
 // tappableWriteDB performs the actual database INSERT/UPDATE for a Tappable
 // This is called by both direct writes and the write-behind queue
 func tappableWriteDB(details db.DbDetails, tappable *Tappable, isNewRecord bool) error {
@@ -143,7 +181,7 @@ func tappableWriteDB(details db.DbDetails, tappable *Tappable, isNewRecord bool)
 			INSERT INTO tappable (
 				id, lat, lon, fort_id, spawn_id, type, pokemon_id, item_id, count, expire_timestamp, expire_timestamp_verified, updated
 			) VALUES (
-				"%d", :lat, :lon, :fort_id, :spawn_id, :type, :pokemon_id, :item_id, :count, :expire_timestamp, :expire_timestamp_verified, :updated
+				'%d', :lat, :lon, :fort_id, :spawn_id, :type, :pokemon_id, :item_id, :count, :expire_timestamp, :expire_timestamp_verified, :updated
 			)
 			`, tappable.Id), tappable)
 		statsCollector.IncDbQuery("insert tappable", err)
@@ -166,7 +204,7 @@ func tappableWriteDB(details db.DbDetails, tappable *Tappable, isNewRecord bool)
 				expire_timestamp = :expire_timestamp,
 				expire_timestamp_verified = :expire_timestamp_verified,
 				updated = :updated
-			WHERE id = "%d"
+			WHERE id = '%d'
 			`, tappable.Id), tappable)
 		statsCollector.IncDbQuery("update tappable", err)
 		if err != nil {
@@ -0,0 +1,145 @@
+package decoder
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"golbat/webhooks"
+)
+
+func init() {
+	webhooks.SetFilterParser(parseWebhookFilter)
+}
+
+// webhookFilter applies the filter clauses of one webhook destination, which
+// have the shape of the pokemon v3 and fort scan filters. A pokemon or fort
+// message is sent if it matches any of the clauses of its kind; when there
+// are no clauses of its kind, or the message is of another kind, it is sent.
+type webhookFilter struct {
+	pokemon []ApiPokemonDnfFilter3
+	forts   []ApiFortDnfFilter
+	// fortTypes holds the type of fort each fort clause is about, so that a
+	// raid clause does not match every pokestop
+	fortTypes []FortType
+}
+
+func parseWebhookFilter(pokemonFilters, fortFilters []map[string]any) (webhooks.MessageFilter, error) {
+	filter := &webhookFilter{}
+	if err := decodeWebhookFilterClauses(pokemonFilters, &filter.pokemon); err != nil {
+		return nil, fmt.Errorf("pokemon_filters: %w", err)
+	}
+	if err := decodeWebhookFilterClauses(fortFilters, &filter.forts); err != nil {
+		return nil, fmt.Errorf("fort_filters: %w", err)
+	}
+	for i := range filter.forts {
+		filter.fortTypes = append(filter.fortTypes, fortDnfFilterType(&filter.forts[i]))
+	}
+	return filter, nil
+}
+
+// fortDnfFilterType returns the type of fort a clause has conditions for, or
+// 0 if it only has conditions common to all forts.
+func fortDnfFilterType(filter *ApiFortDnfFilter) FortType {
+	switch {
+	case filter.AvailableSlots != nil || filter.TeamId != nil || filter.RaidLevel != nil || filter.RaidPokemon != nil:
+		return GYM
+	case filter.LureId != nil || filter.QuestRewardType != nil || filter.QuestRewardAmount != nil ||
+		filter.QuestRewardItemId != nil || filter.QuestRewardPokemon != nil ||
+		filter.IncidentDisplayType != nil || filter.IncidentStyle != nil || filter.IncidentCharacter != nil ||
+		filter.IncidentPokemon != nil || filter.ContestPokemon != nil || filter.ContestPokemonType != nil ||
+		filter.ContestTotalEntries != nil:
+		return POKESTOP
+	case filter.BattleLevel != nil || filter.BattlePokemon != nil:
+		return STATION
+	}
+	return 0
+}
+
+// decodeWebhookFilterClauses decodes clauses from the config through their
+// API JSON shape. Unknown fields are rejected, so a misspelt condition is not
+// silently ignored.
+func decodeWebhookFilterClauses[T any](clauses []map[string]any, out *[]T) error {
+	if len(clauses) == 0 {
+		return nil
+	}
+	data, err := json.Marshal(clauses)
+	if err != nil {
+		return err
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	return decoder.Decode(out)
+}
+
+// pokemonFilterSubject is implemented by the webhooks pokemon clauses apply to
+type pokemonFilterSubject interface {
+	pokemonFilterLookup() PokemonLookupCacheItem
+}
+
+// fortFilterSubject is implemented by the webhooks fort clauses apply to
+type fortFilterSubject interface {
+	fortFilterLookup() *FortLookup
+}
+
+func (hook PokemonWebhook) pokemonFilterLookup() PokemonLookupCacheItem { return hook.filterLookup }
+func (hook GymDetailsWebhook) fortFilterLookup() *FortLookup            { return hook.filterLookup }
+func (hook RaidWebhook) fortFilterLookup() *FortLookup                  { return hook.filterLookup }
+func (hook QuestWebhook) fortFilterLookup() *FortLookup                 { return hook.filterLookup }
+func (hook PokestopWebhook) fortFilterLookup() *FortLookup              { return hook.filterLookup }
+func (hook IncidentWebhook) fortFilterLookup() *FortLookup              { return hook.filterLookup }
+func (hook StationWebhook) fortFilterLookup() *FortLookup               { return hook.filterLookup }
+func (hook StationDetailsWebhook) fortFilterLookup() *FortLookup        { return hook.filterLookup }
+func (hook ContestWebhook) fortFilterLookup() *FortLookup               { return hook.filterLookup }
+
+func (filter *webhookFilter) Match(message any) bool {
+	switch subject := message.(type) {
+	case pokemonFilterSubject:
+		if len(filter.pokemon) == 0 {
+			return true
+		}
+		lookup := subject.pokemonFilterLookup()
+		if lookup.PokemonLookup == nil {
+			return false
+		}
+		for i := range filter.pokemon {
+			clause := &filter.pokemon[i]
+			if matchPokemonDnfIds(clause.Pokemon, lookup.PokemonLookup) &&
+				isPokemonDnfMatch3(lookup.PokemonLookup, lookup.PokemonPvpLookup, clause) {
+				return true
+			}
+		}
+		return false
+	case fortFilterSubject:
+		if len(filter.forts) == 0 {
+			return true
+		}
+		lookup := subject.fortFilterLookup()
+		if lookup == nil {
+			return false
+		}
+		now := time.Now().Unix()
+		for i := range filter.forts {
+			if isFortDnfMatch(filter.fortTypes[i], lookup, &filter.forts[i], now) {
+				return true
+			}
+		}
+		return false
+	}
+	return true
+}
+
+// matchPokemonDnfIds checks the pokemon/form list of a clause the same way the
+// scan does: an empty list, an id of 0 or a null form match anything.
+func matchPokemonDnfIds(ids []ApiPokemonDnfId, lookup *PokemonLookup) bool {
+	if len(ids) == 0 {
+		return true
+	}
+	for _, id := range ids {
+		if (id.Pokemon == 0 || id.Pokemon == lookup.PokemonId) && (id.Form == nil || *id.Form == lookup.Form) {
+			return true
+		}
+	}
+	return false
+}
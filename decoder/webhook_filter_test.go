@@ -0,0 +1,78 @@
+package decoder
+
+import (
+	"testing"
+	"time"
+)
+
+func TestWebhookFilterPokemon(t *testing.T) {
+	filter, err := parseWebhookFilter([]map[string]any{
+		{"iv": map[string]any{"min": 100, "max": 100}},
+		{"pokemon": []any{map[string]any{"id": 1}}, "pvp_great": map[string]any{"min": 1, "max": 10}},
+	}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	hook := func(pokemonId int16, iv int8, great int16) PokemonWebhook {
+		return PokemonWebhook{filterLookup: PokemonLookupCacheItem{
+			PokemonLookup:    &PokemonLookup{PokemonId: pokemonId, Iv: iv},
+			PokemonPvpLookup: &PokemonPvpLookup{Little: 4096, Great: great, Ultra: 4096},
+		}}
+	}
+	tests := []struct {
+		name string
+		hook PokemonWebhook
+		want bool
+	}{
+		{"hundo", hook(25, 100, 4096), true},
+		{"great league top 10", hook(1, 60, 3), true},
+		{"great league top 10 of another pokemon", hook(25, 60, 3), false},
+		{"neither", hook(1, 98, 11), false},
+		{"no iv", hook(1, -1, 4096), false},
+		{"no lookup", PokemonWebhook{}, false},
+	}
+	for _, tt := range tests {
+		if got := filter.Match(tt.hook); got != tt.want {
+			t.Errorf("%s: Match = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+	if !filter.Match(RaidWebhook{}) {
+		t.Error("raid rejected by a filter with only pokemon clauses")
+	}
+}
+
+func TestWebhookFilterForts(t *testing.T) {
+	filter, err := parseWebhookFilter(nil, []map[string]any{
+		{"raid_level": []any{5}},
+		{"lure_id": []any{502}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	future := time.Now().Unix() + 600
+
+	raid := func(level int8) RaidWebhook {
+		return RaidWebhook{filterLookup: &FortLookup{FortType: GYM, RaidLevel: level, RaidEndTimestamp: future}}
+	}
+	if !filter.Match(raid(5)) || filter.Match(raid(3)) {
+		t.Error("raid level clause not applied")
+	}
+	lured := PokestopWebhook{filterLookup: &FortLookup{FortType: POKESTOP, LureId: 502}}
+	unlured := PokestopWebhook{filterLookup: &FortLookup{FortType: POKESTOP}}
+	if !filter.Match(lured) || filter.Match(unlured) {
+		t.Error("lure clause not applied")
+	}
+	if !filter.Match(PokemonWebhook{}) || !filter.Match(WeatherWebhook{}) {
+		t.Error("messages without fort clauses rejected")
+	}
+}
+
+func TestWebhookFilterRejectsUnknownConditions(t *testing.T) {
+	if _, err := parseWebhookFilter([]map[string]any{{"ivv": map[string]any{"min": 100}}}, nil); err == nil {
+		t.Error("misspelt pokemon condition accepted")
+	}
+	if _, err := parseWebhookFilter(nil, []map[string]any{{"raid_level": "five"}}); err == nil {
+		t.Error("wrongly typed fort condition accepted")
+	}
+}
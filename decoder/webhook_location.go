@@ -0,0 +1,48 @@
+package decoder
+
+// Webhook payloads implement webhooks.Located, so live stream subscribers
+// can select them by bounding box.
+
+func (hook PokemonWebhook) WebhookLocation() (float64, float64) { return hook.Latitude, hook.Longitude }
+func (hook GymDetailsWebhook) WebhookLocation() (float64, float64) {
+	return hook.Latitude, hook.Longitude
+}
+func (hook RaidWebhook) WebhookLocation() (float64, float64) { return hook.Latitude, hook.Longitude }
+func (hook RaidLobbyWebhook) WebhookLocation() (float64, float64) {
+	return hook.Latitude, hook.Longitude
+}
+func (hook QuestWebhook) WebhookLocation() (float64, float64) { return hook.Latitude, hook.Longitude }
+func (hook PokestopWebhook) WebhookLocation() (float64, float64) {
+	return hook.Latitude, hook.Longitude
+}
+func (hook ContestWebhook) WebhookLocation() (float64, float64) { return hook.Latitude, hook.Longitude }
+func (hook IncidentWebhook) WebhookLocation() (float64, float64) {
+	return hook.Latitude, hook.Longitude
+}
+func (hook StationWebhook) WebhookLocation() (float64, float64) { return hook.Latitude, hook.Longitude }
+func (hook StationDetailsWebhook) WebhookLocation() (float64, float64) {
+	return hook.Latitude, hook.Longitude
+}
+func (hook MaxBattleLobbyWebhook) WebhookLocation() (float64, float64) {
+	return hook.Latitude, hook.Longitude
+}
+func (hook TappableWebhook) WebhookLocation() (float64, float64) {
+	return hook.Latitude, hook.Longitude
+}
+func (hook WeatherWebhook) WebhookLocation() (float64, float64) { return hook.Latitude, hook.Longitude }
+func (hook RouteWebhook) WebhookLocation() (float64, float64) {
+	return hook.StartLatitude, hook.StartLongitude
+}
+
+// WebhookLocation of a fort change is where the fort is now, or was if it
+// was removed.
+func (hook FortChangeWebhook) WebhookLocation() (float64, float64) {
+	fort := hook.New
+	if fort == nil {
+		fort = hook.Old
+	}
+	if fort == nil {
+		return 0, 0
+	}
+	return fort.Location.Latitude, fort.Location.Longitude
+}
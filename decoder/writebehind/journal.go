@@ -0,0 +1,168 @@
+package writebehind
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// journalSegmentSize is the size at which a journal moves on to a new segment
+// file, so that segments whose entries have all been written can be deleted
+const journalSegmentSize = 64 << 20
+
+// journal is an append-only record of the entries queued on a queue, kept in
+// numbered segment files so writes still pending when Golbat dies can be
+// replayed on the next start. Each segment counts the entries it holds that
+// are still queued. Segments are deleted oldest first once their count drops
+// to zero, so a replay never finds an old version of an entry without the
+// newer ones; the last is emptied when nothing at all is left.
+//
+// Records are buffered and only handed to the operating system by flush, so
+// queueing an entry costs no system call. Those still buffered when Golbat is
+// killed are lost.
+type journal struct {
+	mu      sync.Mutex
+	dir     string
+	name    string
+	file    *os.File
+	buf     *bufio.Writer
+	segment int
+	oldest  int
+	size    int64
+	live    map[int]int
+
+	// segments left by the previous run, oldest first
+	previous []string
+}
+
+// openJournal opens a journal for the queue name in dir, starting a segment
+// after any left by the previous run
+func openJournal(dir, name string) (*journal, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	previous, err := filepath.Glob(filepath.Join(dir, name+".*.journal"))
+	if err != nil {
+		return nil, err
+	}
+	j := &journal{dir: dir, name: name, live: make(map[int]int)}
+	for _, path := range previous {
+		var segment int
+		if _, err := fmt.Sscanf(filepath.Base(path), name+".%d.journal", &segment); err != nil {
+			continue
+		}
+		j.segment = max(j.segment, segment)
+		j.previous = append(j.previous, path)
+	}
+	// zero padded names sort in segment order
+	slices.Sort(j.previous)
+
+	j.oldest = j.segment + 1
+	if err := j.startSegment(); err != nil {
+		return nil, err
+	}
+	return j, nil
+}
+
+func (j *journal) path(segment int) string {
+	return filepath.Join(j.dir, fmt.Sprintf("%s.%09d.journal", j.name, segment))
+}
+
+// startSegment moves on to a new segment file (must be called with mu held)
+func (j *journal) startSegment() error {
+	file, err := os.OpenFile(j.path(j.segment+1), os.O_CREATE|os.O_WRONLY|os.O_APPEND|os.O_TRUNC, 0o644)
+	if err != nil {
+		return err
+	}
+	if j.file != nil {
+		if err := j.buf.Flush(); err != nil {
+			log.Errorf("Write-behind [%s] unable to write journal: %v", j.name, err)
+		}
+		j.file.Close()
+		j.buf.Reset(file)
+	} else {
+		j.buf = bufio.NewWriterSize(file, 64<<10)
+	}
+	j.file = file
+	j.segment++
+	j.size = 0
+	j.compact()
+	return nil
+}
+
+// compact deletes segments with no queued entries left, from the oldest up,
+// and empties the current one if it is all that is left (must be called with
+// mu held)
+func (j *journal) compact() {
+	for j.oldest < j.segment && j.live[j.oldest] == 0 {
+		delete(j.live, j.oldest)
+		os.Remove(j.path(j.oldest))
+		j.oldest++
+	}
+	if j.oldest == j.segment && j.live[j.segment] == 0 && j.size > 0 {
+		// everything buffered has been written to the database too
+		j.buf.Reset(j.file)
+		if err := j.file.Truncate(0); err == nil {
+			j.size = 0
+		}
+	}
+}
+
+// append buffers a record, which must end in a newline, and returns the
+// segment it went to. The record survives Golbat being killed once it has
+// been flushed, but not the machine losing power.
+func (j *journal) append(record []byte) (int, error) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	if j.size >= journalSegmentSize {
+		if err := j.startSegment(); err != nil {
+			return 0, err
+		}
+	}
+	n, err := j.buf.Write(record)
+	j.size += int64(n)
+	if err != nil {
+		return 0, err
+	}
+	j.live[j.segment]++
+	return j.segment, nil
+}
+
+// flush hands the buffered records to the operating system
+func (j *journal) flush() error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return j.buf.Flush()
+}
+
+// release marks entries recorded in the given segments as written or
+// superseded. Segment 0 stands for an entry that was never journaled.
+func (j *journal) release(segments ...int) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	for _, segment := range segments {
+		if segment != 0 {
+			j.live[segment]--
+		}
+	}
+	j.compact()
+}
+
+// removePrevious deletes the segments left by the previous run, once their
+// entries have been queued again
+func (j *journal) removePrevious() {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	for _, path := range j.previous {
+		os.Remove(path)
+	}
+	j.previous = nil
+}
@@ -10,6 +10,7 @@ import (
 
 // Flushable represents a queue that can be managed
 type Flushable interface {
+	Replay(ctx context.Context)
 	ProcessLoop(ctx context.Context)
 	Flush(ctx context.Context)
 	Size() int
@@ -54,6 +55,11 @@ func (m *QueueManager) Start(ctx context.Context) {
 	queues := m.queues
 	m.mu.RUnlock()
 
+	// Write out what a previous run left in the journals before anything new
+	for _, q := range queues {
+		q.Replay(m.ctx)
+	}
+
 	for _, q := range queues {
 		m.wg.Add(1)
 		go func(queue Flushable) {
@@ -216,3 +216,94 @@ func TestTypedQueueIntegerKey(t *testing.T) {
 		t.Errorf("Expected quality 2 (newer), got %d", entry.Data.quality)
 	}
 }
+
+func TestTypedQueueJournalReplay(t *testing.T) {
+	stats := stats_collector.NewNoopStatsCollector()
+
+	// journaled data needs exported fields
+	type journalData struct {
+		Key     string
+		Quality int
+	}
+
+	dir := t.TempDir()
+	var written []journalData
+	newQueue := func() *TypedQueue[string, journalData] {
+		return NewTypedQueue(TypedQueueConfig[string, journalData]{
+			Name:                "test",
+			BatchSize:           50,
+			BatchTimeout:        100 * time.Millisecond,
+			StartupDelaySeconds: 0,
+			Db:                  db.DbDetails{},
+			Stats:               stats,
+			FlushFunc: func(ctx context.Context, db db.DbDetails, entries []journalData) error {
+				written = append(written, entries...)
+				return nil
+			},
+			KeyFunc:    func(d journalData) string { return d.Key },
+			JournalDir: dir,
+		})
+	}
+
+	// A queue that never gets to write, as if Golbat was killed
+	q := newQueue()
+	q.Enqueue(journalData{Key: "test:1", Quality: 1}, true, time.Hour)
+	q.Enqueue(journalData{Key: "test:2", Quality: 1}, true, time.Hour)
+	q.Enqueue(journalData{Key: "test:1", Quality: 2}, false, time.Hour)
+	q.flushJournal() // as the process loop does every tick
+
+	// The next run writes out the latest version of each entry
+	q = newQueue()
+	q.Replay(context.Background())
+
+	if len(written) != 2 {
+		t.Fatalf("Expected 2 replayed entries, got %d", len(written))
+	}
+	if written[0] != (journalData{Key: "test:1", Quality: 2}) {
+		t.Errorf("Expected the newer test:1 to be replayed, got %+v", written[0])
+	}
+
+	// Once written, nothing is left to replay
+	q = newQueue()
+	if len(q.journal.previous) != 1 {
+		t.Fatalf("Expected the last run's journal segment, got %v", q.journal.previous)
+	}
+	written = nil
+	q.Replay(context.Background())
+	if len(written) != 0 {
+		t.Errorf("Expected nothing replayed after a successful write, got %+v", written)
+	}
+}
+
+func BenchmarkTypedQueueEnqueue(b *testing.B) {
+	type benchData struct {
+		Key     int
+		Quality int
+	}
+
+	for _, bench := range []struct {
+		name       string
+		journalDir string
+	}{
+		{"no journal", ""},
+		{"journal", b.TempDir()},
+	} {
+		b.Run(bench.name, func(b *testing.B) {
+			q := NewTypedQueue(TypedQueueConfig[int, benchData]{
+				Name:       "bench",
+				Db:         db.DbDetails{},
+				Stats:      stats_collector.NewNoopStatsCollector(),
+				FlushFunc:  func(ctx context.Context, db db.DbDetails, entries []benchData) error { return nil },
+				KeyFunc:    func(d benchData) int { return d.Key },
+				JournalDir: bench.journalDir,
+			})
+			b.RunParallel(func(pb *testing.PB) {
+				i := 0
+				for pb.Next() {
+					q.Enqueue(benchData{Key: i % 1000, Quality: i}, false, time.Hour)
+					i++
+				}
+			})
+		})
+	}
+}
@@ -3,6 +3,10 @@ package writebehind
 import (
 	"cmp"
 	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
 	"slices"
 	"sync"
 	"time"
@@ -28,6 +32,14 @@ type Entry[K cmp.Ordered, T any] struct {
 	ReadyAt     time.Time     // When the entry becomes eligible for dispatch
 	IsNewRecord bool          // Track if this needs INSERT (preserved across updates)
 	Delay       time.Duration // Minimum delay before writing (0 = immediate)
+
+	journalSegment int // journal segment holding this version, 0 if not journaled
+}
+
+// journalRecord is how an enqueued entry is written to the journal
+type journalRecord[T any] struct {
+	IsNewRecord bool `json:"new"`
+	Data        T    `json:"data"`
 }
 
 // TypedQueueConfig holds configuration for a typed queue
@@ -43,6 +55,9 @@ type TypedQueueConfig[K cmp.Ordered, T any] struct {
 	FlushFunc func(ctx context.Context, db db.DbDetails, entries []T) error
 	// KeyFunc extracts the unique key from an entry's data
 	KeyFunc func(data T) K
+	// JournalDir keeps a journal of queued entries in this directory, to be
+	// replayed if Golbat stops before writing them. Empty disables it.
+	JournalDir string
 }
 
 // TypedQueue is a type-safe write-behind queue for a specific entity type
@@ -63,6 +78,7 @@ type TypedQueue[K cmp.Ordered, T any] struct {
 	keyFunc      func(data T) K
 	db           db.DbDetails
 	stats        stats_collector.StatsCollector
+	journal      *journal
 
 	// Warmup tracking
 	warmupComplete bool
@@ -87,7 +103,7 @@ func NewTypedQueue[K cmp.Ordered, T any](cfg TypedQueueConfig[K, T]) *TypedQueue
 		cfg.BatchTimeout = 100 * time.Millisecond
 	}
 
-	return &TypedQueue[K, T]{
+	q := &TypedQueue[K, T]{
 		pending:        make(map[K]*Entry[K, T]),
 		batchPending:   make(map[K]*Entry[K, T]),
 		name:           cfg.Name,
@@ -102,12 +118,25 @@ func NewTypedQueue[K cmp.Ordered, T any](cfg TypedQueueConfig[K, T]) *TypedQueue
 		startTime:      time.Now(),
 		startupDelay:   time.Duration(cfg.StartupDelaySeconds) * time.Second,
 	}
+
+	if cfg.JournalDir != "" {
+		journal, err := openJournal(cfg.JournalDir, cfg.Name)
+		if err != nil {
+			log.Errorf("Write-behind [%s] journal disabled, unable to open: %v", cfg.Name, err)
+		} else {
+			q.journal = journal
+		}
+	}
+	return q
 }
 
 // Enqueue adds or updates an entry in the queue
 // Takes data snapshot directly - caller is responsible for calling while holding entity lock
 func (q *TypedQueue[K, T]) Enqueue(data T, isNewRecord bool, delay time.Duration) {
 	key := q.keyFunc(data)
+	// Journaled before taking the queue lock; the entity lock keeps the
+	// versions of one entry in order
+	segment := q.appendJournal(data, isNewRecord)
 
 	q.mu.Lock()
 	defer q.mu.Unlock()
@@ -115,6 +144,12 @@ func (q *TypedQueue[K, T]) Enqueue(data T, isNewRecord bool, delay time.Duration
 	now := time.Now()
 
 	if existing, ok := q.pending[key]; ok {
+		// The journal now holds the newer data
+		if q.journal != nil {
+			q.journal.release(existing.journalSegment)
+		}
+		existing.journalSegment = segment
+
 		// Update existing entry with newer data
 		existing.Data = data
 		existing.UpdatedAt = now
@@ -139,12 +174,95 @@ func (q *TypedQueue[K, T]) Enqueue(data T, isNewRecord bool, delay time.Duration
 			ReadyAt:     readyAt,
 			IsNewRecord: isNewRecord,
 			Delay:       delay,
+
+			journalSegment: segment,
 		}
 	}
 
 	q.stats.SetWriteBehindQueueDepth(q.name, float64(len(q.pending)))
 }
 
+// appendJournal records data in the journal, returning the segment it went
+// to, or 0 if it wasn't journaled
+func (q *TypedQueue[K, T]) appendJournal(data T, isNewRecord bool) int {
+	if q.journal == nil {
+		return 0
+	}
+	record, err := json.Marshal(journalRecord[T]{IsNewRecord: isNewRecord, Data: data})
+	if err != nil {
+		log.Errorf("Write-behind [%s] unable to journal entry: %v", q.name, err)
+		return 0
+	}
+	segment, err := q.journal.append(append(record, '\n'))
+	if err != nil {
+		log.Errorf("Write-behind [%s] unable to journal entry: %v", q.name, err)
+		return 0
+	}
+	return segment
+}
+
+// flushJournal hands the journal's buffered records to the operating system
+func (q *TypedQueue[K, T]) flushJournal() {
+	if q.journal == nil {
+		return
+	}
+	if err := q.journal.flush(); err != nil {
+		log.Errorf("Write-behind [%s] unable to write journal: %v", q.name, err)
+	}
+}
+
+// Replay queues again the entries journaled by a previous run that never
+// reached the database, and writes them out ahead of the startup delay.
+// Call before anything else is queued.
+func (q *TypedQueue[K, T]) Replay(ctx context.Context) {
+	if q.journal == nil || len(q.journal.previous) == 0 {
+		return
+	}
+
+	count := 0
+	for _, path := range q.journal.previous {
+		n, err := q.replayFile(path)
+		count += n
+		if err != nil {
+			log.Warnf("Write-behind [%s] journal %s ends early after %d entries: %v", q.name, path, n, err)
+		}
+	}
+	// Everything read is journaled again in the current segment
+	q.flushJournal()
+	q.journal.removePrevious()
+
+	if count == 0 {
+		return
+	}
+	log.Infof("Write-behind [%s] replaying %d journaled entries", q.name, count)
+	q.Flush(ctx)
+}
+
+// replayFile queues the entries of one journal segment, returning how many it
+// read before the end or a torn record
+func (q *TypedQueue[K, T]) replayFile(path string) (int, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return 0, err
+	}
+	defer file.Close()
+
+	decoder := json.NewDecoder(file)
+	count := 0
+	for {
+		var record journalRecord[T]
+		err := decoder.Decode(&record)
+		if errors.Is(err, io.EOF) {
+			return count, nil
+		}
+		if err != nil {
+			return count, err
+		}
+		q.Enqueue(record.Data, record.IsNewRecord, 0)
+		count++
+	}
+}
+
 // Size returns the current pending queue size
 func (q *TypedQueue[K, T]) Size() int {
 	q.mu.Lock()
@@ -201,6 +319,7 @@ func (q *TypedQueue[K, T]) ProcessLoop(ctx context.Context) {
 			q.Flush(context.Background())
 			return
 		case <-ticker.C:
+			q.flushJournal()
 			if q.checkWarmup() {
 				q.dispatchReady(ctx)
 			}
@@ -242,6 +361,9 @@ func (q *TypedQueue[K, T]) addToBatch(ctx context.Context, entry *Entry[K, T]) {
 
 	// Deduplicate within batch (squash)
 	if existing, ok := q.batchPending[entry.Key]; ok {
+		if q.journal != nil {
+			q.journal.release(existing.journalSegment)
+		}
 		entry.IsNewRecord = entry.IsNewRecord || existing.IsNewRecord
 		if existing.QueuedAt.Before(entry.QueuedAt) {
 			entry.QueuedAt = existing.QueuedAt
@@ -328,6 +450,16 @@ func (q *TypedQueue[K, T]) flushBatchLocked(ctx context.Context) {
 	batchTime := time.Since(start).Seconds()
 	entryCount := len(entries)
 
+	// A failed batch is dropped, from the journal as from memory: replaying
+	// it later could overwrite newer data
+	if q.journal != nil {
+		segments := make([]int, len(entries))
+		for i, entry := range entries {
+			segments[i] = entry.journalSegment
+		}
+		q.journal.release(segments...)
+	}
+
 	if err != nil {
 		q.stats.IncWriteBehindErrors(q.name)
 		log.Errorf("Write-behind [%s] batch error (%d entries): %v", q.name, entryCount, err)
@@ -379,6 +511,8 @@ func (q *TypedQueue[K, T]) Flush(ctx context.Context) {
 		q.flushBatchLocked(ctx)
 	}
 	q.batchMu.Unlock()
+
+	q.flushJournal()
 }
 
 // TypedQueueMetrics holds the metrics for a typed queue
@@ -44,6 +44,7 @@ func InitTypedQueues(ctx context.Context, dbDetails db.DbDetails, stats stats_co
 	batchSize := config.Config.Tuning.WriteBehindBatchSize
 	batchTimeout := time.Duration(config.Config.Tuning.WriteBehindBatchTimeoutMs) * time.Millisecond
 	workerCount := config.Config.Tuning.WriteBehindWorkerCount
+	journalDir := config.Config.Tuning.WriteBehindJournal
 
 	if batchSize <= 0 {
 		batchSize = 50
@@ -72,6 +73,7 @@ func InitTypedQueues(ctx context.Context, dbDetails db.DbDetails, stats stats_co
 		Stats:               stats,
 		FlushFunc:           flushPokestopBatch,
 		KeyFunc:             func(d PokestopData) string { return d.Id },
+		JournalDir:          journalDir,
 	})
 	queueManager.Register(pokestopQueue)
 
@@ -85,6 +87,7 @@ func InitTypedQueues(ctx context.Context, dbDetails db.DbDetails, stats stats_co
 		Stats:               stats,
 		FlushFunc:           flushGymBatch,
 		KeyFunc:             func(d GymData) string { return d.Id },
+		JournalDir:          journalDir,
 	})
 	queueManager.Register(gymQueue)
 
@@ -98,6 +101,7 @@ func InitTypedQueues(ctx context.Context, dbDetails db.DbDetails, stats stats_co
 		Stats:               stats,
 		FlushFunc:           flushPokemonBatchTyped,
 		KeyFunc:             func(d PokemonData) uint64 { return uint64(d.Id) },
+		JournalDir:          journalDir,
 	})
 	queueManager.Register(pokemonQueue)
 
@@ -111,6 +115,7 @@ func InitTypedQueues(ctx context.Context, dbDetails db.DbDetails, stats stats_co
 		Stats:               stats,
 		FlushFunc:           flushSpawnpointBatch,
 		KeyFunc:             func(d SpawnpointData) int64 { return d.Id },
+		JournalDir:          journalDir,
 	})
 	queueManager.Register(spawnpointQueue)
 
@@ -124,6 +129,7 @@ func InitTypedQueues(ctx context.Context, dbDetails db.DbDetails, stats stats_co
 		Stats:               stats,
 		FlushFunc:           flushRouteBatch,
 		KeyFunc:             func(d RouteData) string { return d.Id },
+		JournalDir:          journalDir,
 	})
 	queueManager.Register(routeQueue)
 
@@ -137,6 +143,7 @@ func InitTypedQueues(ctx context.Context, dbDetails db.DbDetails, stats stats_co
 		Stats:               stats,
 		FlushFunc:           flushTappableBatch,
 		KeyFunc:             func(d TappableData) uint64 { return d.Id },
+		JournalDir:          journalDir,
 	})
 	queueManager.Register(tappableQueue)
 
@@ -150,6 +157,7 @@ func InitTypedQueues(ctx context.Context, dbDetails db.DbDetails, stats stats_co
 		Stats:               stats,
 		FlushFunc:           flushStationBatch,
 		KeyFunc:             func(d StationData) string { return d.Id },
+		JournalDir:          journalDir,
 	})
 	queueManager.Register(stationQueue)
 
@@ -163,6 +171,7 @@ func InitTypedQueues(ctx context.Context, dbDetails db.DbDetails, stats stats_co
 		Stats:               stats,
 		FlushFunc:           flushStationBattleBatch,
 		KeyFunc:             func(d stationBattleWrite) string { return d.StationId },
+		JournalDir:          journalDir,
 	})
 	queueManager.Register(stationBattleQueue)
 
@@ -176,6 +185,7 @@ func InitTypedQueues(ctx context.Context, dbDetails db.DbDetails, stats stats_co
 		Stats:               stats,
 		FlushFunc:           flushIncidentBatch,
 		KeyFunc:             func(d IncidentData) string { return d.Id },
+		JournalDir:          journalDir,
 	})
 	queueManager.Register(incidentQueue)
 
@@ -189,6 +199,7 @@ func InitTypedQueues(ctx context.Context, dbDetails db.DbDetails, stats stats_co
 		Stats:               stats,
 		FlushFunc:           flushS2CellBatch,
 		KeyFunc:             func(d S2CellData) uint64 { return d.Id },
+		JournalDir:          journalDir,
 	})
 	queueManager.Register(s2cellQueue)
 
@@ -224,47 +235,47 @@ func StopTypedQueues() {
 // Flush functions for typed queues - receive []T directly, no type assertions needed
 
 func flushPokestopBatch(ctx context.Context, dbDetails db.DbDetails, pokestops []PokestopData) error {
-	_, err := dbDetails.GeneralDb.NamedExecContext(ctx, pokestopBatchUpsertQuery, pokestops)
+	_, err := dbDetails.GeneralDb.NamedExecContext(ctx, db.Rebind(dbDetails.GeneralDb, pokestopBatchUpsertQuery), pokestops)
 	return err
 }
 
 func flushGymBatch(ctx context.Context, dbDetails db.DbDetails, gyms []GymData) error {
-	_, err := dbDetails.GeneralDb.NamedExecContext(ctx, gymBatchUpsertQuery, gyms)
+	_, err := dbDetails.GeneralDb.NamedExecContext(ctx, db.Rebind(dbDetails.GeneralDb, gymBatchUpsertQuery), gyms)
 	return err
 }
 
 func flushPokemonBatchTyped(ctx context.Context, dbDetails db.DbDetails, pokemon []PokemonData) error {
-	_, err := dbDetails.PokemonDb.NamedExecContext(ctx, pokemonBatchUpsertQuery, pokemon)
+	_, err := dbDetails.PokemonDb.NamedExecContext(ctx, db.Rebind(dbDetails.PokemonDb, pokemonBatchUpsertQuery), pokemon)
 	return err
 }
 
 func flushSpawnpointBatch(ctx context.Context, dbDetails db.DbDetails, spawnpoints []SpawnpointData) error {
-	_, err := dbDetails.GeneralDb.NamedExecContext(ctx, spawnpointBatchUpsertQuery, spawnpoints)
+	_, err := dbDetails.GeneralDb.NamedExecContext(ctx, db.Rebind(dbDetails.GeneralDb, spawnpointBatchUpsertQuery), spawnpoints)
 	return err
 }
 
 func flushRouteBatch(ctx context.Context, dbDetails db.DbDetails, routes []RouteData) error {
-	_, err := dbDetails.GeneralDb.NamedExecContext(ctx, routeBatchUpsertQuery, routes)
+	_, err := dbDetails.GeneralDb.NamedExecContext(ctx, db.Rebind(dbDetails.GeneralDb, routeBatchUpsertQuery), routes)
 	return err
 }
 
 func flushTappableBatch(ctx context.Context, dbDetails db.DbDetails, tappables []TappableData) error {
-	_, err := dbDetails.GeneralDb.NamedExecContext(ctx, tappableBatchUpsertQuery, tappables)
+	_, err := dbDetails.GeneralDb.NamedExecContext(ctx, db.Rebind(dbDetails.GeneralDb, tappableBatchUpsertQuery), tappables)
 	return err
 }
 
 func flushStationBatch(ctx context.Context, dbDetails db.DbDetails, stations []StationData) error {
-	_, err := dbDetails.GeneralDb.NamedExecContext(ctx, stationBatchUpsertQuery, stations)
+	_, err := dbDetails.GeneralDb.NamedExecContext(ctx, db.Rebind(dbDetails.GeneralDb, stationBatchUpsertQuery), stations)
 	return err
 }
 
 func flushIncidentBatch(ctx context.Context, dbDetails db.DbDetails, incidents []IncidentData) error {
-	_, err := dbDetails.GeneralDb.NamedExecContext(ctx, incidentBatchUpsertQuery, incidents)
+	_, err := dbDetails.GeneralDb.NamedExecContext(ctx, db.Rebind(dbDetails.GeneralDb, incidentBatchUpsertQuery), incidents)
 	return err
 }
 
 func flushS2CellBatch(ctx context.Context, dbDetails db.DbDetails, cells []S2CellData) error {
-	_, err := dbDetails.GeneralDb.NamedExecContext(ctx, s2cellBatchUpsertQuery, cells)
+	_, err := dbDetails.GeneralDb.NamedExecContext(ctx, db.Rebind(dbDetails.GeneralDb, s2cellBatchUpsertQuery), cells)
 	if err != nil {
 		log.Errorf("flushS2CellBatch: %s", err)
 	}
@@ -273,7 +284,7 @@ func flushS2CellBatch(ctx context.Context, dbDetails db.DbDetails, cells []S2Cel
 }
 
 // Batch upsert queries - using INSERT ... ON DUPLICATE KEY UPDATE
-// This eliminates need to track isNewRecord
+// This eliminates need to track isNewRecord. db.Rebind rewrites them for SQLite
 
 const pokestopBatchUpsertQuery = `
 INSERT INTO pokestop (
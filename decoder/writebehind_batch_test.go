@@ -0,0 +1,84 @@
+package decoder
+
+import (
+	"context"
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"golbat/db"
+	"golbat/stats_collector"
+
+	"github.com/guregu/null/v6"
+)
+
+// openSQLiteTestDb returns a fresh SQLite database with the Golbat schema
+func openSQLiteTestDb(t *testing.T) db.DbDetails {
+	t.Helper()
+	x := db.OpenSQLite(db.SQLiteDSN(filepath.Join(t.TempDir(), "golbat.db")))
+	t.Cleanup(func() { x.Close() })
+
+	schema, err := os.ReadFile("../sql/sqlite/1_schema.up.sql")
+	if err != nil {
+		t.Fatal(err)
+	}
+	x.MustExec(string(schema))
+	return db.DbDetails{PokemonDb: x, UsePokemonCache: true, GeneralDb: x}
+}
+
+func TestFlushBatchesSQLite(t *testing.T) {
+	previousStats := statsCollector
+	statsCollector = stats_collector.NewNoopStatsCollector()
+	defer func() { statsCollector = previousStats }()
+
+	dbDetails := openSQLiteTestDb(t)
+	ctx := context.Background()
+
+	flushes := map[string]func(name string) error{
+		"pokestop": func(name string) error {
+			return flushPokestopBatch(ctx, dbDetails, []PokestopData{{Id: "stop", Name: null.StringFrom(name)}})
+		},
+		"gym": func(name string) error {
+			return flushGymBatch(ctx, dbDetails, []GymData{{Id: "gym", Name: null.StringFrom(name)}})
+		},
+		"pokemon": func(string) error {
+			return flushPokemonBatchTyped(ctx, dbDetails, []PokemonData{{Id: math.MaxUint64, PokemonId: 25}})
+		},
+		"spawnpoint": func(string) error {
+			return flushSpawnpointBatch(ctx, dbDetails, []SpawnpointData{{Id: 1}})
+		},
+		"route": func(name string) error {
+			return flushRouteBatch(ctx, dbDetails, []RouteData{{Id: "route", Name: name}})
+		},
+		"tappable": func(string) error {
+			return flushTappableBatch(ctx, dbDetails, []TappableData{{Id: math.MaxUint64}})
+		},
+		"station": func(name string) error {
+			return flushStationBatch(ctx, dbDetails, []StationData{{Id: "station", Name: name}})
+		},
+		"incident": func(string) error {
+			return flushIncidentBatch(ctx, dbDetails, []IncidentData{{Id: "incident", PokestopId: "stop"}})
+		},
+		"s2cell": func(string) error {
+			return flushS2CellBatch(ctx, dbDetails, []S2CellData{{Id: math.MaxUint64}})
+		},
+	}
+	for table, flush := range flushes {
+		// the second flush updates the row the first one inserted
+		for _, name := range []string{"first", "second"} {
+			if err := flush(name); err != nil {
+				t.Fatalf("%s: %s", table, err)
+			}
+		}
+		var count int
+		if err := dbDetails.GeneralDb.Get(&count, "SELECT COUNT(*) FROM "+table); err != nil || count != 1 {
+			t.Errorf("%s: %d rows (%v), want 1", table, count, err)
+		}
+	}
+
+	var name string
+	if err := dbDetails.GeneralDb.Get(&name, "SELECT name FROM pokestop WHERE id = 'stop'"); err != nil || name != "second" {
+		t.Errorf("pokestop name %q (%v), want it updated", name, err)
+	}
+}
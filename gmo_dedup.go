@@ -0,0 +1,114 @@
+package main
+
+import (
+	"context"
+	"encoding/binary"
+	"hash/maphash"
+	"time"
+
+	"golbat/config"
+	"golbat/decoder"
+	"golbat/pogo"
+
+	"github.com/golang/geo/s2"
+	"github.com/jellydator/ttlcache/v3"
+	"google.golang.org/protobuf/proto"
+)
+
+// gmoCellKey identifies a map cell as processed under one set of scan
+// parameters, so a device that processes less of a cell (for example no
+// pokemon) does not stop another from processing the rest.
+type gmoCellKey struct {
+	cell   uint64
+	params decoder.ScanParameters
+}
+
+var (
+	// gmoCellHashes holds the content hash of each recently processed map
+	// cell. Entries are not refreshed on a hit, so an unchanged cell is still
+	// processed once per window.
+	gmoCellHashes *ttlcache.Cache[gmoCellKey, uint64]
+	gmoHashSeed   = maphash.MakeSeed()
+)
+
+// InitGmoDedup starts the GMO cell deduplication window if one is configured.
+func InitGmoDedup(ctx context.Context) {
+	window := config.Config.Tuning.GmoDedupWindow
+	if window <= 0 {
+		return
+	}
+	gmoCellHashes = ttlcache.New[gmoCellKey, uint64](
+		ttlcache.WithTTL[gmoCellKey, uint64](time.Duration(window)*time.Second),
+		ttlcache.WithDisableTouchOnHit[gmoCellKey, uint64](),
+	)
+	go gmoCellHashes.Start()
+	go func() {
+		<-ctx.Done()
+		gmoCellHashes.Stop()
+	}()
+}
+
+// gmoCellUnchanged reports whether mapCell has the same content as when it was
+// last processed within the dedup window, recording its content if not.
+// weather is the GMO's weather keyed by level 10 cell.
+func gmoCellUnchanged(mapCell *pogo.ClientMapCellProto, weather map[int64]*pogo.ClientWeatherProto, scanParameters decoder.ScanParameters) bool {
+	if gmoCellHashes == nil {
+		return false
+	}
+	key := gmoCellKey{cell: mapCell.S2CellId, params: scanParameters}
+	hash := gmoCellHash(mapCell, weather[int64(s2.CellID(mapCell.S2CellId).Parent(10))])
+	if item := gmoCellHashes.Get(key); item != nil && item.Value() == hash {
+		return true
+	}
+	gmoCellHashes.Set(key, hash, ttlcache.DefaultTTL)
+	return false
+}
+
+// gmoCellHash hashes what Golbat processes from a map cell: forts (with their
+// lure pokemon), wild, nearby and catchable pokemon, stations and the cell's
+// weather. Fields that change with every request without the cell changing,
+// such as the time until a wild pokemon despawns or the distance to a nearby
+// one, are left out. Anything else in the cell, such as tappables (which are
+// only processed from ProcessTappable responses), is not hashed, so a change
+// to it alone does not get the cell processed again within the window.
+func gmoCellHash(mapCell *pogo.ClientMapCellProto, weather *pogo.ClientWeatherProto) uint64 {
+	var h maphash.Hash
+	h.SetSeed(gmoHashSeed)
+	marshal := proto.MarshalOptions{Deterministic: true}
+	var buf []byte
+	writeProto := func(m proto.Message) {
+		buf, _ = marshal.MarshalAppend(buf[:0], m)
+		binary.Write(&h, binary.LittleEndian, uint32(len(buf)))
+		h.Write(buf)
+	}
+
+	for _, fort := range mapCell.Fort {
+		writeProto(fort)
+	}
+	h.WriteByte(0)
+	for _, mon := range mapCell.WildPokemon {
+		binary.Write(&h, binary.LittleEndian, mon.EncounterId)
+		h.WriteString(mon.SpawnPointId)
+		writeProto(mon.Pokemon)
+	}
+	h.WriteByte(0)
+	for _, mon := range mapCell.NearbyPokemon {
+		binary.Write(&h, binary.LittleEndian, mon.EncounterId)
+		binary.Write(&h, binary.LittleEndian, mon.PokedexNumber)
+		h.WriteString(mon.FortId)
+		writeProto(mon.PokemonDisplay)
+	}
+	h.WriteByte(0)
+	for _, mon := range mapCell.CatchablePokemon {
+		writeProto(mon)
+	}
+	h.WriteByte(0)
+	for _, station := range mapCell.Stations {
+		writeProto(station)
+	}
+	h.WriteByte(0)
+	if weather != nil {
+		writeProto(weather)
+	}
+	return h.Sum64()
+}
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"context"
+	"testing"
+
+	"golbat/config"
+	"golbat/decoder"
+	"golbat/pogo"
+
+	"github.com/golang/geo/s2"
+)
+
+func TestGmoCellUnchanged(t *testing.T) {
+	previous := config.Config.Tuning.GmoDedupWindow
+	config.Config.Tuning.GmoDedupWindow = 60
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(func() {
+		cancel()
+		gmoCellHashes = nil
+		config.Config.Tuning.GmoDedupWindow = previous
+	})
+	InitGmoDedup(ctx)
+
+	cellId := uint64(s2.CellIDFromLatLng(s2.LatLngFromDegrees(51.5, -0.1)).Parent(15))
+	weatherId := int64(s2.CellID(cellId).Parent(10))
+	newCell := func(timeTillHidden int32) *pogo.ClientMapCellProto {
+		return &pogo.ClientMapCellProto{
+			S2CellId: cellId,
+			Fort:     []*pogo.PokemonFortProto{{FortId: "fort-1", LastModifiedMs: 1000}},
+			WildPokemon: []*pogo.WildPokemonProto{{
+				EncounterId:      1,
+				SpawnPointId:     "spawn-1",
+				TimeTillHiddenMs: timeTillHidden,
+			}},
+		}
+	}
+	weather := map[int64]*pogo.ClientWeatherProto{weatherId: {S2CellId: weatherId}}
+	params := decoder.ScanParameters{ProcessPokemon: true, ProcessGyms: true}
+
+	if gmoCellUnchanged(newCell(30000), weather, params) {
+		t.Fatal("first sighting skipped")
+	}
+	if !gmoCellUnchanged(newCell(25000), weather, params) {
+		t.Error("same content with a later despawn countdown was processed again")
+	}
+
+	changed := newCell(25000)
+	changed.Fort[0].LastModifiedMs = 2000
+	if gmoCellUnchanged(changed, weather, params) {
+		t.Error("changed fort skipped")
+	}
+	if !gmoCellUnchanged(changed, weather, params) {
+		t.Error("changed fort processed twice")
+	}
+
+	catchable := newCell(25000)
+	catchable.Fort[0].LastModifiedMs = 2000
+	catchable.CatchablePokemon = []*pogo.MapPokemonProto{{EncounterId: 2}}
+	if gmoCellUnchanged(catchable, weather, params) {
+		t.Error("cell skipped when only its catchable pokemon changed")
+	}
+
+	otherParams := decoder.ScanParameters{ProcessPokemon: true}
+	if gmoCellUnchanged(changed, weather, otherParams) {
+		t.Error("cell skipped for different scan parameters")
+	}
+
+	newWeather := map[int64]*pogo.ClientWeatherProto{weatherId: {S2CellId: weatherId, GameplayWeather: &pogo.GameplayWeatherProto{
+		GameplayCondition: pogo.GameplayWeatherProto_RAINY,
+	}}}
+	if gmoCellUnchanged(changed, newWeather, params) {
+		t.Error("cell skipped after its weather changed")
+	}
+}
+
+func TestGmoCellUnchangedDisabled(t *testing.T) {
+	cell := &pogo.ClientMapCellProto{S2CellId: 1}
+	for i := 0; i < 2; i++ {
+		if gmoCellUnchanged(cell, nil, decoder.ScanParameters{}) {
+			t.Fatal("cell skipped without a dedup window")
+		}
+	}
+}
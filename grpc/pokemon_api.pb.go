@@ -1125,6 +1125,2391 @@ func (x *PokemonDetails) GetDisplayPokemonForm() int32 {
 	return 0
 }
 
+// Types are webhook type names as used in the webhooks config ("pokemon",
+// "raid", ...); all supported types when empty. Areas are geofence names,
+// "parent/name" or "name", with * as a wildcard.
+type SubscribeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Types         []string               `protobuf:"bytes,1,rep,name=types,proto3" json:"types,omitempty"`
+	Areas         []string               `protobuf:"bytes,2,rep,name=areas,proto3" json:"areas,omitempty"`
+	Bbox          *BoundingBox           `protobuf:"bytes,3,opt,name=bbox,proto3,oneof" json:"bbox,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SubscribeRequest) Reset() {
+	*x = SubscribeRequest{}
+	mi := &file_grpc_pokemon_api_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubscribeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubscribeRequest) ProtoMessage() {}
+
+func (x *SubscribeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_grpc_pokemon_api_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubscribeRequest.ProtoReflect.Descriptor instead.
+func (*SubscribeRequest) Descriptor() ([]byte, []int) {
+	return file_grpc_pokemon_api_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *SubscribeRequest) GetTypes() []string {
+	if x != nil {
+		return x.Types
+	}
+	return nil
+}
+
+func (x *SubscribeRequest) GetAreas() []string {
+	if x != nil {
+		return x.Areas
+	}
+	return nil
+}
+
+func (x *SubscribeRequest) GetBbox() *BoundingBox {
+	if x != nil {
+		return x.Bbox
+	}
+	return nil
+}
+
+type BoundingBox struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MinLat        float64                `protobuf:"fixed64,1,opt,name=min_lat,json=minLat,proto3" json:"min_lat,omitempty"`
+	MinLon        float64                `protobuf:"fixed64,2,opt,name=min_lon,json=minLon,proto3" json:"min_lon,omitempty"`
+	MaxLat        float64                `protobuf:"fixed64,3,opt,name=max_lat,json=maxLat,proto3" json:"max_lat,omitempty"`
+	MaxLon        float64                `protobuf:"fixed64,4,opt,name=max_lon,json=maxLon,proto3" json:"max_lon,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BoundingBox) Reset() {
+	*x = BoundingBox{}
+	mi := &file_grpc_pokemon_api_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BoundingBox) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BoundingBox) ProtoMessage() {}
+
+func (x *BoundingBox) ProtoReflect() protoreflect.Message {
+	mi := &file_grpc_pokemon_api_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BoundingBox.ProtoReflect.Descriptor instead.
+func (*BoundingBox) Descriptor() ([]byte, []int) {
+	return file_grpc_pokemon_api_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *BoundingBox) GetMinLat() float64 {
+	if x != nil {
+		return x.MinLat
+	}
+	return 0
+}
+
+func (x *BoundingBox) GetMinLon() float64 {
+	if x != nil {
+		return x.MinLon
+	}
+	return 0
+}
+
+func (x *BoundingBox) GetMaxLat() float64 {
+	if x != nil {
+		return x.MaxLat
+	}
+	return 0
+}
+
+func (x *BoundingBox) GetMaxLon() float64 {
+	if x != nil {
+		return x.MaxLon
+	}
+	return 0
+}
+
+// Event carries one webhook message. schema_version is raised when a change
+// to the event messages is not backwards compatible.
+type Event struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SchemaVersion uint32                 `protobuf:"varint,1,opt,name=schema_version,json=schemaVersion,proto3" json:"schema_version,omitempty"`
+	// Types that are valid to be assigned to Event:
+	//
+	//	*Event_Pokemon
+	//	*Event_Gym
+	//	*Event_Raid
+	//	*Event_Pokestop
+	//	*Event_Quest
+	//	*Event_Invasion
+	//	*Event_MaxBattle
+	//	*Event_Station
+	//	*Event_Weather
+	//	*Event_Dropped
+	Event         isEvent_Event `protobuf_oneof:"event"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Event) Reset() {
+	*x = Event{}
+	mi := &file_grpc_pokemon_api_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Event) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Event) ProtoMessage() {}
+
+func (x *Event) ProtoReflect() protoreflect.Message {
+	mi := &file_grpc_pokemon_api_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Event.ProtoReflect.Descriptor instead.
+func (*Event) Descriptor() ([]byte, []int) {
+	return file_grpc_pokemon_api_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *Event) GetSchemaVersion() uint32 {
+	if x != nil {
+		return x.SchemaVersion
+	}
+	return 0
+}
+
+func (x *Event) GetEvent() isEvent_Event {
+	if x != nil {
+		return x.Event
+	}
+	return nil
+}
+
+func (x *Event) GetPokemon() *PokemonEvent {
+	if x != nil {
+		if x, ok := x.Event.(*Event_Pokemon); ok {
+			return x.Pokemon
+		}
+	}
+	return nil
+}
+
+func (x *Event) GetGym() *GymEvent {
+	if x != nil {
+		if x, ok := x.Event.(*Event_Gym); ok {
+			return x.Gym
+		}
+	}
+	return nil
+}
+
+func (x *Event) GetRaid() *RaidEvent {
+	if x != nil {
+		if x, ok := x.Event.(*Event_Raid); ok {
+			return x.Raid
+		}
+	}
+	return nil
+}
+
+func (x *Event) GetPokestop() *PokestopEvent {
+	if x != nil {
+		if x, ok := x.Event.(*Event_Pokestop); ok {
+			return x.Pokestop
+		}
+	}
+	return nil
+}
+
+func (x *Event) GetQuest() *QuestEvent {
+	if x != nil {
+		if x, ok := x.Event.(*Event_Quest); ok {
+			return x.Quest
+		}
+	}
+	return nil
+}
+
+func (x *Event) GetInvasion() *InvasionEvent {
+	if x != nil {
+		if x, ok := x.Event.(*Event_Invasion); ok {
+			return x.Invasion
+		}
+	}
+	return nil
+}
+
+func (x *Event) GetMaxBattle() *MaxBattleEvent {
+	if x != nil {
+		if x, ok := x.Event.(*Event_MaxBattle); ok {
+			return x.MaxBattle
+		}
+	}
+	return nil
+}
+
+func (x *Event) GetStation() *StationEvent {
+	if x != nil {
+		if x, ok := x.Event.(*Event_Station); ok {
+			return x.Station
+		}
+	}
+	return nil
+}
+
+func (x *Event) GetWeather() *WeatherEvent {
+	if x != nil {
+		if x, ok := x.Event.(*Event_Weather); ok {
+			return x.Weather
+		}
+	}
+	return nil
+}
+
+func (x *Event) GetDropped() *EventsDropped {
+	if x != nil {
+		if x, ok := x.Event.(*Event_Dropped); ok {
+			return x.Dropped
+		}
+	}
+	return nil
+}
+
+type isEvent_Event interface {
+	isEvent_Event()
+}
+
+type Event_Pokemon struct {
+	Pokemon *PokemonEvent `protobuf:"bytes,2,opt,name=pokemon,proto3,oneof"`
+}
+
+type Event_Gym struct {
+	Gym *GymEvent `protobuf:"bytes,3,opt,name=gym,proto3,oneof"`
+}
+
+type Event_Raid struct {
+	Raid *RaidEvent `protobuf:"bytes,4,opt,name=raid,proto3,oneof"`
+}
+
+type Event_Pokestop struct {
+	Pokestop *PokestopEvent `protobuf:"bytes,5,opt,name=pokestop,proto3,oneof"`
+}
+
+type Event_Quest struct {
+	Quest *QuestEvent `protobuf:"bytes,6,opt,name=quest,proto3,oneof"`
+}
+
+type Event_Invasion struct {
+	Invasion *InvasionEvent `protobuf:"bytes,7,opt,name=invasion,proto3,oneof"`
+}
+
+type Event_MaxBattle struct {
+	MaxBattle *MaxBattleEvent `protobuf:"bytes,8,opt,name=max_battle,json=maxBattle,proto3,oneof"`
+}
+
+type Event_Station struct {
+	Station *StationEvent `protobuf:"bytes,9,opt,name=station,proto3,oneof"`
+}
+
+type Event_Weather struct {
+	Weather *WeatherEvent `protobuf:"bytes,10,opt,name=weather,proto3,oneof"`
+}
+
+type Event_Dropped struct {
+	Dropped *EventsDropped `protobuf:"bytes,11,opt,name=dropped,proto3,oneof"`
+}
+
+func (*Event_Pokemon) isEvent_Event() {}
+
+func (*Event_Gym) isEvent_Event() {}
+
+func (*Event_Raid) isEvent_Event() {}
+
+func (*Event_Pokestop) isEvent_Event() {}
+
+func (*Event_Quest) isEvent_Event() {}
+
+func (*Event_Invasion) isEvent_Event() {}
+
+func (*Event_MaxBattle) isEvent_Event() {}
+
+func (*Event_Station) isEvent_Event() {}
+
+func (*Event_Weather) isEvent_Event() {}
+
+func (*Event_Dropped) isEvent_Event() {}
+
+// EventsDropped reports events lost because the subscriber did not keep up
+type EventsDropped struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Count         uint64                 `protobuf:"varint,1,opt,name=count,proto3" json:"count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EventsDropped) Reset() {
+	*x = EventsDropped{}
+	mi := &file_grpc_pokemon_api_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EventsDropped) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EventsDropped) ProtoMessage() {}
+
+func (x *EventsDropped) ProtoReflect() protoreflect.Message {
+	mi := &file_grpc_pokemon_api_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EventsDropped.ProtoReflect.Descriptor instead.
+func (*EventsDropped) Descriptor() ([]byte, []int) {
+	return file_grpc_pokemon_api_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *EventsDropped) GetCount() uint64 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+type PokemonEvent struct {
+	state                 protoimpl.MessageState `protogen:"open.v1"`
+	EncounterId           string                 `protobuf:"bytes,1,opt,name=encounter_id,json=encounterId,proto3" json:"encounter_id,omitempty"`
+	SpawnpointId          string                 `protobuf:"bytes,2,opt,name=spawnpoint_id,json=spawnpointId,proto3" json:"spawnpoint_id,omitempty"`
+	PokestopId            string                 `protobuf:"bytes,3,opt,name=pokestop_id,json=pokestopId,proto3" json:"pokestop_id,omitempty"`
+	PokestopName          *string                `protobuf:"bytes,4,opt,name=pokestop_name,json=pokestopName,proto3,oneof" json:"pokestop_name,omitempty"`
+	PokemonId             int32                  `protobuf:"varint,5,opt,name=pokemon_id,json=pokemonId,proto3" json:"pokemon_id,omitempty"`
+	Latitude              float64                `protobuf:"fixed64,6,opt,name=latitude,proto3" json:"latitude,omitempty"`
+	Longitude             float64                `protobuf:"fixed64,7,opt,name=longitude,proto3" json:"longitude,omitempty"`
+	DisappearTime         int64                  `protobuf:"varint,8,opt,name=disappear_time,json=disappearTime,proto3" json:"disappear_time,omitempty"`
+	DisappearTimeVerified bool                   `protobuf:"varint,9,opt,name=disappear_time_verified,json=disappearTimeVerified,proto3" json:"disappear_time_verified,omitempty"`
+	FirstSeen             int64                  `protobuf:"varint,10,opt,name=first_seen,json=firstSeen,proto3" json:"first_seen,omitempty"`
+	LastModifiedTime      *int64                 `protobuf:"varint,11,opt,name=last_modified_time,json=lastModifiedTime,proto3,oneof" json:"last_modified_time,omitempty"`
+	Gender                *int32                 `protobuf:"varint,12,opt,name=gender,proto3,oneof" json:"gender,omitempty"`
+	Cp                    *int32                 `protobuf:"varint,13,opt,name=cp,proto3,oneof" json:"cp,omitempty"`
+	Form                  *int32                 `protobuf:"varint,14,opt,name=form,proto3,oneof" json:"form,omitempty"`
+	Costume               *int32                 `protobuf:"varint,15,opt,name=costume,proto3,oneof" json:"costume,omitempty"`
+	IndividualAttack      *int32                 `protobuf:"varint,16,opt,name=individual_attack,json=individualAttack,proto3,oneof" json:"individual_attack,omitempty"`
+	IndividualDefense     *int32                 `protobuf:"varint,17,opt,name=individual_defense,json=individualDefense,proto3,oneof" json:"individual_defense,omitempty"`
+	IndividualStamina     *int32                 `protobuf:"varint,18,opt,name=individual_stamina,json=individualStamina,proto3,oneof" json:"individual_stamina,omitempty"`
+	PokemonLevel          *int32                 `protobuf:"varint,19,opt,name=pokemon_level,json=pokemonLevel,proto3,oneof" json:"pokemon_level,omitempty"`
+	Move_1                *int32                 `protobuf:"varint,20,opt,name=move_1,json=move1,proto3,oneof" json:"move_1,omitempty"`
+	Move_2                *int32                 `protobuf:"varint,21,opt,name=move_2,json=move2,proto3,oneof" json:"move_2,omitempty"`
+	Weight                *float64               `protobuf:"fixed64,22,opt,name=weight,proto3,oneof" json:"weight,omitempty"`
+	Size                  *int32                 `protobuf:"varint,23,opt,name=size,proto3,oneof" json:"size,omitempty"`
+	Height                *float64               `protobuf:"fixed64,24,opt,name=height,proto3,oneof" json:"height,omitempty"`
+	Weather               *int32                 `protobuf:"varint,25,opt,name=weather,proto3,oneof" json:"weather,omitempty"`
+	Capture_1             float64                `protobuf:"fixed64,26,opt,name=capture_1,json=capture1,proto3" json:"capture_1,omitempty"`
+	Capture_2             float64                `protobuf:"fixed64,27,opt,name=capture_2,json=capture2,proto3" json:"capture_2,omitempty"`
+	Capture_3             float64                `protobuf:"fixed64,28,opt,name=capture_3,json=capture3,proto3" json:"capture_3,omitempty"`
+	Shiny                 *bool                  `protobuf:"varint,29,opt,name=shiny,proto3,oneof" json:"shiny,omitempty"`
+	Username              *string                `protobuf:"bytes,30,opt,name=username,proto3,oneof" json:"username,omitempty"`
+	DisplayPokemonId      *int32                 `protobuf:"varint,31,opt,name=display_pokemon_id,json=displayPokemonId,proto3,oneof" json:"display_pokemon_id,omitempty"`
+	DisplayPokemonForm    *int32                 `protobuf:"varint,32,opt,name=display_pokemon_form,json=displayPokemonForm,proto3,oneof" json:"display_pokemon_form,omitempty"`
+	IsEvent               bool                   `protobuf:"varint,33,opt,name=is_event,json=isEvent,proto3" json:"is_event,omitempty"`
+	SeenType              *string                `protobuf:"bytes,34,opt,name=seen_type,json=seenType,proto3,oneof" json:"seen_type,omitempty"`
+	// pvp rankings as in the webhook, json encoded
+	PvpJson       *string `protobuf:"bytes,35,opt,name=pvp_json,json=pvpJson,proto3,oneof" json:"pvp_json,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PokemonEvent) Reset() {
+	*x = PokemonEvent{}
+	mi := &file_grpc_pokemon_api_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PokemonEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PokemonEvent) ProtoMessage() {}
+
+func (x *PokemonEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_grpc_pokemon_api_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PokemonEvent.ProtoReflect.Descriptor instead.
+func (*PokemonEvent) Descriptor() ([]byte, []int) {
+	return file_grpc_pokemon_api_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *PokemonEvent) GetEncounterId() string {
+	if x != nil {
+		return x.EncounterId
+	}
+	return ""
+}
+
+func (x *PokemonEvent) GetSpawnpointId() string {
+	if x != nil {
+		return x.SpawnpointId
+	}
+	return ""
+}
+
+func (x *PokemonEvent) GetPokestopId() string {
+	if x != nil {
+		return x.PokestopId
+	}
+	return ""
+}
+
+func (x *PokemonEvent) GetPokestopName() string {
+	if x != nil && x.PokestopName != nil {
+		return *x.PokestopName
+	}
+	return ""
+}
+
+func (x *PokemonEvent) GetPokemonId() int32 {
+	if x != nil {
+		return x.PokemonId
+	}
+	return 0
+}
+
+func (x *PokemonEvent) GetLatitude() float64 {
+	if x != nil {
+		return x.Latitude
+	}
+	return 0
+}
+
+func (x *PokemonEvent) GetLongitude() float64 {
+	if x != nil {
+		return x.Longitude
+	}
+	return 0
+}
+
+func (x *PokemonEvent) GetDisappearTime() int64 {
+	if x != nil {
+		return x.DisappearTime
+	}
+	return 0
+}
+
+func (x *PokemonEvent) GetDisappearTimeVerified() bool {
+	if x != nil {
+		return x.DisappearTimeVerified
+	}
+	return false
+}
+
+func (x *PokemonEvent) GetFirstSeen() int64 {
+	if x != nil {
+		return x.FirstSeen
+	}
+	return 0
+}
+
+func (x *PokemonEvent) GetLastModifiedTime() int64 {
+	if x != nil && x.LastModifiedTime != nil {
+		return *x.LastModifiedTime
+	}
+	return 0
+}
+
+func (x *PokemonEvent) GetGender() int32 {
+	if x != nil && x.Gender != nil {
+		return *x.Gender
+	}
+	return 0
+}
+
+func (x *PokemonEvent) GetCp() int32 {
+	if x != nil && x.Cp != nil {
+		return *x.Cp
+	}
+	return 0
+}
+
+func (x *PokemonEvent) GetForm() int32 {
+	if x != nil && x.Form != nil {
+		return *x.Form
+	}
+	return 0
+}
+
+func (x *PokemonEvent) GetCostume() int32 {
+	if x != nil && x.Costume != nil {
+		return *x.Costume
+	}
+	return 0
+}
+
+func (x *PokemonEvent) GetIndividualAttack() int32 {
+	if x != nil && x.IndividualAttack != nil {
+		return *x.IndividualAttack
+	}
+	return 0
+}
+
+func (x *PokemonEvent) GetIndividualDefense() int32 {
+	if x != nil && x.IndividualDefense != nil {
+		return *x.IndividualDefense
+	}
+	return 0
+}
+
+func (x *PokemonEvent) GetIndividualStamina() int32 {
+	if x != nil && x.IndividualStamina != nil {
+		return *x.IndividualStamina
+	}
+	return 0
+}
+
+func (x *PokemonEvent) GetPokemonLevel() int32 {
+	if x != nil && x.PokemonLevel != nil {
+		return *x.PokemonLevel
+	}
+	return 0
+}
+
+func (x *PokemonEvent) GetMove_1() int32 {
+	if x != nil && x.Move_1 != nil {
+		return *x.Move_1
+	}
+	return 0
+}
+
+func (x *PokemonEvent) GetMove_2() int32 {
+	if x != nil && x.Move_2 != nil {
+		return *x.Move_2
+	}
+	return 0
+}
+
+func (x *PokemonEvent) GetWeight() float64 {
+	if x != nil && x.Weight != nil {
+		return *x.Weight
+	}
+	return 0
+}
+
+func (x *PokemonEvent) GetSize() int32 {
+	if x != nil && x.Size != nil {
+		return *x.Size
+	}
+	return 0
+}
+
+func (x *PokemonEvent) GetHeight() float64 {
+	if x != nil && x.Height != nil {
+		return *x.Height
+	}
+	return 0
+}
+
+func (x *PokemonEvent) GetWeather() int32 {
+	if x != nil && x.Weather != nil {
+		return *x.Weather
+	}
+	return 0
+}
+
+func (x *PokemonEvent) GetCapture_1() float64 {
+	if x != nil {
+		return x.Capture_1
+	}
+	return 0
+}
+
+func (x *PokemonEvent) GetCapture_2() float64 {
+	if x != nil {
+		return x.Capture_2
+	}
+	return 0
+}
+
+func (x *PokemonEvent) GetCapture_3() float64 {
+	if x != nil {
+		return x.Capture_3
+	}
+	return 0
+}
+
+func (x *PokemonEvent) GetShiny() bool {
+	if x != nil && x.Shiny != nil {
+		return *x.Shiny
+	}
+	return false
+}
+
+func (x *PokemonEvent) GetUsername() string {
+	if x != nil && x.Username != nil {
+		return *x.Username
+	}
+	return ""
+}
+
+func (x *PokemonEvent) GetDisplayPokemonId() int32 {
+	if x != nil && x.DisplayPokemonId != nil {
+		return *x.DisplayPokemonId
+	}
+	return 0
+}
+
+func (x *PokemonEvent) GetDisplayPokemonForm() int32 {
+	if x != nil && x.DisplayPokemonForm != nil {
+		return *x.DisplayPokemonForm
+	}
+	return 0
+}
+
+func (x *PokemonEvent) GetIsEvent() bool {
+	if x != nil {
+		return x.IsEvent
+	}
+	return false
+}
+
+func (x *PokemonEvent) GetSeenType() string {
+	if x != nil && x.SeenType != nil {
+		return *x.SeenType
+	}
+	return ""
+}
+
+func (x *PokemonEvent) GetPvpJson() string {
+	if x != nil && x.PvpJson != nil {
+		return *x.PvpJson
+	}
+	return ""
+}
+
+type GymEvent struct {
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	Id                  string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name                string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Url                 string                 `protobuf:"bytes,3,opt,name=url,proto3" json:"url,omitempty"`
+	Latitude            float64                `protobuf:"fixed64,4,opt,name=latitude,proto3" json:"latitude,omitempty"`
+	Longitude           float64                `protobuf:"fixed64,5,opt,name=longitude,proto3" json:"longitude,omitempty"`
+	Team                int32                  `protobuf:"varint,6,opt,name=team,proto3" json:"team,omitempty"`
+	GuardPokemonId      int32                  `protobuf:"varint,7,opt,name=guard_pokemon_id,json=guardPokemonId,proto3" json:"guard_pokemon_id,omitempty"`
+	SlotsAvailable      int32                  `protobuf:"varint,8,opt,name=slots_available,json=slotsAvailable,proto3" json:"slots_available,omitempty"`
+	ExRaidEligible      bool                   `protobuf:"varint,9,opt,name=ex_raid_eligible,json=exRaidEligible,proto3" json:"ex_raid_eligible,omitempty"`
+	InBattle            bool                   `protobuf:"varint,10,opt,name=in_battle,json=inBattle,proto3" json:"in_battle,omitempty"`
+	SponsorId           int32                  `protobuf:"varint,11,opt,name=sponsor_id,json=sponsorId,proto3" json:"sponsor_id,omitempty"`
+	PartnerId           int32                  `protobuf:"varint,12,opt,name=partner_id,json=partnerId,proto3" json:"partner_id,omitempty"`
+	PowerUpPoints       int32                  `protobuf:"varint,13,opt,name=power_up_points,json=powerUpPoints,proto3" json:"power_up_points,omitempty"`
+	PowerUpLevel        int32                  `protobuf:"varint,14,opt,name=power_up_level,json=powerUpLevel,proto3" json:"power_up_level,omitempty"`
+	PowerUpEndTimestamp int64                  `protobuf:"varint,15,opt,name=power_up_end_timestamp,json=powerUpEndTimestamp,proto3" json:"power_up_end_timestamp,omitempty"`
+	ArScanEligible      bool                   `protobuf:"varint,16,opt,name=ar_scan_eligible,json=arScanEligible,proto3" json:"ar_scan_eligible,omitempty"`
+	// defenders as in the webhook, json encoded
+	DefendersJson *string `protobuf:"bytes,17,opt,name=defenders_json,json=defendersJson,proto3,oneof" json:"defenders_json,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GymEvent) Reset() {
+	*x = GymEvent{}
+	mi := &file_grpc_pokemon_api_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GymEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GymEvent) ProtoMessage() {}
+
+func (x *GymEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_grpc_pokemon_api_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GymEvent.ProtoReflect.Descriptor instead.
+func (*GymEvent) Descriptor() ([]byte, []int) {
+	return file_grpc_pokemon_api_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *GymEvent) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *GymEvent) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *GymEvent) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *GymEvent) GetLatitude() float64 {
+	if x != nil {
+		return x.Latitude
+	}
+	return 0
+}
+
+func (x *GymEvent) GetLongitude() float64 {
+	if x != nil {
+		return x.Longitude
+	}
+	return 0
+}
+
+func (x *GymEvent) GetTeam() int32 {
+	if x != nil {
+		return x.Team
+	}
+	return 0
+}
+
+func (x *GymEvent) GetGuardPokemonId() int32 {
+	if x != nil {
+		return x.GuardPokemonId
+	}
+	return 0
+}
+
+func (x *GymEvent) GetSlotsAvailable() int32 {
+	if x != nil {
+		return x.SlotsAvailable
+	}
+	return 0
+}
+
+func (x *GymEvent) GetExRaidEligible() bool {
+	if x != nil {
+		return x.ExRaidEligible
+	}
+	return false
+}
+
+func (x *GymEvent) GetInBattle() bool {
+	if x != nil {
+		return x.InBattle
+	}
+	return false
+}
+
+func (x *GymEvent) GetSponsorId() int32 {
+	if x != nil {
+		return x.SponsorId
+	}
+	return 0
+}
+
+func (x *GymEvent) GetPartnerId() int32 {
+	if x != nil {
+		return x.PartnerId
+	}
+	return 0
+}
+
+func (x *GymEvent) GetPowerUpPoints() int32 {
+	if x != nil {
+		return x.PowerUpPoints
+	}
+	return 0
+}
+
+func (x *GymEvent) GetPowerUpLevel() int32 {
+	if x != nil {
+		return x.PowerUpLevel
+	}
+	return 0
+}
+
+func (x *GymEvent) GetPowerUpEndTimestamp() int64 {
+	if x != nil {
+		return x.PowerUpEndTimestamp
+	}
+	return 0
+}
+
+func (x *GymEvent) GetArScanEligible() bool {
+	if x != nil {
+		return x.ArScanEligible
+	}
+	return false
+}
+
+func (x *GymEvent) GetDefendersJson() string {
+	if x != nil && x.DefendersJson != nil {
+		return *x.DefendersJson
+	}
+	return ""
+}
+
+type RaidEvent struct {
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	GymId               string                 `protobuf:"bytes,1,opt,name=gym_id,json=gymId,proto3" json:"gym_id,omitempty"`
+	GymName             string                 `protobuf:"bytes,2,opt,name=gym_name,json=gymName,proto3" json:"gym_name,omitempty"`
+	GymUrl              string                 `protobuf:"bytes,3,opt,name=gym_url,json=gymUrl,proto3" json:"gym_url,omitempty"`
+	Latitude            float64                `protobuf:"fixed64,4,opt,name=latitude,proto3" json:"latitude,omitempty"`
+	Longitude           float64                `protobuf:"fixed64,5,opt,name=longitude,proto3" json:"longitude,omitempty"`
+	TeamId              int32                  `protobuf:"varint,6,opt,name=team_id,json=teamId,proto3" json:"team_id,omitempty"`
+	Spawn               int64                  `protobuf:"varint,7,opt,name=spawn,proto3" json:"spawn,omitempty"`
+	Start               int64                  `protobuf:"varint,8,opt,name=start,proto3" json:"start,omitempty"`
+	End                 int64                  `protobuf:"varint,9,opt,name=end,proto3" json:"end,omitempty"`
+	Level               int32                  `protobuf:"varint,10,opt,name=level,proto3" json:"level,omitempty"`
+	PokemonId           int32                  `protobuf:"varint,11,opt,name=pokemon_id,json=pokemonId,proto3" json:"pokemon_id,omitempty"`
+	Cp                  int32                  `protobuf:"varint,12,opt,name=cp,proto3" json:"cp,omitempty"`
+	Gender              int32                  `protobuf:"varint,13,opt,name=gender,proto3" json:"gender,omitempty"`
+	Form                int32                  `protobuf:"varint,14,opt,name=form,proto3" json:"form,omitempty"`
+	Alignment           int32                  `protobuf:"varint,15,opt,name=alignment,proto3" json:"alignment,omitempty"`
+	Costume             int32                  `protobuf:"varint,16,opt,name=costume,proto3" json:"costume,omitempty"`
+	Evolution           int32                  `protobuf:"varint,17,opt,name=evolution,proto3" json:"evolution,omitempty"`
+	Move_1              int32                  `protobuf:"varint,18,opt,name=move_1,json=move1,proto3" json:"move_1,omitempty"`
+	Move_2              int32                  `protobuf:"varint,19,opt,name=move_2,json=move2,proto3" json:"move_2,omitempty"`
+	ExRaidEligible      bool                   `protobuf:"varint,20,opt,name=ex_raid_eligible,json=exRaidEligible,proto3" json:"ex_raid_eligible,omitempty"`
+	IsExclusive         bool                   `protobuf:"varint,21,opt,name=is_exclusive,json=isExclusive,proto3" json:"is_exclusive,omitempty"`
+	SponsorId           int32                  `protobuf:"varint,22,opt,name=sponsor_id,json=sponsorId,proto3" json:"sponsor_id,omitempty"`
+	PartnerId           string                 `protobuf:"bytes,23,opt,name=partner_id,json=partnerId,proto3" json:"partner_id,omitempty"`
+	PowerUpPoints       int32                  `protobuf:"varint,24,opt,name=power_up_points,json=powerUpPoints,proto3" json:"power_up_points,omitempty"`
+	PowerUpLevel        int32                  `protobuf:"varint,25,opt,name=power_up_level,json=powerUpLevel,proto3" json:"power_up_level,omitempty"`
+	PowerUpEndTimestamp int64                  `protobuf:"varint,26,opt,name=power_up_end_timestamp,json=powerUpEndTimestamp,proto3" json:"power_up_end_timestamp,omitempty"`
+	ArScanEligible      bool                   `protobuf:"varint,27,opt,name=ar_scan_eligible,json=arScanEligible,proto3" json:"ar_scan_eligible,omitempty"`
+	// rsvps as in the webhook, json encoded
+	RsvpsJson     *string `protobuf:"bytes,28,opt,name=rsvps_json,json=rsvpsJson,proto3,oneof" json:"rsvps_json,omitempty"`
+	RaidSeed      *string `protobuf:"bytes,29,opt,name=raid_seed,json=raidSeed,proto3,oneof" json:"raid_seed,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RaidEvent) Reset() {
+	*x = RaidEvent{}
+	mi := &file_grpc_pokemon_api_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RaidEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RaidEvent) ProtoMessage() {}
+
+func (x *RaidEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_grpc_pokemon_api_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RaidEvent.ProtoReflect.Descriptor instead.
+func (*RaidEvent) Descriptor() ([]byte, []int) {
+	return file_grpc_pokemon_api_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *RaidEvent) GetGymId() string {
+	if x != nil {
+		return x.GymId
+	}
+	return ""
+}
+
+func (x *RaidEvent) GetGymName() string {
+	if x != nil {
+		return x.GymName
+	}
+	return ""
+}
+
+func (x *RaidEvent) GetGymUrl() string {
+	if x != nil {
+		return x.GymUrl
+	}
+	return ""
+}
+
+func (x *RaidEvent) GetLatitude() float64 {
+	if x != nil {
+		return x.Latitude
+	}
+	return 0
+}
+
+func (x *RaidEvent) GetLongitude() float64 {
+	if x != nil {
+		return x.Longitude
+	}
+	return 0
+}
+
+func (x *RaidEvent) GetTeamId() int32 {
+	if x != nil {
+		return x.TeamId
+	}
+	return 0
+}
+
+func (x *RaidEvent) GetSpawn() int64 {
+	if x != nil {
+		return x.Spawn
+	}
+	return 0
+}
+
+func (x *RaidEvent) GetStart() int64 {
+	if x != nil {
+		return x.Start
+	}
+	return 0
+}
+
+func (x *RaidEvent) GetEnd() int64 {
+	if x != nil {
+		return x.End
+	}
+	return 0
+}
+
+func (x *RaidEvent) GetLevel() int32 {
+	if x != nil {
+		return x.Level
+	}
+	return 0
+}
+
+func (x *RaidEvent) GetPokemonId() int32 {
+	if x != nil {
+		return x.PokemonId
+	}
+	return 0
+}
+
+func (x *RaidEvent) GetCp() int32 {
+	if x != nil {
+		return x.Cp
+	}
+	return 0
+}
+
+func (x *RaidEvent) GetGender() int32 {
+	if x != nil {
+		return x.Gender
+	}
+	return 0
+}
+
+func (x *RaidEvent) GetForm() int32 {
+	if x != nil {
+		return x.Form
+	}
+	return 0
+}
+
+func (x *RaidEvent) GetAlignment() int32 {
+	if x != nil {
+		return x.Alignment
+	}
+	return 0
+}
+
+func (x *RaidEvent) GetCostume() int32 {
+	if x != nil {
+		return x.Costume
+	}
+	return 0
+}
+
+func (x *RaidEvent) GetEvolution() int32 {
+	if x != nil {
+		return x.Evolution
+	}
+	return 0
+}
+
+func (x *RaidEvent) GetMove_1() int32 {
+	if x != nil {
+		return x.Move_1
+	}
+	return 0
+}
+
+func (x *RaidEvent) GetMove_2() int32 {
+	if x != nil {
+		return x.Move_2
+	}
+	return 0
+}
+
+func (x *RaidEvent) GetExRaidEligible() bool {
+	if x != nil {
+		return x.ExRaidEligible
+	}
+	return false
+}
+
+func (x *RaidEvent) GetIsExclusive() bool {
+	if x != nil {
+		return x.IsExclusive
+	}
+	return false
+}
+
+func (x *RaidEvent) GetSponsorId() int32 {
+	if x != nil {
+		return x.SponsorId
+	}
+	return 0
+}
+
+func (x *RaidEvent) GetPartnerId() string {
+	if x != nil {
+		return x.PartnerId
+	}
+	return ""
+}
+
+func (x *RaidEvent) GetPowerUpPoints() int32 {
+	if x != nil {
+		return x.PowerUpPoints
+	}
+	return 0
+}
+
+func (x *RaidEvent) GetPowerUpLevel() int32 {
+	if x != nil {
+		return x.PowerUpLevel
+	}
+	return 0
+}
+
+func (x *RaidEvent) GetPowerUpEndTimestamp() int64 {
+	if x != nil {
+		return x.PowerUpEndTimestamp
+	}
+	return 0
+}
+
+func (x *RaidEvent) GetArScanEligible() bool {
+	if x != nil {
+		return x.ArScanEligible
+	}
+	return false
+}
+
+func (x *RaidEvent) GetRsvpsJson() string {
+	if x != nil && x.RsvpsJson != nil {
+		return *x.RsvpsJson
+	}
+	return ""
+}
+
+func (x *RaidEvent) GetRaidSeed() string {
+	if x != nil && x.RaidSeed != nil {
+		return *x.RaidSeed
+	}
+	return ""
+}
+
+type PokestopEvent struct {
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	PokestopId          string                 `protobuf:"bytes,1,opt,name=pokestop_id,json=pokestopId,proto3" json:"pokestop_id,omitempty"`
+	Latitude            float64                `protobuf:"fixed64,2,opt,name=latitude,proto3" json:"latitude,omitempty"`
+	Longitude           float64                `protobuf:"fixed64,3,opt,name=longitude,proto3" json:"longitude,omitempty"`
+	Name                string                 `protobuf:"bytes,4,opt,name=name,proto3" json:"name,omitempty"`
+	Url                 string                 `protobuf:"bytes,5,opt,name=url,proto3" json:"url,omitempty"`
+	LureExpiration      int64                  `protobuf:"varint,6,opt,name=lure_expiration,json=lureExpiration,proto3" json:"lure_expiration,omitempty"`
+	LastModified        int64                  `protobuf:"varint,7,opt,name=last_modified,json=lastModified,proto3" json:"last_modified,omitempty"`
+	Enabled             bool                   `protobuf:"varint,8,opt,name=enabled,proto3" json:"enabled,omitempty"`
+	LureId              int32                  `protobuf:"varint,9,opt,name=lure_id,json=lureId,proto3" json:"lure_id,omitempty"`
+	ArScanEligible      bool                   `protobuf:"varint,10,opt,name=ar_scan_eligible,json=arScanEligible,proto3" json:"ar_scan_eligible,omitempty"`
+	PowerUpLevel        int32                  `protobuf:"varint,11,opt,name=power_up_level,json=powerUpLevel,proto3" json:"power_up_level,omitempty"`
+	PowerUpPoints       int32                  `protobuf:"varint,12,opt,name=power_up_points,json=powerUpPoints,proto3" json:"power_up_points,omitempty"`
+	PowerUpEndTimestamp int64                  `protobuf:"varint,13,opt,name=power_up_end_timestamp,json=powerUpEndTimestamp,proto3" json:"power_up_end_timestamp,omitempty"`
+	Updated             int64                  `protobuf:"varint,14,opt,name=updated,proto3" json:"updated,omitempty"`
+	// showcase focus as in the webhook, json encoded
+	ShowcaseFocusJson       *string `protobuf:"bytes,15,opt,name=showcase_focus_json,json=showcaseFocusJson,proto3,oneof" json:"showcase_focus_json,omitempty"`
+	ShowcasePokemonId       *int32  `protobuf:"varint,16,opt,name=showcase_pokemon_id,json=showcasePokemonId,proto3,oneof" json:"showcase_pokemon_id,omitempty"`
+	ShowcasePokemonFormId   *int32  `protobuf:"varint,17,opt,name=showcase_pokemon_form_id,json=showcasePokemonFormId,proto3,oneof" json:"showcase_pokemon_form_id,omitempty"`
+	ShowcasePokemonTypeId   *int32  `protobuf:"varint,18,opt,name=showcase_pokemon_type_id,json=showcasePokemonTypeId,proto3,oneof" json:"showcase_pokemon_type_id,omitempty"`
+	ShowcaseRankingStandard *int32  `protobuf:"varint,19,opt,name=showcase_ranking_standard,json=showcaseRankingStandard,proto3,oneof" json:"showcase_ranking_standard,omitempty"`
+	ShowcaseExpiry          *int64  `protobuf:"varint,20,opt,name=showcase_expiry,json=showcaseExpiry,proto3,oneof" json:"showcase_expiry,omitempty"`
+	// showcase rankings as in the webhook, json encoded
+	ShowcaseRankingsJson *string `protobuf:"bytes,21,opt,name=showcase_rankings_json,json=showcaseRankingsJson,proto3,oneof" json:"showcase_rankings_json,omitempty"`
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
+}
+
+func (x *PokestopEvent) Reset() {
+	*x = PokestopEvent{}
+	mi := &file_grpc_pokemon_api_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PokestopEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PokestopEvent) ProtoMessage() {}
+
+func (x *PokestopEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_grpc_pokemon_api_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PokestopEvent.ProtoReflect.Descriptor instead.
+func (*PokestopEvent) Descriptor() ([]byte, []int) {
+	return file_grpc_pokemon_api_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *PokestopEvent) GetPokestopId() string {
+	if x != nil {
+		return x.PokestopId
+	}
+	return ""
+}
+
+func (x *PokestopEvent) GetLatitude() float64 {
+	if x != nil {
+		return x.Latitude
+	}
+	return 0
+}
+
+func (x *PokestopEvent) GetLongitude() float64 {
+	if x != nil {
+		return x.Longitude
+	}
+	return 0
+}
+
+func (x *PokestopEvent) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *PokestopEvent) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *PokestopEvent) GetLureExpiration() int64 {
+	if x != nil {
+		return x.LureExpiration
+	}
+	return 0
+}
+
+func (x *PokestopEvent) GetLastModified() int64 {
+	if x != nil {
+		return x.LastModified
+	}
+	return 0
+}
+
+func (x *PokestopEvent) GetEnabled() bool {
+	if x != nil {
+		return x.Enabled
+	}
+	return false
+}
+
+func (x *PokestopEvent) GetLureId() int32 {
+	if x != nil {
+		return x.LureId
+	}
+	return 0
+}
+
+func (x *PokestopEvent) GetArScanEligible() bool {
+	if x != nil {
+		return x.ArScanEligible
+	}
+	return false
+}
+
+func (x *PokestopEvent) GetPowerUpLevel() int32 {
+	if x != nil {
+		return x.PowerUpLevel
+	}
+	return 0
+}
+
+func (x *PokestopEvent) GetPowerUpPoints() int32 {
+	if x != nil {
+		return x.PowerUpPoints
+	}
+	return 0
+}
+
+func (x *PokestopEvent) GetPowerUpEndTimestamp() int64 {
+	if x != nil {
+		return x.PowerUpEndTimestamp
+	}
+	return 0
+}
+
+func (x *PokestopEvent) GetUpdated() int64 {
+	if x != nil {
+		return x.Updated
+	}
+	return 0
+}
+
+func (x *PokestopEvent) GetShowcaseFocusJson() string {
+	if x != nil && x.ShowcaseFocusJson != nil {
+		return *x.ShowcaseFocusJson
+	}
+	return ""
+}
+
+func (x *PokestopEvent) GetShowcasePokemonId() int32 {
+	if x != nil && x.ShowcasePokemonId != nil {
+		return *x.ShowcasePokemonId
+	}
+	return 0
+}
+
+func (x *PokestopEvent) GetShowcasePokemonFormId() int32 {
+	if x != nil && x.ShowcasePokemonFormId != nil {
+		return *x.ShowcasePokemonFormId
+	}
+	return 0
+}
+
+func (x *PokestopEvent) GetShowcasePokemonTypeId() int32 {
+	if x != nil && x.ShowcasePokemonTypeId != nil {
+		return *x.ShowcasePokemonTypeId
+	}
+	return 0
+}
+
+func (x *PokestopEvent) GetShowcaseRankingStandard() int32 {
+	if x != nil && x.ShowcaseRankingStandard != nil {
+		return *x.ShowcaseRankingStandard
+	}
+	return 0
+}
+
+func (x *PokestopEvent) GetShowcaseExpiry() int64 {
+	if x != nil && x.ShowcaseExpiry != nil {
+		return *x.ShowcaseExpiry
+	}
+	return 0
+}
+
+func (x *PokestopEvent) GetShowcaseRankingsJson() string {
+	if x != nil && x.ShowcaseRankingsJson != nil {
+		return *x.ShowcaseRankingsJson
+	}
+	return ""
+}
+
+type QuestEvent struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	PokestopId   string                 `protobuf:"bytes,1,opt,name=pokestop_id,json=pokestopId,proto3" json:"pokestop_id,omitempty"`
+	Latitude     float64                `protobuf:"fixed64,2,opt,name=latitude,proto3" json:"latitude,omitempty"`
+	Longitude    float64                `protobuf:"fixed64,3,opt,name=longitude,proto3" json:"longitude,omitempty"`
+	PokestopName string                 `protobuf:"bytes,4,opt,name=pokestop_name,json=pokestopName,proto3" json:"pokestop_name,omitempty"`
+	PokestopUrl  string                 `protobuf:"bytes,5,opt,name=pokestop_url,json=pokestopUrl,proto3" json:"pokestop_url,omitempty"`
+	Type         *int32                 `protobuf:"varint,6,opt,name=type,proto3,oneof" json:"type,omitempty"`
+	Target       *int32                 `protobuf:"varint,7,opt,name=target,proto3,oneof" json:"target,omitempty"`
+	Template     *string                `protobuf:"bytes,8,opt,name=template,proto3,oneof" json:"template,omitempty"`
+	Title        *string                `protobuf:"bytes,9,opt,name=title,proto3,oneof" json:"title,omitempty"`
+	// conditions and rewards as in the webhook, json encoded
+	ConditionsJson *string `protobuf:"bytes,10,opt,name=conditions_json,json=conditionsJson,proto3,oneof" json:"conditions_json,omitempty"`
+	RewardsJson    *string `protobuf:"bytes,11,opt,name=rewards_json,json=rewardsJson,proto3,oneof" json:"rewards_json,omitempty"`
+	Updated        int64   `protobuf:"varint,12,opt,name=updated,proto3" json:"updated,omitempty"`
+	ArScanEligible bool    `protobuf:"varint,13,opt,name=ar_scan_eligible,json=arScanEligible,proto3" json:"ar_scan_eligible,omitempty"`
+	WithAr         bool    `protobuf:"varint,14,opt,name=with_ar,json=withAr,proto3" json:"with_ar,omitempty"`
+	QuestSeed      *string `protobuf:"bytes,15,opt,name=quest_seed,json=questSeed,proto3,oneof" json:"quest_seed,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *QuestEvent) Reset() {
+	*x = QuestEvent{}
+	mi := &file_grpc_pokemon_api_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QuestEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QuestEvent) ProtoMessage() {}
+
+func (x *QuestEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_grpc_pokemon_api_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QuestEvent.ProtoReflect.Descriptor instead.
+func (*QuestEvent) Descriptor() ([]byte, []int) {
+	return file_grpc_pokemon_api_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *QuestEvent) GetPokestopId() string {
+	if x != nil {
+		return x.PokestopId
+	}
+	return ""
+}
+
+func (x *QuestEvent) GetLatitude() float64 {
+	if x != nil {
+		return x.Latitude
+	}
+	return 0
+}
+
+func (x *QuestEvent) GetLongitude() float64 {
+	if x != nil {
+		return x.Longitude
+	}
+	return 0
+}
+
+func (x *QuestEvent) GetPokestopName() string {
+	if x != nil {
+		return x.PokestopName
+	}
+	return ""
+}
+
+func (x *QuestEvent) GetPokestopUrl() string {
+	if x != nil {
+		return x.PokestopUrl
+	}
+	return ""
+}
+
+func (x *QuestEvent) GetType() int32 {
+	if x != nil && x.Type != nil {
+		return *x.Type
+	}
+	return 0
+}
+
+func (x *QuestEvent) GetTarget() int32 {
+	if x != nil && x.Target != nil {
+		return *x.Target
+	}
+	return 0
+}
+
+func (x *QuestEvent) GetTemplate() string {
+	if x != nil && x.Template != nil {
+		return *x.Template
+	}
+	return ""
+}
+
+func (x *QuestEvent) GetTitle() string {
+	if x != nil && x.Title != nil {
+		return *x.Title
+	}
+	return ""
+}
+
+func (x *QuestEvent) GetConditionsJson() string {
+	if x != nil && x.ConditionsJson != nil {
+		return *x.ConditionsJson
+	}
+	return ""
+}
+
+func (x *QuestEvent) GetRewardsJson() string {
+	if x != nil && x.RewardsJson != nil {
+		return *x.RewardsJson
+	}
+	return ""
+}
+
+func (x *QuestEvent) GetUpdated() int64 {
+	if x != nil {
+		return x.Updated
+	}
+	return 0
+}
+
+func (x *QuestEvent) GetArScanEligible() bool {
+	if x != nil {
+		return x.ArScanEligible
+	}
+	return false
+}
+
+func (x *QuestEvent) GetWithAr() bool {
+	if x != nil {
+		return x.WithAr
+	}
+	return false
+}
+
+func (x *QuestEvent) GetQuestSeed() string {
+	if x != nil && x.QuestSeed != nil {
+		return *x.QuestSeed
+	}
+	return ""
+}
+
+type InvasionEvent struct {
+	state                   protoimpl.MessageState `protogen:"open.v1"`
+	Id                      string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	PokestopId              string                 `protobuf:"bytes,2,opt,name=pokestop_id,json=pokestopId,proto3" json:"pokestop_id,omitempty"`
+	Latitude                float64                `protobuf:"fixed64,3,opt,name=latitude,proto3" json:"latitude,omitempty"`
+	Longitude               float64                `protobuf:"fixed64,4,opt,name=longitude,proto3" json:"longitude,omitempty"`
+	PokestopName            string                 `protobuf:"bytes,5,opt,name=pokestop_name,json=pokestopName,proto3" json:"pokestop_name,omitempty"`
+	Url                     string                 `protobuf:"bytes,6,opt,name=url,proto3" json:"url,omitempty"`
+	Enabled                 bool                   `protobuf:"varint,7,opt,name=enabled,proto3" json:"enabled,omitempty"`
+	Start                   int64                  `protobuf:"varint,8,opt,name=start,proto3" json:"start,omitempty"`
+	IncidentExpireTimestamp int64                  `protobuf:"varint,9,opt,name=incident_expire_timestamp,json=incidentExpireTimestamp,proto3" json:"incident_expire_timestamp,omitempty"`
+	DisplayType             int32                  `protobuf:"varint,10,opt,name=display_type,json=displayType,proto3" json:"display_type,omitempty"`
+	Style                   int32                  `protobuf:"varint,11,opt,name=style,proto3" json:"style,omitempty"`
+	GruntType               int32                  `protobuf:"varint,12,opt,name=grunt_type,json=gruntType,proto3" json:"grunt_type,omitempty"`
+	Character               int32                  `protobuf:"varint,13,opt,name=character,proto3" json:"character,omitempty"`
+	Updated                 int64                  `protobuf:"varint,14,opt,name=updated,proto3" json:"updated,omitempty"`
+	Confirmed               bool                   `protobuf:"varint,15,opt,name=confirmed,proto3" json:"confirmed,omitempty"`
+	Lineup                  []*InvasionLineup      `protobuf:"bytes,16,rep,name=lineup,proto3" json:"lineup,omitempty"`
+	unknownFields           protoimpl.UnknownFields
+	sizeCache               protoimpl.SizeCache
+}
+
+func (x *InvasionEvent) Reset() {
+	*x = InvasionEvent{}
+	mi := &file_grpc_pokemon_api_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InvasionEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InvasionEvent) ProtoMessage() {}
+
+func (x *InvasionEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_grpc_pokemon_api_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InvasionEvent.ProtoReflect.Descriptor instead.
+func (*InvasionEvent) Descriptor() ([]byte, []int) {
+	return file_grpc_pokemon_api_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *InvasionEvent) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *InvasionEvent) GetPokestopId() string {
+	if x != nil {
+		return x.PokestopId
+	}
+	return ""
+}
+
+func (x *InvasionEvent) GetLatitude() float64 {
+	if x != nil {
+		return x.Latitude
+	}
+	return 0
+}
+
+func (x *InvasionEvent) GetLongitude() float64 {
+	if x != nil {
+		return x.Longitude
+	}
+	return 0
+}
+
+func (x *InvasionEvent) GetPokestopName() string {
+	if x != nil {
+		return x.PokestopName
+	}
+	return ""
+}
+
+func (x *InvasionEvent) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *InvasionEvent) GetEnabled() bool {
+	if x != nil {
+		return x.Enabled
+	}
+	return false
+}
+
+func (x *InvasionEvent) GetStart() int64 {
+	if x != nil {
+		return x.Start
+	}
+	return 0
+}
+
+func (x *InvasionEvent) GetIncidentExpireTimestamp() int64 {
+	if x != nil {
+		return x.IncidentExpireTimestamp
+	}
+	return 0
+}
+
+func (x *InvasionEvent) GetDisplayType() int32 {
+	if x != nil {
+		return x.DisplayType
+	}
+	return 0
+}
+
+func (x *InvasionEvent) GetStyle() int32 {
+	if x != nil {
+		return x.Style
+	}
+	return 0
+}
+
+func (x *InvasionEvent) GetGruntType() int32 {
+	if x != nil {
+		return x.GruntType
+	}
+	return 0
+}
+
+func (x *InvasionEvent) GetCharacter() int32 {
+	if x != nil {
+		return x.Character
+	}
+	return 0
+}
+
+func (x *InvasionEvent) GetUpdated() int64 {
+	if x != nil {
+		return x.Updated
+	}
+	return 0
+}
+
+func (x *InvasionEvent) GetConfirmed() bool {
+	if x != nil {
+		return x.Confirmed
+	}
+	return false
+}
+
+func (x *InvasionEvent) GetLineup() []*InvasionLineup {
+	if x != nil {
+		return x.Lineup
+	}
+	return nil
+}
+
+type InvasionLineup struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Slot          int32                  `protobuf:"varint,1,opt,name=slot,proto3" json:"slot,omitempty"`
+	PokemonId     *int32                 `protobuf:"varint,2,opt,name=pokemon_id,json=pokemonId,proto3,oneof" json:"pokemon_id,omitempty"`
+	Form          *int32                 `protobuf:"varint,3,opt,name=form,proto3,oneof" json:"form,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *InvasionLineup) Reset() {
+	*x = InvasionLineup{}
+	mi := &file_grpc_pokemon_api_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InvasionLineup) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InvasionLineup) ProtoMessage() {}
+
+func (x *InvasionLineup) ProtoReflect() protoreflect.Message {
+	mi := &file_grpc_pokemon_api_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InvasionLineup.ProtoReflect.Descriptor instead.
+func (*InvasionLineup) Descriptor() ([]byte, []int) {
+	return file_grpc_pokemon_api_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *InvasionLineup) GetSlot() int32 {
+	if x != nil {
+		return x.Slot
+	}
+	return 0
+}
+
+func (x *InvasionLineup) GetPokemonId() int32 {
+	if x != nil && x.PokemonId != nil {
+		return *x.PokemonId
+	}
+	return 0
+}
+
+func (x *InvasionLineup) GetForm() int32 {
+	if x != nil && x.Form != nil {
+		return *x.Form
+	}
+	return 0
+}
+
+// MaxBattleEvent is a station with its battles, the top battle also in the
+// battle_* fields
+type MaxBattleEvent struct {
+	state                  protoimpl.MessageState `protogen:"open.v1"`
+	Id                     string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Latitude               float64                `protobuf:"fixed64,2,opt,name=latitude,proto3" json:"latitude,omitempty"`
+	Longitude              float64                `protobuf:"fixed64,3,opt,name=longitude,proto3" json:"longitude,omitempty"`
+	Name                   string                 `protobuf:"bytes,4,opt,name=name,proto3" json:"name,omitempty"`
+	StartTime              int64                  `protobuf:"varint,5,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`
+	EndTime                int64                  `protobuf:"varint,6,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`
+	IsBattleAvailable      bool                   `protobuf:"varint,7,opt,name=is_battle_available,json=isBattleAvailable,proto3" json:"is_battle_available,omitempty"`
+	BattleLevel            *int32                 `protobuf:"varint,8,opt,name=battle_level,json=battleLevel,proto3,oneof" json:"battle_level,omitempty"`
+	BattleStart            *int64                 `protobuf:"varint,9,opt,name=battle_start,json=battleStart,proto3,oneof" json:"battle_start,omitempty"`
+	BattleEnd              *int64                 `protobuf:"varint,10,opt,name=battle_end,json=battleEnd,proto3,oneof" json:"battle_end,omitempty"`
+	BattlePokemonId        *int32                 `protobuf:"varint,11,opt,name=battle_pokemon_id,json=battlePokemonId,proto3,oneof" json:"battle_pokemon_id,omitempty"`
+	BattlePokemonForm      *int32                 `protobuf:"varint,12,opt,name=battle_pokemon_form,json=battlePokemonForm,proto3,oneof" json:"battle_pokemon_form,omitempty"`
+	BattlePokemonCostume   *int32                 `protobuf:"varint,13,opt,name=battle_pokemon_costume,json=battlePokemonCostume,proto3,oneof" json:"battle_pokemon_costume,omitempty"`
+	BattlePokemonGender    *int32                 `protobuf:"varint,14,opt,name=battle_pokemon_gender,json=battlePokemonGender,proto3,oneof" json:"battle_pokemon_gender,omitempty"`
+	BattlePokemonAlignment *int32                 `protobuf:"varint,15,opt,name=battle_pokemon_alignment,json=battlePokemonAlignment,proto3,oneof" json:"battle_pokemon_alignment,omitempty"`
+	BattlePokemonBreadMode *int32                 `protobuf:"varint,16,opt,name=battle_pokemon_bread_mode,json=battlePokemonBreadMode,proto3,oneof" json:"battle_pokemon_bread_mode,omitempty"`
+	BattlePokemonMove_1    *int32                 `protobuf:"varint,17,opt,name=battle_pokemon_move_1,json=battlePokemonMove1,proto3,oneof" json:"battle_pokemon_move_1,omitempty"`
+	BattlePokemonMove_2    *int32                 `protobuf:"varint,18,opt,name=battle_pokemon_move_2,json=battlePokemonMove2,proto3,oneof" json:"battle_pokemon_move_2,omitempty"`
+	TotalStationedPokemon  *int32                 `protobuf:"varint,19,opt,name=total_stationed_pokemon,json=totalStationedPokemon,proto3,oneof" json:"total_stationed_pokemon,omitempty"`
+	TotalStationedGmax     *int32                 `protobuf:"varint,20,opt,name=total_stationed_gmax,json=totalStationedGmax,proto3,oneof" json:"total_stationed_gmax,omitempty"`
+	Battles                []*StationBattle       `protobuf:"bytes,21,rep,name=battles,proto3" json:"battles,omitempty"`
+	Updated                int64                  `protobuf:"varint,22,opt,name=updated,proto3" json:"updated,omitempty"`
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *MaxBattleEvent) Reset() {
+	*x = MaxBattleEvent{}
+	mi := &file_grpc_pokemon_api_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MaxBattleEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MaxBattleEvent) ProtoMessage() {}
+
+func (x *MaxBattleEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_grpc_pokemon_api_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MaxBattleEvent.ProtoReflect.Descriptor instead.
+func (*MaxBattleEvent) Descriptor() ([]byte, []int) {
+	return file_grpc_pokemon_api_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *MaxBattleEvent) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *MaxBattleEvent) GetLatitude() float64 {
+	if x != nil {
+		return x.Latitude
+	}
+	return 0
+}
+
+func (x *MaxBattleEvent) GetLongitude() float64 {
+	if x != nil {
+		return x.Longitude
+	}
+	return 0
+}
+
+func (x *MaxBattleEvent) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *MaxBattleEvent) GetStartTime() int64 {
+	if x != nil {
+		return x.StartTime
+	}
+	return 0
+}
+
+func (x *MaxBattleEvent) GetEndTime() int64 {
+	if x != nil {
+		return x.EndTime
+	}
+	return 0
+}
+
+func (x *MaxBattleEvent) GetIsBattleAvailable() bool {
+	if x != nil {
+		return x.IsBattleAvailable
+	}
+	return false
+}
+
+func (x *MaxBattleEvent) GetBattleLevel() int32 {
+	if x != nil && x.BattleLevel != nil {
+		return *x.BattleLevel
+	}
+	return 0
+}
+
+func (x *MaxBattleEvent) GetBattleStart() int64 {
+	if x != nil && x.BattleStart != nil {
+		return *x.BattleStart
+	}
+	return 0
+}
+
+func (x *MaxBattleEvent) GetBattleEnd() int64 {
+	if x != nil && x.BattleEnd != nil {
+		return *x.BattleEnd
+	}
+	return 0
+}
+
+func (x *MaxBattleEvent) GetBattlePokemonId() int32 {
+	if x != nil && x.BattlePokemonId != nil {
+		return *x.BattlePokemonId
+	}
+	return 0
+}
+
+func (x *MaxBattleEvent) GetBattlePokemonForm() int32 {
+	if x != nil && x.BattlePokemonForm != nil {
+		return *x.BattlePokemonForm
+	}
+	return 0
+}
+
+func (x *MaxBattleEvent) GetBattlePokemonCostume() int32 {
+	if x != nil && x.BattlePokemonCostume != nil {
+		return *x.BattlePokemonCostume
+	}
+	return 0
+}
+
+func (x *MaxBattleEvent) GetBattlePokemonGender() int32 {
+	if x != nil && x.BattlePokemonGender != nil {
+		return *x.BattlePokemonGender
+	}
+	return 0
+}
+
+func (x *MaxBattleEvent) GetBattlePokemonAlignment() int32 {
+	if x != nil && x.BattlePokemonAlignment != nil {
+		return *x.BattlePokemonAlignment
+	}
+	return 0
+}
+
+func (x *MaxBattleEvent) GetBattlePokemonBreadMode() int32 {
+	if x != nil && x.BattlePokemonBreadMode != nil {
+		return *x.BattlePokemonBreadMode
+	}
+	return 0
+}
+
+func (x *MaxBattleEvent) GetBattlePokemonMove_1() int32 {
+	if x != nil && x.BattlePokemonMove_1 != nil {
+		return *x.BattlePokemonMove_1
+	}
+	return 0
+}
+
+func (x *MaxBattleEvent) GetBattlePokemonMove_2() int32 {
+	if x != nil && x.BattlePokemonMove_2 != nil {
+		return *x.BattlePokemonMove_2
+	}
+	return 0
+}
+
+func (x *MaxBattleEvent) GetTotalStationedPokemon() int32 {
+	if x != nil && x.TotalStationedPokemon != nil {
+		return *x.TotalStationedPokemon
+	}
+	return 0
+}
+
+func (x *MaxBattleEvent) GetTotalStationedGmax() int32 {
+	if x != nil && x.TotalStationedGmax != nil {
+		return *x.TotalStationedGmax
+	}
+	return 0
+}
+
+func (x *MaxBattleEvent) GetBattles() []*StationBattle {
+	if x != nil {
+		return x.Battles
+	}
+	return nil
+}
+
+func (x *MaxBattleEvent) GetUpdated() int64 {
+	if x != nil {
+		return x.Updated
+	}
+	return 0
+}
+
+type StationBattle struct {
+	state                     protoimpl.MessageState `protogen:"open.v1"`
+	BreadBattleSeed           int64                  `protobuf:"varint,1,opt,name=bread_battle_seed,json=breadBattleSeed,proto3" json:"bread_battle_seed,omitempty"`
+	BattleLevel               int32                  `protobuf:"varint,2,opt,name=battle_level,json=battleLevel,proto3" json:"battle_level,omitempty"`
+	BattleStart               int64                  `protobuf:"varint,3,opt,name=battle_start,json=battleStart,proto3" json:"battle_start,omitempty"`
+	BattleEnd                 int64                  `protobuf:"varint,4,opt,name=battle_end,json=battleEnd,proto3" json:"battle_end,omitempty"`
+	BattlePokemonId           *int32                 `protobuf:"varint,5,opt,name=battle_pokemon_id,json=battlePokemonId,proto3,oneof" json:"battle_pokemon_id,omitempty"`
+	BattlePokemonForm         *int32                 `protobuf:"varint,6,opt,name=battle_pokemon_form,json=battlePokemonForm,proto3,oneof" json:"battle_pokemon_form,omitempty"`
+	BattlePokemonCostume      *int32                 `protobuf:"varint,7,opt,name=battle_pokemon_costume,json=battlePokemonCostume,proto3,oneof" json:"battle_pokemon_costume,omitempty"`
+	BattlePokemonGender       *int32                 `protobuf:"varint,8,opt,name=battle_pokemon_gender,json=battlePokemonGender,proto3,oneof" json:"battle_pokemon_gender,omitempty"`
+	BattlePokemonAlignment    *int32                 `protobuf:"varint,9,opt,name=battle_pokemon_alignment,json=battlePokemonAlignment,proto3,oneof" json:"battle_pokemon_alignment,omitempty"`
+	BattlePokemonBreadMode    *int32                 `protobuf:"varint,10,opt,name=battle_pokemon_bread_mode,json=battlePokemonBreadMode,proto3,oneof" json:"battle_pokemon_bread_mode,omitempty"`
+	BattlePokemonMove_1       *int32                 `protobuf:"varint,11,opt,name=battle_pokemon_move_1,json=battlePokemonMove1,proto3,oneof" json:"battle_pokemon_move_1,omitempty"`
+	BattlePokemonMove_2       *int32                 `protobuf:"varint,12,opt,name=battle_pokemon_move_2,json=battlePokemonMove2,proto3,oneof" json:"battle_pokemon_move_2,omitempty"`
+	BattlePokemonStamina      *int32                 `protobuf:"varint,13,opt,name=battle_pokemon_stamina,json=battlePokemonStamina,proto3,oneof" json:"battle_pokemon_stamina,omitempty"`
+	BattlePokemonCpMultiplier *float64               `protobuf:"fixed64,14,opt,name=battle_pokemon_cp_multiplier,json=battlePokemonCpMultiplier,proto3,oneof" json:"battle_pokemon_cp_multiplier,omitempty"`
+	unknownFields             protoimpl.UnknownFields
+	sizeCache                 protoimpl.SizeCache
+}
+
+func (x *StationBattle) Reset() {
+	*x = StationBattle{}
+	mi := &file_grpc_pokemon_api_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StationBattle) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StationBattle) ProtoMessage() {}
+
+func (x *StationBattle) ProtoReflect() protoreflect.Message {
+	mi := &file_grpc_pokemon_api_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StationBattle.ProtoReflect.Descriptor instead.
+func (*StationBattle) Descriptor() ([]byte, []int) {
+	return file_grpc_pokemon_api_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *StationBattle) GetBreadBattleSeed() int64 {
+	if x != nil {
+		return x.BreadBattleSeed
+	}
+	return 0
+}
+
+func (x *StationBattle) GetBattleLevel() int32 {
+	if x != nil {
+		return x.BattleLevel
+	}
+	return 0
+}
+
+func (x *StationBattle) GetBattleStart() int64 {
+	if x != nil {
+		return x.BattleStart
+	}
+	return 0
+}
+
+func (x *StationBattle) GetBattleEnd() int64 {
+	if x != nil {
+		return x.BattleEnd
+	}
+	return 0
+}
+
+func (x *StationBattle) GetBattlePokemonId() int32 {
+	if x != nil && x.BattlePokemonId != nil {
+		return *x.BattlePokemonId
+	}
+	return 0
+}
+
+func (x *StationBattle) GetBattlePokemonForm() int32 {
+	if x != nil && x.BattlePokemonForm != nil {
+		return *x.BattlePokemonForm
+	}
+	return 0
+}
+
+func (x *StationBattle) GetBattlePokemonCostume() int32 {
+	if x != nil && x.BattlePokemonCostume != nil {
+		return *x.BattlePokemonCostume
+	}
+	return 0
+}
+
+func (x *StationBattle) GetBattlePokemonGender() int32 {
+	if x != nil && x.BattlePokemonGender != nil {
+		return *x.BattlePokemonGender
+	}
+	return 0
+}
+
+func (x *StationBattle) GetBattlePokemonAlignment() int32 {
+	if x != nil && x.BattlePokemonAlignment != nil {
+		return *x.BattlePokemonAlignment
+	}
+	return 0
+}
+
+func (x *StationBattle) GetBattlePokemonBreadMode() int32 {
+	if x != nil && x.BattlePokemonBreadMode != nil {
+		return *x.BattlePokemonBreadMode
+	}
+	return 0
+}
+
+func (x *StationBattle) GetBattlePokemonMove_1() int32 {
+	if x != nil && x.BattlePokemonMove_1 != nil {
+		return *x.BattlePokemonMove_1
+	}
+	return 0
+}
+
+func (x *StationBattle) GetBattlePokemonMove_2() int32 {
+	if x != nil && x.BattlePokemonMove_2 != nil {
+		return *x.BattlePokemonMove_2
+	}
+	return 0
+}
+
+func (x *StationBattle) GetBattlePokemonStamina() int32 {
+	if x != nil && x.BattlePokemonStamina != nil {
+		return *x.BattlePokemonStamina
+	}
+	return 0
+}
+
+func (x *StationBattle) GetBattlePokemonCpMultiplier() float64 {
+	if x != nil && x.BattlePokemonCpMultiplier != nil {
+		return *x.BattlePokemonCpMultiplier
+	}
+	return 0
+}
+
+type StationEvent struct {
+	state                 protoimpl.MessageState `protogen:"open.v1"`
+	Id                    string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Latitude              float64                `protobuf:"fixed64,2,opt,name=latitude,proto3" json:"latitude,omitempty"`
+	Longitude             float64                `protobuf:"fixed64,3,opt,name=longitude,proto3" json:"longitude,omitempty"`
+	Name                  string                 `protobuf:"bytes,4,opt,name=name,proto3" json:"name,omitempty"`
+	StartTime             int64                  `protobuf:"varint,5,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`
+	EndTime               int64                  `protobuf:"varint,6,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`
+	IsBattleAvailable     bool                   `protobuf:"varint,7,opt,name=is_battle_available,json=isBattleAvailable,proto3" json:"is_battle_available,omitempty"`
+	IsInactive            bool                   `protobuf:"varint,8,opt,name=is_inactive,json=isInactive,proto3" json:"is_inactive,omitempty"`
+	TotalStationedPokemon *int32                 `protobuf:"varint,9,opt,name=total_stationed_pokemon,json=totalStationedPokemon,proto3,oneof" json:"total_stationed_pokemon,omitempty"`
+	TotalStationedGmax    *int32                 `protobuf:"varint,10,opt,name=total_stationed_gmax,json=totalStationedGmax,proto3,oneof" json:"total_stationed_gmax,omitempty"`
+	Updated               int64                  `protobuf:"varint,11,opt,name=updated,proto3" json:"updated,omitempty"`
+	unknownFields         protoimpl.UnknownFields
+	sizeCache             protoimpl.SizeCache
+}
+
+func (x *StationEvent) Reset() {
+	*x = StationEvent{}
+	mi := &file_grpc_pokemon_api_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StationEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StationEvent) ProtoMessage() {}
+
+func (x *StationEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_grpc_pokemon_api_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StationEvent.ProtoReflect.Descriptor instead.
+func (*StationEvent) Descriptor() ([]byte, []int) {
+	return file_grpc_pokemon_api_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *StationEvent) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *StationEvent) GetLatitude() float64 {
+	if x != nil {
+		return x.Latitude
+	}
+	return 0
+}
+
+func (x *StationEvent) GetLongitude() float64 {
+	if x != nil {
+		return x.Longitude
+	}
+	return 0
+}
+
+func (x *StationEvent) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *StationEvent) GetStartTime() int64 {
+	if x != nil {
+		return x.StartTime
+	}
+	return 0
+}
+
+func (x *StationEvent) GetEndTime() int64 {
+	if x != nil {
+		return x.EndTime
+	}
+	return 0
+}
+
+func (x *StationEvent) GetIsBattleAvailable() bool {
+	if x != nil {
+		return x.IsBattleAvailable
+	}
+	return false
+}
+
+func (x *StationEvent) GetIsInactive() bool {
+	if x != nil {
+		return x.IsInactive
+	}
+	return false
+}
+
+func (x *StationEvent) GetTotalStationedPokemon() int32 {
+	if x != nil && x.TotalStationedPokemon != nil {
+		return *x.TotalStationedPokemon
+	}
+	return 0
+}
+
+func (x *StationEvent) GetTotalStationedGmax() int32 {
+	if x != nil && x.TotalStationedGmax != nil {
+		return *x.TotalStationedGmax
+	}
+	return 0
+}
+
+func (x *StationEvent) GetUpdated() int64 {
+	if x != nil {
+		return x.Updated
+	}
+	return 0
+}
+
+type WeatherEvent struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	S2CellId  int64                  `protobuf:"varint,1,opt,name=s2_cell_id,json=s2CellId,proto3" json:"s2_cell_id,omitempty"`
+	Latitude  float64                `protobuf:"fixed64,2,opt,name=latitude,proto3" json:"latitude,omitempty"`
+	Longitude float64                `protobuf:"fixed64,3,opt,name=longitude,proto3" json:"longitude,omitempty"`
+	// corners of the cell, as lat, lon pairs
+	Polygon            []float64 `protobuf:"fixed64,4,rep,packed,name=polygon,proto3" json:"polygon,omitempty"`
+	GameplayCondition  int32     `protobuf:"varint,5,opt,name=gameplay_condition,json=gameplayCondition,proto3" json:"gameplay_condition,omitempty"`
+	WindDirection      int32     `protobuf:"varint,6,opt,name=wind_direction,json=windDirection,proto3" json:"wind_direction,omitempty"`
+	CloudLevel         int32     `protobuf:"varint,7,opt,name=cloud_level,json=cloudLevel,proto3" json:"cloud_level,omitempty"`
+	RainLevel          int32     `protobuf:"varint,8,opt,name=rain_level,json=rainLevel,proto3" json:"rain_level,omitempty"`
+	WindLevel          int32     `protobuf:"varint,9,opt,name=wind_level,json=windLevel,proto3" json:"wind_level,omitempty"`
+	SnowLevel          int32     `protobuf:"varint,10,opt,name=snow_level,json=snowLevel,proto3" json:"snow_level,omitempty"`
+	FogLevel           int32     `protobuf:"varint,11,opt,name=fog_level,json=fogLevel,proto3" json:"fog_level,omitempty"`
+	SpecialEffectLevel int32     `protobuf:"varint,12,opt,name=special_effect_level,json=specialEffectLevel,proto3" json:"special_effect_level,omitempty"`
+	Severity           int32     `protobuf:"varint,13,opt,name=severity,proto3" json:"severity,omitempty"`
+	WarnWeather        bool      `protobuf:"varint,14,opt,name=warn_weather,json=warnWeather,proto3" json:"warn_weather,omitempty"`
+	Updated            int64     `protobuf:"varint,15,opt,name=updated,proto3" json:"updated,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *WeatherEvent) Reset() {
+	*x = WeatherEvent{}
+	mi := &file_grpc_pokemon_api_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WeatherEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WeatherEvent) ProtoMessage() {}
+
+func (x *WeatherEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_grpc_pokemon_api_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WeatherEvent.ProtoReflect.Descriptor instead.
+func (*WeatherEvent) Descriptor() ([]byte, []int) {
+	return file_grpc_pokemon_api_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *WeatherEvent) GetS2CellId() int64 {
+	if x != nil {
+		return x.S2CellId
+	}
+	return 0
+}
+
+func (x *WeatherEvent) GetLatitude() float64 {
+	if x != nil {
+		return x.Latitude
+	}
+	return 0
+}
+
+func (x *WeatherEvent) GetLongitude() float64 {
+	if x != nil {
+		return x.Longitude
+	}
+	return 0
+}
+
+func (x *WeatherEvent) GetPolygon() []float64 {
+	if x != nil {
+		return x.Polygon
+	}
+	return nil
+}
+
+func (x *WeatherEvent) GetGameplayCondition() int32 {
+	if x != nil {
+		return x.GameplayCondition
+	}
+	return 0
+}
+
+func (x *WeatherEvent) GetWindDirection() int32 {
+	if x != nil {
+		return x.WindDirection
+	}
+	return 0
+}
+
+func (x *WeatherEvent) GetCloudLevel() int32 {
+	if x != nil {
+		return x.CloudLevel
+	}
+	return 0
+}
+
+func (x *WeatherEvent) GetRainLevel() int32 {
+	if x != nil {
+		return x.RainLevel
+	}
+	return 0
+}
+
+func (x *WeatherEvent) GetWindLevel() int32 {
+	if x != nil {
+		return x.WindLevel
+	}
+	return 0
+}
+
+func (x *WeatherEvent) GetSnowLevel() int32 {
+	if x != nil {
+		return x.SnowLevel
+	}
+	return 0
+}
+
+func (x *WeatherEvent) GetFogLevel() int32 {
+	if x != nil {
+		return x.FogLevel
+	}
+	return 0
+}
+
+func (x *WeatherEvent) GetSpecialEffectLevel() int32 {
+	if x != nil {
+		return x.SpecialEffectLevel
+	}
+	return 0
+}
+
+func (x *WeatherEvent) GetSeverity() int32 {
+	if x != nil {
+		return x.Severity
+	}
+	return 0
+}
+
+func (x *WeatherEvent) GetWarnWeather() bool {
+	if x != nil {
+		return x.WarnWeather
+	}
+	return false
+}
+
+func (x *WeatherEvent) GetUpdated() int64 {
+	if x != nil {
+		return x.Updated
+	}
+	return 0
+}
+
 var File_grpc_pokemon_api_proto protoreflect.FileDescriptor
 
 const file_grpc_pokemon_api_proto_rawDesc = "" +
@@ -1307,10 +3692,356 @@ const file_grpc_pokemon_api_proto_rawDesc = "" +
 	"_capture_3B\x06\n" +
 	"\x04_pvpB\v\n" +
 	"\t_distanceB\x17\n" +
-	"\x15_display_pokemon_form2\xad\x01\n" +
+	"\x15_display_pokemon_form\"z\n" +
+	"\x10SubscribeRequest\x12\x14\n" +
+	"\x05types\x18\x01 \x03(\tR\x05types\x12\x14\n" +
+	"\x05areas\x18\x02 \x03(\tR\x05areas\x121\n" +
+	"\x04bbox\x18\x03 \x01(\v2\x18.pokemon_api.BoundingBoxH\x00R\x04bbox\x88\x01\x01B\a\n" +
+	"\x05_bbox\"q\n" +
+	"\vBoundingBox\x12\x17\n" +
+	"\amin_lat\x18\x01 \x01(\x01R\x06minLat\x12\x17\n" +
+	"\amin_lon\x18\x02 \x01(\x01R\x06minLon\x12\x17\n" +
+	"\amax_lat\x18\x03 \x01(\x01R\x06maxLat\x12\x17\n" +
+	"\amax_lon\x18\x04 \x01(\x01R\x06maxLon\"\xd0\x04\n" +
+	"\x05Event\x12%\n" +
+	"\x0eschema_version\x18\x01 \x01(\rR\rschemaVersion\x125\n" +
+	"\apokemon\x18\x02 \x01(\v2\x19.pokemon_api.PokemonEventH\x00R\apokemon\x12)\n" +
+	"\x03gym\x18\x03 \x01(\v2\x15.pokemon_api.GymEventH\x00R\x03gym\x12,\n" +
+	"\x04raid\x18\x04 \x01(\v2\x16.pokemon_api.RaidEventH\x00R\x04raid\x128\n" +
+	"\bpokestop\x18\x05 \x01(\v2\x1a.pokemon_api.PokestopEventH\x00R\bpokestop\x12/\n" +
+	"\x05quest\x18\x06 \x01(\v2\x17.pokemon_api.QuestEventH\x00R\x05quest\x128\n" +
+	"\binvasion\x18\a \x01(\v2\x1a.pokemon_api.InvasionEventH\x00R\binvasion\x12<\n" +
+	"\n" +
+	"max_battle\x18\b \x01(\v2\x1b.pokemon_api.MaxBattleEventH\x00R\tmaxBattle\x125\n" +
+	"\astation\x18\t \x01(\v2\x19.pokemon_api.StationEventH\x00R\astation\x125\n" +
+	"\aweather\x18\n" +
+	" \x01(\v2\x19.pokemon_api.WeatherEventH\x00R\aweather\x126\n" +
+	"\adropped\x18\v \x01(\v2\x1a.pokemon_api.EventsDroppedH\x00R\adroppedB\a\n" +
+	"\x05event\"%\n" +
+	"\rEventsDropped\x12\x14\n" +
+	"\x05count\x18\x01 \x01(\x04R\x05count\"\xa6\f\n" +
+	"\fPokemonEvent\x12!\n" +
+	"\fencounter_id\x18\x01 \x01(\tR\vencounterId\x12#\n" +
+	"\rspawnpoint_id\x18\x02 \x01(\tR\fspawnpointId\x12\x1f\n" +
+	"\vpokestop_id\x18\x03 \x01(\tR\n" +
+	"pokestopId\x12(\n" +
+	"\rpokestop_name\x18\x04 \x01(\tH\x00R\fpokestopName\x88\x01\x01\x12\x1d\n" +
+	"\n" +
+	"pokemon_id\x18\x05 \x01(\x05R\tpokemonId\x12\x1a\n" +
+	"\blatitude\x18\x06 \x01(\x01R\blatitude\x12\x1c\n" +
+	"\tlongitude\x18\a \x01(\x01R\tlongitude\x12%\n" +
+	"\x0edisappear_time\x18\b \x01(\x03R\rdisappearTime\x126\n" +
+	"\x17disappear_time_verified\x18\t \x01(\bR\x15disappearTimeVerified\x12\x1d\n" +
+	"\n" +
+	"first_seen\x18\n" +
+	" \x01(\x03R\tfirstSeen\x121\n" +
+	"\x12last_modified_time\x18\v \x01(\x03H\x01R\x10lastModifiedTime\x88\x01\x01\x12\x1b\n" +
+	"\x06gender\x18\f \x01(\x05H\x02R\x06gender\x88\x01\x01\x12\x13\n" +
+	"\x02cp\x18\r \x01(\x05H\x03R\x02cp\x88\x01\x01\x12\x17\n" +
+	"\x04form\x18\x0e \x01(\x05H\x04R\x04form\x88\x01\x01\x12\x1d\n" +
+	"\acostume\x18\x0f \x01(\x05H\x05R\acostume\x88\x01\x01\x120\n" +
+	"\x11individual_attack\x18\x10 \x01(\x05H\x06R\x10individualAttack\x88\x01\x01\x122\n" +
+	"\x12individual_defense\x18\x11 \x01(\x05H\aR\x11individualDefense\x88\x01\x01\x122\n" +
+	"\x12individual_stamina\x18\x12 \x01(\x05H\bR\x11individualStamina\x88\x01\x01\x12(\n" +
+	"\rpokemon_level\x18\x13 \x01(\x05H\tR\fpokemonLevel\x88\x01\x01\x12\x1a\n" +
+	"\x06move_1\x18\x14 \x01(\x05H\n" +
+	"R\x05move1\x88\x01\x01\x12\x1a\n" +
+	"\x06move_2\x18\x15 \x01(\x05H\vR\x05move2\x88\x01\x01\x12\x1b\n" +
+	"\x06weight\x18\x16 \x01(\x01H\fR\x06weight\x88\x01\x01\x12\x17\n" +
+	"\x04size\x18\x17 \x01(\x05H\rR\x04size\x88\x01\x01\x12\x1b\n" +
+	"\x06height\x18\x18 \x01(\x01H\x0eR\x06height\x88\x01\x01\x12\x1d\n" +
+	"\aweather\x18\x19 \x01(\x05H\x0fR\aweather\x88\x01\x01\x12\x1b\n" +
+	"\tcapture_1\x18\x1a \x01(\x01R\bcapture1\x12\x1b\n" +
+	"\tcapture_2\x18\x1b \x01(\x01R\bcapture2\x12\x1b\n" +
+	"\tcapture_3\x18\x1c \x01(\x01R\bcapture3\x12\x19\n" +
+	"\x05shiny\x18\x1d \x01(\bH\x10R\x05shiny\x88\x01\x01\x12\x1f\n" +
+	"\busername\x18\x1e \x01(\tH\x11R\busername\x88\x01\x01\x121\n" +
+	"\x12display_pokemon_id\x18\x1f \x01(\x05H\x12R\x10displayPokemonId\x88\x01\x01\x125\n" +
+	"\x14display_pokemon_form\x18  \x01(\x05H\x13R\x12displayPokemonForm\x88\x01\x01\x12\x19\n" +
+	"\bis_event\x18! \x01(\bR\aisEvent\x12 \n" +
+	"\tseen_type\x18\" \x01(\tH\x14R\bseenType\x88\x01\x01\x12\x1e\n" +
+	"\bpvp_json\x18# \x01(\tH\x15R\apvpJson\x88\x01\x01B\x10\n" +
+	"\x0e_pokestop_nameB\x15\n" +
+	"\x13_last_modified_timeB\t\n" +
+	"\a_genderB\x05\n" +
+	"\x03_cpB\a\n" +
+	"\x05_formB\n" +
+	"\n" +
+	"\b_costumeB\x14\n" +
+	"\x12_individual_attackB\x15\n" +
+	"\x13_individual_defenseB\x15\n" +
+	"\x13_individual_staminaB\x10\n" +
+	"\x0e_pokemon_levelB\t\n" +
+	"\a_move_1B\t\n" +
+	"\a_move_2B\t\n" +
+	"\a_weightB\a\n" +
+	"\x05_sizeB\t\n" +
+	"\a_heightB\n" +
+	"\n" +
+	"\b_weatherB\b\n" +
+	"\x06_shinyB\v\n" +
+	"\t_usernameB\x15\n" +
+	"\x13_display_pokemon_idB\x17\n" +
+	"\x15_display_pokemon_formB\f\n" +
+	"\n" +
+	"_seen_typeB\v\n" +
+	"\t_pvp_json\"\xd2\x04\n" +
+	"\bGymEvent\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x10\n" +
+	"\x03url\x18\x03 \x01(\tR\x03url\x12\x1a\n" +
+	"\blatitude\x18\x04 \x01(\x01R\blatitude\x12\x1c\n" +
+	"\tlongitude\x18\x05 \x01(\x01R\tlongitude\x12\x12\n" +
+	"\x04team\x18\x06 \x01(\x05R\x04team\x12(\n" +
+	"\x10guard_pokemon_id\x18\a \x01(\x05R\x0eguardPokemonId\x12'\n" +
+	"\x0fslots_available\x18\b \x01(\x05R\x0eslotsAvailable\x12(\n" +
+	"\x10ex_raid_eligible\x18\t \x01(\bR\x0eexRaidEligible\x12\x1b\n" +
+	"\tin_battle\x18\n" +
+	" \x01(\bR\binBattle\x12\x1d\n" +
+	"\n" +
+	"sponsor_id\x18\v \x01(\x05R\tsponsorId\x12\x1d\n" +
+	"\n" +
+	"partner_id\x18\f \x01(\x05R\tpartnerId\x12&\n" +
+	"\x0fpower_up_points\x18\r \x01(\x05R\rpowerUpPoints\x12$\n" +
+	"\x0epower_up_level\x18\x0e \x01(\x05R\fpowerUpLevel\x123\n" +
+	"\x16power_up_end_timestamp\x18\x0f \x01(\x03R\x13powerUpEndTimestamp\x12(\n" +
+	"\x10ar_scan_eligible\x18\x10 \x01(\bR\x0earScanEligible\x12*\n" +
+	"\x0edefenders_json\x18\x11 \x01(\tH\x00R\rdefendersJson\x88\x01\x01B\x11\n" +
+	"\x0f_defenders_json\"\xf7\x06\n" +
+	"\tRaidEvent\x12\x15\n" +
+	"\x06gym_id\x18\x01 \x01(\tR\x05gymId\x12\x19\n" +
+	"\bgym_name\x18\x02 \x01(\tR\agymName\x12\x17\n" +
+	"\agym_url\x18\x03 \x01(\tR\x06gymUrl\x12\x1a\n" +
+	"\blatitude\x18\x04 \x01(\x01R\blatitude\x12\x1c\n" +
+	"\tlongitude\x18\x05 \x01(\x01R\tlongitude\x12\x17\n" +
+	"\ateam_id\x18\x06 \x01(\x05R\x06teamId\x12\x14\n" +
+	"\x05spawn\x18\a \x01(\x03R\x05spawn\x12\x14\n" +
+	"\x05start\x18\b \x01(\x03R\x05start\x12\x10\n" +
+	"\x03end\x18\t \x01(\x03R\x03end\x12\x14\n" +
+	"\x05level\x18\n" +
+	" \x01(\x05R\x05level\x12\x1d\n" +
+	"\n" +
+	"pokemon_id\x18\v \x01(\x05R\tpokemonId\x12\x0e\n" +
+	"\x02cp\x18\f \x01(\x05R\x02cp\x12\x16\n" +
+	"\x06gender\x18\r \x01(\x05R\x06gender\x12\x12\n" +
+	"\x04form\x18\x0e \x01(\x05R\x04form\x12\x1c\n" +
+	"\talignment\x18\x0f \x01(\x05R\talignment\x12\x18\n" +
+	"\acostume\x18\x10 \x01(\x05R\acostume\x12\x1c\n" +
+	"\tevolution\x18\x11 \x01(\x05R\tevolution\x12\x15\n" +
+	"\x06move_1\x18\x12 \x01(\x05R\x05move1\x12\x15\n" +
+	"\x06move_2\x18\x13 \x01(\x05R\x05move2\x12(\n" +
+	"\x10ex_raid_eligible\x18\x14 \x01(\bR\x0eexRaidEligible\x12!\n" +
+	"\fis_exclusive\x18\x15 \x01(\bR\visExclusive\x12\x1d\n" +
+	"\n" +
+	"sponsor_id\x18\x16 \x01(\x05R\tsponsorId\x12\x1d\n" +
+	"\n" +
+	"partner_id\x18\x17 \x01(\tR\tpartnerId\x12&\n" +
+	"\x0fpower_up_points\x18\x18 \x01(\x05R\rpowerUpPoints\x12$\n" +
+	"\x0epower_up_level\x18\x19 \x01(\x05R\fpowerUpLevel\x123\n" +
+	"\x16power_up_end_timestamp\x18\x1a \x01(\x03R\x13powerUpEndTimestamp\x12(\n" +
+	"\x10ar_scan_eligible\x18\x1b \x01(\bR\x0earScanEligible\x12\"\n" +
+	"\n" +
+	"rsvps_json\x18\x1c \x01(\tH\x00R\trsvpsJson\x88\x01\x01\x12 \n" +
+	"\traid_seed\x18\x1d \x01(\tH\x01R\braidSeed\x88\x01\x01B\r\n" +
+	"\v_rsvps_jsonB\f\n" +
+	"\n" +
+	"_raid_seed\"\x9f\b\n" +
+	"\rPokestopEvent\x12\x1f\n" +
+	"\vpokestop_id\x18\x01 \x01(\tR\n" +
+	"pokestopId\x12\x1a\n" +
+	"\blatitude\x18\x02 \x01(\x01R\blatitude\x12\x1c\n" +
+	"\tlongitude\x18\x03 \x01(\x01R\tlongitude\x12\x12\n" +
+	"\x04name\x18\x04 \x01(\tR\x04name\x12\x10\n" +
+	"\x03url\x18\x05 \x01(\tR\x03url\x12'\n" +
+	"\x0flure_expiration\x18\x06 \x01(\x03R\x0elureExpiration\x12#\n" +
+	"\rlast_modified\x18\a \x01(\x03R\flastModified\x12\x18\n" +
+	"\aenabled\x18\b \x01(\bR\aenabled\x12\x17\n" +
+	"\alure_id\x18\t \x01(\x05R\x06lureId\x12(\n" +
+	"\x10ar_scan_eligible\x18\n" +
+	" \x01(\bR\x0earScanEligible\x12$\n" +
+	"\x0epower_up_level\x18\v \x01(\x05R\fpowerUpLevel\x12&\n" +
+	"\x0fpower_up_points\x18\f \x01(\x05R\rpowerUpPoints\x123\n" +
+	"\x16power_up_end_timestamp\x18\r \x01(\x03R\x13powerUpEndTimestamp\x12\x18\n" +
+	"\aupdated\x18\x0e \x01(\x03R\aupdated\x123\n" +
+	"\x13showcase_focus_json\x18\x0f \x01(\tH\x00R\x11showcaseFocusJson\x88\x01\x01\x123\n" +
+	"\x13showcase_pokemon_id\x18\x10 \x01(\x05H\x01R\x11showcasePokemonId\x88\x01\x01\x12<\n" +
+	"\x18showcase_pokemon_form_id\x18\x11 \x01(\x05H\x02R\x15showcasePokemonFormId\x88\x01\x01\x12<\n" +
+	"\x18showcase_pokemon_type_id\x18\x12 \x01(\x05H\x03R\x15showcasePokemonTypeId\x88\x01\x01\x12?\n" +
+	"\x19showcase_ranking_standard\x18\x13 \x01(\x05H\x04R\x17showcaseRankingStandard\x88\x01\x01\x12,\n" +
+	"\x0fshowcase_expiry\x18\x14 \x01(\x03H\x05R\x0eshowcaseExpiry\x88\x01\x01\x129\n" +
+	"\x16showcase_rankings_json\x18\x15 \x01(\tH\x06R\x14showcaseRankingsJson\x88\x01\x01B\x16\n" +
+	"\x14_showcase_focus_jsonB\x16\n" +
+	"\x14_showcase_pokemon_idB\x1b\n" +
+	"\x19_showcase_pokemon_form_idB\x1b\n" +
+	"\x19_showcase_pokemon_type_idB\x1c\n" +
+	"\x1a_showcase_ranking_standardB\x12\n" +
+	"\x10_showcase_expiryB\x19\n" +
+	"\x17_showcase_rankings_json\"\xd7\x04\n" +
+	"\n" +
+	"QuestEvent\x12\x1f\n" +
+	"\vpokestop_id\x18\x01 \x01(\tR\n" +
+	"pokestopId\x12\x1a\n" +
+	"\blatitude\x18\x02 \x01(\x01R\blatitude\x12\x1c\n" +
+	"\tlongitude\x18\x03 \x01(\x01R\tlongitude\x12#\n" +
+	"\rpokestop_name\x18\x04 \x01(\tR\fpokestopName\x12!\n" +
+	"\fpokestop_url\x18\x05 \x01(\tR\vpokestopUrl\x12\x17\n" +
+	"\x04type\x18\x06 \x01(\x05H\x00R\x04type\x88\x01\x01\x12\x1b\n" +
+	"\x06target\x18\a \x01(\x05H\x01R\x06target\x88\x01\x01\x12\x1f\n" +
+	"\btemplate\x18\b \x01(\tH\x02R\btemplate\x88\x01\x01\x12\x19\n" +
+	"\x05title\x18\t \x01(\tH\x03R\x05title\x88\x01\x01\x12,\n" +
+	"\x0fconditions_json\x18\n" +
+	" \x01(\tH\x04R\x0econditionsJson\x88\x01\x01\x12&\n" +
+	"\frewards_json\x18\v \x01(\tH\x05R\vrewardsJson\x88\x01\x01\x12\x18\n" +
+	"\aupdated\x18\f \x01(\x03R\aupdated\x12(\n" +
+	"\x10ar_scan_eligible\x18\r \x01(\bR\x0earScanEligible\x12\x17\n" +
+	"\awith_ar\x18\x0e \x01(\bR\x06withAr\x12\"\n" +
+	"\n" +
+	"quest_seed\x18\x0f \x01(\tH\x06R\tquestSeed\x88\x01\x01B\a\n" +
+	"\x05_typeB\t\n" +
+	"\a_targetB\v\n" +
+	"\t_templateB\b\n" +
+	"\x06_titleB\x12\n" +
+	"\x10_conditions_jsonB\x0f\n" +
+	"\r_rewards_jsonB\r\n" +
+	"\v_quest_seed\"\x80\x04\n" +
+	"\rInvasionEvent\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1f\n" +
+	"\vpokestop_id\x18\x02 \x01(\tR\n" +
+	"pokestopId\x12\x1a\n" +
+	"\blatitude\x18\x03 \x01(\x01R\blatitude\x12\x1c\n" +
+	"\tlongitude\x18\x04 \x01(\x01R\tlongitude\x12#\n" +
+	"\rpokestop_name\x18\x05 \x01(\tR\fpokestopName\x12\x10\n" +
+	"\x03url\x18\x06 \x01(\tR\x03url\x12\x18\n" +
+	"\aenabled\x18\a \x01(\bR\aenabled\x12\x14\n" +
+	"\x05start\x18\b \x01(\x03R\x05start\x12:\n" +
+	"\x19incident_expire_timestamp\x18\t \x01(\x03R\x17incidentExpireTimestamp\x12!\n" +
+	"\fdisplay_type\x18\n" +
+	" \x01(\x05R\vdisplayType\x12\x14\n" +
+	"\x05style\x18\v \x01(\x05R\x05style\x12\x1d\n" +
+	"\n" +
+	"grunt_type\x18\f \x01(\x05R\tgruntType\x12\x1c\n" +
+	"\tcharacter\x18\r \x01(\x05R\tcharacter\x12\x18\n" +
+	"\aupdated\x18\x0e \x01(\x03R\aupdated\x12\x1c\n" +
+	"\tconfirmed\x18\x0f \x01(\bR\tconfirmed\x123\n" +
+	"\x06lineup\x18\x10 \x03(\v2\x1b.pokemon_api.InvasionLineupR\x06lineup\"y\n" +
+	"\x0eInvasionLineup\x12\x12\n" +
+	"\x04slot\x18\x01 \x01(\x05R\x04slot\x12\"\n" +
+	"\n" +
+	"pokemon_id\x18\x02 \x01(\x05H\x00R\tpokemonId\x88\x01\x01\x12\x17\n" +
+	"\x04form\x18\x03 \x01(\x05H\x01R\x04form\x88\x01\x01B\r\n" +
+	"\v_pokemon_idB\a\n" +
+	"\x05_form\"\x91\n" +
+	"\n" +
+	"\x0eMaxBattleEvent\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1a\n" +
+	"\blatitude\x18\x02 \x01(\x01R\blatitude\x12\x1c\n" +
+	"\tlongitude\x18\x03 \x01(\x01R\tlongitude\x12\x12\n" +
+	"\x04name\x18\x04 \x01(\tR\x04name\x12\x1d\n" +
+	"\n" +
+	"start_time\x18\x05 \x01(\x03R\tstartTime\x12\x19\n" +
+	"\bend_time\x18\x06 \x01(\x03R\aendTime\x12.\n" +
+	"\x13is_battle_available\x18\a \x01(\bR\x11isBattleAvailable\x12&\n" +
+	"\fbattle_level\x18\b \x01(\x05H\x00R\vbattleLevel\x88\x01\x01\x12&\n" +
+	"\fbattle_start\x18\t \x01(\x03H\x01R\vbattleStart\x88\x01\x01\x12\"\n" +
+	"\n" +
+	"battle_end\x18\n" +
+	" \x01(\x03H\x02R\tbattleEnd\x88\x01\x01\x12/\n" +
+	"\x11battle_pokemon_id\x18\v \x01(\x05H\x03R\x0fbattlePokemonId\x88\x01\x01\x123\n" +
+	"\x13battle_pokemon_form\x18\f \x01(\x05H\x04R\x11battlePokemonForm\x88\x01\x01\x129\n" +
+	"\x16battle_pokemon_costume\x18\r \x01(\x05H\x05R\x14battlePokemonCostume\x88\x01\x01\x127\n" +
+	"\x15battle_pokemon_gender\x18\x0e \x01(\x05H\x06R\x13battlePokemonGender\x88\x01\x01\x12=\n" +
+	"\x18battle_pokemon_alignment\x18\x0f \x01(\x05H\aR\x16battlePokemonAlignment\x88\x01\x01\x12>\n" +
+	"\x19battle_pokemon_bread_mode\x18\x10 \x01(\x05H\bR\x16battlePokemonBreadMode\x88\x01\x01\x126\n" +
+	"\x15battle_pokemon_move_1\x18\x11 \x01(\x05H\tR\x12battlePokemonMove1\x88\x01\x01\x126\n" +
+	"\x15battle_pokemon_move_2\x18\x12 \x01(\x05H\n" +
+	"R\x12battlePokemonMove2\x88\x01\x01\x12;\n" +
+	"\x17total_stationed_pokemon\x18\x13 \x01(\x05H\vR\x15totalStationedPokemon\x88\x01\x01\x125\n" +
+	"\x14total_stationed_gmax\x18\x14 \x01(\x05H\fR\x12totalStationedGmax\x88\x01\x01\x124\n" +
+	"\abattles\x18\x15 \x03(\v2\x1a.pokemon_api.StationBattleR\abattles\x12\x18\n" +
+	"\aupdated\x18\x16 \x01(\x03R\aupdatedB\x0f\n" +
+	"\r_battle_levelB\x0f\n" +
+	"\r_battle_startB\r\n" +
+	"\v_battle_endB\x14\n" +
+	"\x12_battle_pokemon_idB\x16\n" +
+	"\x14_battle_pokemon_formB\x19\n" +
+	"\x17_battle_pokemon_costumeB\x18\n" +
+	"\x16_battle_pokemon_genderB\x1b\n" +
+	"\x19_battle_pokemon_alignmentB\x1c\n" +
+	"\x1a_battle_pokemon_bread_modeB\x18\n" +
+	"\x16_battle_pokemon_move_1B\x18\n" +
+	"\x16_battle_pokemon_move_2B\x1a\n" +
+	"\x18_total_stationed_pokemonB\x17\n" +
+	"\x15_total_stationed_gmax\"\xf8\a\n" +
+	"\rStationBattle\x12*\n" +
+	"\x11bread_battle_seed\x18\x01 \x01(\x03R\x0fbreadBattleSeed\x12!\n" +
+	"\fbattle_level\x18\x02 \x01(\x05R\vbattleLevel\x12!\n" +
+	"\fbattle_start\x18\x03 \x01(\x03R\vbattleStart\x12\x1d\n" +
+	"\n" +
+	"battle_end\x18\x04 \x01(\x03R\tbattleEnd\x12/\n" +
+	"\x11battle_pokemon_id\x18\x05 \x01(\x05H\x00R\x0fbattlePokemonId\x88\x01\x01\x123\n" +
+	"\x13battle_pokemon_form\x18\x06 \x01(\x05H\x01R\x11battlePokemonForm\x88\x01\x01\x129\n" +
+	"\x16battle_pokemon_costume\x18\a \x01(\x05H\x02R\x14battlePokemonCostume\x88\x01\x01\x127\n" +
+	"\x15battle_pokemon_gender\x18\b \x01(\x05H\x03R\x13battlePokemonGender\x88\x01\x01\x12=\n" +
+	"\x18battle_pokemon_alignment\x18\t \x01(\x05H\x04R\x16battlePokemonAlignment\x88\x01\x01\x12>\n" +
+	"\x19battle_pokemon_bread_mode\x18\n" +
+	" \x01(\x05H\x05R\x16battlePokemonBreadMode\x88\x01\x01\x126\n" +
+	"\x15battle_pokemon_move_1\x18\v \x01(\x05H\x06R\x12battlePokemonMove1\x88\x01\x01\x126\n" +
+	"\x15battle_pokemon_move_2\x18\f \x01(\x05H\aR\x12battlePokemonMove2\x88\x01\x01\x129\n" +
+	"\x16battle_pokemon_stamina\x18\r \x01(\x05H\bR\x14battlePokemonStamina\x88\x01\x01\x12D\n" +
+	"\x1cbattle_pokemon_cp_multiplier\x18\x0e \x01(\x01H\tR\x19battlePokemonCpMultiplier\x88\x01\x01B\x14\n" +
+	"\x12_battle_pokemon_idB\x16\n" +
+	"\x14_battle_pokemon_formB\x19\n" +
+	"\x17_battle_pokemon_costumeB\x18\n" +
+	"\x16_battle_pokemon_genderB\x1b\n" +
+	"\x19_battle_pokemon_alignmentB\x1c\n" +
+	"\x1a_battle_pokemon_bread_modeB\x18\n" +
+	"\x16_battle_pokemon_move_1B\x18\n" +
+	"\x16_battle_pokemon_move_2B\x19\n" +
+	"\x17_battle_pokemon_staminaB\x1f\n" +
+	"\x1d_battle_pokemon_cp_multiplier\"\xba\x03\n" +
+	"\fStationEvent\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1a\n" +
+	"\blatitude\x18\x02 \x01(\x01R\blatitude\x12\x1c\n" +
+	"\tlongitude\x18\x03 \x01(\x01R\tlongitude\x12\x12\n" +
+	"\x04name\x18\x04 \x01(\tR\x04name\x12\x1d\n" +
+	"\n" +
+	"start_time\x18\x05 \x01(\x03R\tstartTime\x12\x19\n" +
+	"\bend_time\x18\x06 \x01(\x03R\aendTime\x12.\n" +
+	"\x13is_battle_available\x18\a \x01(\bR\x11isBattleAvailable\x12\x1f\n" +
+	"\vis_inactive\x18\b \x01(\bR\n" +
+	"isInactive\x12;\n" +
+	"\x17total_stationed_pokemon\x18\t \x01(\x05H\x00R\x15totalStationedPokemon\x88\x01\x01\x125\n" +
+	"\x14total_stationed_gmax\x18\n" +
+	" \x01(\x05H\x01R\x12totalStationedGmax\x88\x01\x01\x12\x18\n" +
+	"\aupdated\x18\v \x01(\x03R\aupdatedB\x1a\n" +
+	"\x18_total_stationed_pokemonB\x17\n" +
+	"\x15_total_stationed_gmax\"\xfc\x03\n" +
+	"\fWeatherEvent\x12\x1c\n" +
+	"\n" +
+	"s2_cell_id\x18\x01 \x01(\x03R\bs2CellId\x12\x1a\n" +
+	"\blatitude\x18\x02 \x01(\x01R\blatitude\x12\x1c\n" +
+	"\tlongitude\x18\x03 \x01(\x01R\tlongitude\x12\x18\n" +
+	"\apolygon\x18\x04 \x03(\x01R\apolygon\x12-\n" +
+	"\x12gameplay_condition\x18\x05 \x01(\x05R\x11gameplayCondition\x12%\n" +
+	"\x0ewind_direction\x18\x06 \x01(\x05R\rwindDirection\x12\x1f\n" +
+	"\vcloud_level\x18\a \x01(\x05R\n" +
+	"cloudLevel\x12\x1d\n" +
+	"\n" +
+	"rain_level\x18\b \x01(\x05R\trainLevel\x12\x1d\n" +
+	"\n" +
+	"wind_level\x18\t \x01(\x05R\twindLevel\x12\x1d\n" +
+	"\n" +
+	"snow_level\x18\n" +
+	" \x01(\x05R\tsnowLevel\x12\x1b\n" +
+	"\tfog_level\x18\v \x01(\x05R\bfogLevel\x120\n" +
+	"\x14special_effect_level\x18\f \x01(\x05R\x12specialEffectLevel\x12\x1a\n" +
+	"\bseverity\x18\r \x01(\x05R\bseverity\x12!\n" +
+	"\fwarn_weather\x18\x0e \x01(\bR\vwarnWeather\x12\x18\n" +
+	"\aupdated\x18\x0f \x01(\x03R\aupdated2\xf1\x01\n" +
 	"\aPokemon\x12M\n" +
 	"\x06Search\x12\x1f.pokemon_api.PokemonScanRequest\x1a .pokemon_api.PokemonScanResponse\"\x00\x12S\n" +
-	"\bSearchV3\x12!.pokemon_api.PokemonScanRequestV3\x1a\".pokemon_api.PokemonScanResponseV3\"\x00B\"Z github.com/unownhash/golbat/grpcb\x06proto3"
+	"\bSearchV3\x12!.pokemon_api.PokemonScanRequestV3\x1a\".pokemon_api.PokemonScanResponseV3\"\x00\x12B\n" +
+	"\tSubscribe\x12\x1d.pokemon_api.SubscribeRequest\x1a\x12.pokemon_api.Event\"\x000\x01B\"Z github.com/unownhash/golbat/grpcb\x06proto3"
 
 var (
 	file_grpc_pokemon_api_proto_rawDescOnce sync.Once
@@ -1325,7 +4056,7 @@ func file_grpc_pokemon_api_proto_rawDescGZIP() []byte {
 }
 
 var file_grpc_pokemon_api_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
-var file_grpc_pokemon_api_proto_msgTypes = make([]protoimpl.MessageInfo, 9)
+var file_grpc_pokemon_api_proto_msgTypes = make([]protoimpl.MessageInfo, 24)
 var file_grpc_pokemon_api_proto_goTypes = []any{
 	(PokemonScanResponse_Status)(0),   // 0: pokemon_api.PokemonScanResponse.Status
 	(PokemonScanResponseV3_Status)(0), // 1: pokemon_api.PokemonScanResponseV3.Status
@@ -1338,6 +4069,21 @@ var file_grpc_pokemon_api_proto_goTypes = []any{
 	(*PokemonScanResponse)(nil),       // 8: pokemon_api.PokemonScanResponse
 	(*PokemonScanResponseV3)(nil),     // 9: pokemon_api.PokemonScanResponseV3
 	(*PokemonDetails)(nil),            // 10: pokemon_api.PokemonDetails
+	(*SubscribeRequest)(nil),          // 11: pokemon_api.SubscribeRequest
+	(*BoundingBox)(nil),               // 12: pokemon_api.BoundingBox
+	(*Event)(nil),                     // 13: pokemon_api.Event
+	(*EventsDropped)(nil),             // 14: pokemon_api.EventsDropped
+	(*PokemonEvent)(nil),              // 15: pokemon_api.PokemonEvent
+	(*GymEvent)(nil),                  // 16: pokemon_api.GymEvent
+	(*RaidEvent)(nil),                 // 17: pokemon_api.RaidEvent
+	(*PokestopEvent)(nil),             // 18: pokemon_api.PokestopEvent
+	(*QuestEvent)(nil),                // 19: pokemon_api.QuestEvent
+	(*InvasionEvent)(nil),             // 20: pokemon_api.InvasionEvent
+	(*InvasionLineup)(nil),            // 21: pokemon_api.InvasionLineup
+	(*MaxBattleEvent)(nil),            // 22: pokemon_api.MaxBattleEvent
+	(*StationBattle)(nil),             // 23: pokemon_api.StationBattle
+	(*StationEvent)(nil),              // 24: pokemon_api.StationEvent
+	(*WeatherEvent)(nil),              // 25: pokemon_api.WeatherEvent
 }
 var file_grpc_pokemon_api_proto_depIdxs = []int32{
 	4,  // 0: pokemon_api.PokemonScanRequest.filters:type_name -> pokemon_api.PokemonDnf
@@ -1369,15 +4115,30 @@ var file_grpc_pokemon_api_proto_depIdxs = []int32{
 	10, // 26: pokemon_api.PokemonScanResponse.pokemon:type_name -> pokemon_api.PokemonDetails
 	1,  // 27: pokemon_api.PokemonScanResponseV3.status:type_name -> pokemon_api.PokemonScanResponseV3.Status
 	10, // 28: pokemon_api.PokemonScanResponseV3.pokemon:type_name -> pokemon_api.PokemonDetails
-	2,  // 29: pokemon_api.Pokemon.Search:input_type -> pokemon_api.PokemonScanRequest
-	3,  // 30: pokemon_api.Pokemon.SearchV3:input_type -> pokemon_api.PokemonScanRequestV3
-	8,  // 31: pokemon_api.Pokemon.Search:output_type -> pokemon_api.PokemonScanResponse
-	9,  // 32: pokemon_api.Pokemon.SearchV3:output_type -> pokemon_api.PokemonScanResponseV3
-	31, // [31:33] is the sub-list for method output_type
-	29, // [29:31] is the sub-list for method input_type
-	29, // [29:29] is the sub-list for extension type_name
-	29, // [29:29] is the sub-list for extension extendee
-	0,  // [0:29] is the sub-list for field type_name
+	12, // 29: pokemon_api.SubscribeRequest.bbox:type_name -> pokemon_api.BoundingBox
+	15, // 30: pokemon_api.Event.pokemon:type_name -> pokemon_api.PokemonEvent
+	16, // 31: pokemon_api.Event.gym:type_name -> pokemon_api.GymEvent
+	17, // 32: pokemon_api.Event.raid:type_name -> pokemon_api.RaidEvent
+	18, // 33: pokemon_api.Event.pokestop:type_name -> pokemon_api.PokestopEvent
+	19, // 34: pokemon_api.Event.quest:type_name -> pokemon_api.QuestEvent
+	20, // 35: pokemon_api.Event.invasion:type_name -> pokemon_api.InvasionEvent
+	22, // 36: pokemon_api.Event.max_battle:type_name -> pokemon_api.MaxBattleEvent
+	24, // 37: pokemon_api.Event.station:type_name -> pokemon_api.StationEvent
+	25, // 38: pokemon_api.Event.weather:type_name -> pokemon_api.WeatherEvent
+	14, // 39: pokemon_api.Event.dropped:type_name -> pokemon_api.EventsDropped
+	21, // 40: pokemon_api.InvasionEvent.lineup:type_name -> pokemon_api.InvasionLineup
+	23, // 41: pokemon_api.MaxBattleEvent.battles:type_name -> pokemon_api.StationBattle
+	2,  // 42: pokemon_api.Pokemon.Search:input_type -> pokemon_api.PokemonScanRequest
+	3,  // 43: pokemon_api.Pokemon.SearchV3:input_type -> pokemon_api.PokemonScanRequestV3
+	11, // 44: pokemon_api.Pokemon.Subscribe:input_type -> pokemon_api.SubscribeRequest
+	8,  // 45: pokemon_api.Pokemon.Search:output_type -> pokemon_api.PokemonScanResponse
+	9,  // 46: pokemon_api.Pokemon.SearchV3:output_type -> pokemon_api.PokemonScanResponseV3
+	13, // 47: pokemon_api.Pokemon.Subscribe:output_type -> pokemon_api.Event
+	45, // [45:48] is the sub-list for method output_type
+	42, // [42:45] is the sub-list for method input_type
+	42, // [42:42] is the sub-list for extension type_name
+	42, // [42:42] is the sub-list for extension extendee
+	0,  // [0:42] is the sub-list for field type_name
 }
 
 func init() { file_grpc_pokemon_api_proto_init() }
@@ -1390,13 +4151,35 @@ func file_grpc_pokemon_api_proto_init() {
 	file_grpc_pokemon_api_proto_msgTypes[4].OneofWrappers = []any{}
 	file_grpc_pokemon_api_proto_msgTypes[5].OneofWrappers = []any{}
 	file_grpc_pokemon_api_proto_msgTypes[8].OneofWrappers = []any{}
+	file_grpc_pokemon_api_proto_msgTypes[9].OneofWrappers = []any{}
+	file_grpc_pokemon_api_proto_msgTypes[11].OneofWrappers = []any{
+		(*Event_Pokemon)(nil),
+		(*Event_Gym)(nil),
+		(*Event_Raid)(nil),
+		(*Event_Pokestop)(nil),
+		(*Event_Quest)(nil),
+		(*Event_Invasion)(nil),
+		(*Event_MaxBattle)(nil),
+		(*Event_Station)(nil),
+		(*Event_Weather)(nil),
+		(*Event_Dropped)(nil),
+	}
+	file_grpc_pokemon_api_proto_msgTypes[13].OneofWrappers = []any{}
+	file_grpc_pokemon_api_proto_msgTypes[14].OneofWrappers = []any{}
+	file_grpc_pokemon_api_proto_msgTypes[15].OneofWrappers = []any{}
+	file_grpc_pokemon_api_proto_msgTypes[16].OneofWrappers = []any{}
+	file_grpc_pokemon_api_proto_msgTypes[17].OneofWrappers = []any{}
+	file_grpc_pokemon_api_proto_msgTypes[19].OneofWrappers = []any{}
+	file_grpc_pokemon_api_proto_msgTypes[20].OneofWrappers = []any{}
+	file_grpc_pokemon_api_proto_msgTypes[21].OneofWrappers = []any{}
+	file_grpc_pokemon_api_proto_msgTypes[22].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_grpc_pokemon_api_proto_rawDesc), len(file_grpc_pokemon_api_proto_rawDesc)),
 			NumEnums:      2,
-			NumMessages:   9,
+			NumMessages:   24,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
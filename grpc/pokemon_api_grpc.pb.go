@@ -19,8 +19,9 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	Pokemon_Search_FullMethodName   = "/pokemon_api.Pokemon/Search"
-	Pokemon_SearchV3_FullMethodName = "/pokemon_api.Pokemon/SearchV3"
+	Pokemon_Search_FullMethodName    = "/pokemon_api.Pokemon/Search"
+	Pokemon_SearchV3_FullMethodName  = "/pokemon_api.Pokemon/SearchV3"
+	Pokemon_Subscribe_FullMethodName = "/pokemon_api.Pokemon/Subscribe"
 )
 
 // PokemonClient is the client API for Pokemon service.
@@ -31,6 +32,8 @@ const (
 type PokemonClient interface {
 	Search(ctx context.Context, in *PokemonScanRequest, opts ...grpc.CallOption) (*PokemonScanResponse, error)
 	SearchV3(ctx context.Context, in *PokemonScanRequestV3, opts ...grpc.CallOption) (*PokemonScanResponseV3, error)
+	// Subscribe streams the messages also sent as webhooks, as typed events
+	Subscribe(ctx context.Context, in *SubscribeRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Event], error)
 }
 
 type pokemonClient struct {
@@ -61,6 +64,25 @@ func (c *pokemonClient) SearchV3(ctx context.Context, in *PokemonScanRequestV3,
 	return out, nil
 }
 
+func (c *pokemonClient) Subscribe(ctx context.Context, in *SubscribeRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Event], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Pokemon_ServiceDesc.Streams[0], Pokemon_Subscribe_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[SubscribeRequest, Event]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Pokemon_SubscribeClient = grpc.ServerStreamingClient[Event]
+
 // PokemonServer is the server API for Pokemon service.
 // All implementations must embed UnimplementedPokemonServer
 // for forward compatibility.
@@ -69,6 +91,8 @@ func (c *pokemonClient) SearchV3(ctx context.Context, in *PokemonScanRequestV3,
 type PokemonServer interface {
 	Search(context.Context, *PokemonScanRequest) (*PokemonScanResponse, error)
 	SearchV3(context.Context, *PokemonScanRequestV3) (*PokemonScanResponseV3, error)
+	// Subscribe streams the messages also sent as webhooks, as typed events
+	Subscribe(*SubscribeRequest, grpc.ServerStreamingServer[Event]) error
 	mustEmbedUnimplementedPokemonServer()
 }
 
@@ -85,6 +109,9 @@ func (UnimplementedPokemonServer) Search(context.Context, *PokemonScanRequest) (
 func (UnimplementedPokemonServer) SearchV3(context.Context, *PokemonScanRequestV3) (*PokemonScanResponseV3, error) {
 	return nil, status.Error(codes.Unimplemented, "method SearchV3 not implemented")
 }
+func (UnimplementedPokemonServer) Subscribe(*SubscribeRequest, grpc.ServerStreamingServer[Event]) error {
+	return status.Error(codes.Unimplemented, "method Subscribe not implemented")
+}
 func (UnimplementedPokemonServer) mustEmbedUnimplementedPokemonServer() {}
 func (UnimplementedPokemonServer) testEmbeddedByValue()                 {}
 
@@ -142,6 +169,17 @@ func _Pokemon_SearchV3_Handler(srv interface{}, ctx context.Context, dec func(in
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Pokemon_Subscribe_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SubscribeRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(PokemonServer).Subscribe(m, &grpc.GenericServerStream[SubscribeRequest, Event]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Pokemon_SubscribeServer = grpc.ServerStreamingServer[Event]
+
 // Pokemon_ServiceDesc is the grpc.ServiceDesc for Pokemon service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -158,6 +196,12 @@ var Pokemon_ServiceDesc = grpc.ServiceDesc{
 			Handler:    _Pokemon_SearchV3_Handler,
 		},
 	},
-	Streams:  []grpc.StreamDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Subscribe",
+			Handler:       _Pokemon_Subscribe_Handler,
+			ServerStreams: true,
+		},
+	},
 	Metadata: "grpc/pokemon_api.proto",
 }
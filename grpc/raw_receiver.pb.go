@@ -34,6 +34,7 @@ type RawProtoRequest struct {
 	ScanContext    *string                `protobuf:"bytes,9,opt,name=scan_context,json=scanContext,proto3,oneof" json:"scan_context,omitempty"`
 	NebulaContents []*NebulaContent       `protobuf:"bytes,10,rep,name=nebula_contents,json=nebulaContents,proto3" json:"nebula_contents,omitempty"`
 	PushContents   []*PushGatewayContent  `protobuf:"bytes,11,rep,name=push_contents,json=pushContents,proto3" json:"push_contents,omitempty"` // push-gateway messages forwarded verbatim
+	Sequence       uint64                 `protobuf:"varint,12,opt,name=sequence,proto3" json:"sequence,omitempty"`                            // optional sender-chosen id, echoed in the streaming acknowledgement
 	unknownFields  protoimpl.UnknownFields
 	sizeCache      protoimpl.SizeCache
 }
@@ -145,6 +146,13 @@ func (x *RawProtoRequest) GetPushContents() []*PushGatewayContent {
 	return nil
 }
 
+func (x *RawProtoRequest) GetSequence() uint64 {
+	if x != nil {
+		return x.Sequence
+	}
+	return 0
+}
+
 type PushGatewayContent struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	MessageType   string                 `protobuf:"bytes,1,opt,name=message_type,json=messageType,proto3" json:"message_type,omitempty"` // oneof-case tag, e.g. "raid_lobby_player_count"
@@ -205,6 +213,8 @@ type NebulaContent struct {
 	// Types that are valid to be assigned to Context:
 	//
 	//	*NebulaContent_Invasion
+	//	*NebulaContent_Raid
+	//	*NebulaContent_MaxBattle
 	Context       isNebulaContent_Context `protobuf_oneof:"context"`
 	BattleId      string                  `protobuf:"bytes,5,opt,name=battle_id,json=battleId,proto3" json:"battle_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
@@ -278,6 +288,24 @@ func (x *NebulaContent) GetInvasion() *InvasionContext {
 	return nil
 }
 
+func (x *NebulaContent) GetRaid() *RaidContext {
+	if x != nil {
+		if x, ok := x.Context.(*NebulaContent_Raid); ok {
+			return x.Raid
+		}
+	}
+	return nil
+}
+
+func (x *NebulaContent) GetMaxBattle() *MaxBattleContext {
+	if x != nil {
+		if x, ok := x.Context.(*NebulaContent_MaxBattle); ok {
+			return x.MaxBattle
+		}
+	}
+	return nil
+}
+
 func (x *NebulaContent) GetBattleId() string {
 	if x != nil {
 		return x.BattleId
@@ -293,8 +321,20 @@ type NebulaContent_Invasion struct {
 	Invasion *InvasionContext `protobuf:"bytes,4,opt,name=invasion,proto3,oneof"`
 }
 
+type NebulaContent_Raid struct {
+	Raid *RaidContext `protobuf:"bytes,6,opt,name=raid,proto3,oneof"`
+}
+
+type NebulaContent_MaxBattle struct {
+	MaxBattle *MaxBattleContext `protobuf:"bytes,7,opt,name=max_battle,json=maxBattle,proto3,oneof"`
+}
+
 func (*NebulaContent_Invasion) isNebulaContent_Context() {}
 
+func (*NebulaContent_Raid) isNebulaContent_Context() {}
+
+func (*NebulaContent_MaxBattle) isNebulaContent_Context() {}
+
 type InvasionContext struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	FortId        string                 `protobuf:"bytes,1,opt,name=fort_id,json=fortId,proto3" json:"fort_id,omitempty"`
@@ -347,6 +387,110 @@ func (x *InvasionContext) GetIncidentId() string {
 	return ""
 }
 
+type RaidContext struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	FortId        string                 `protobuf:"bytes,1,opt,name=fort_id,json=fortId,proto3" json:"fort_id,omitempty"`
+	RaidSeed      int64                  `protobuf:"varint,2,opt,name=raid_seed,json=raidSeed,proto3" json:"raid_seed,omitempty"` // optional; when set must match the gym's current raid
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RaidContext) Reset() {
+	*x = RaidContext{}
+	mi := &file_grpc_raw_receiver_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RaidContext) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RaidContext) ProtoMessage() {}
+
+func (x *RaidContext) ProtoReflect() protoreflect.Message {
+	mi := &file_grpc_raw_receiver_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RaidContext.ProtoReflect.Descriptor instead.
+func (*RaidContext) Descriptor() ([]byte, []int) {
+	return file_grpc_raw_receiver_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *RaidContext) GetFortId() string {
+	if x != nil {
+		return x.FortId
+	}
+	return ""
+}
+
+func (x *RaidContext) GetRaidSeed() int64 {
+	if x != nil {
+		return x.RaidSeed
+	}
+	return 0
+}
+
+type MaxBattleContext struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	StationId       string                 `protobuf:"bytes,1,opt,name=station_id,json=stationId,proto3" json:"station_id,omitempty"`
+	BreadBattleSeed int64                  `protobuf:"varint,2,opt,name=bread_battle_seed,json=breadBattleSeed,proto3" json:"bread_battle_seed,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *MaxBattleContext) Reset() {
+	*x = MaxBattleContext{}
+	mi := &file_grpc_raw_receiver_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MaxBattleContext) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MaxBattleContext) ProtoMessage() {}
+
+func (x *MaxBattleContext) ProtoReflect() protoreflect.Message {
+	mi := &file_grpc_raw_receiver_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MaxBattleContext.ProtoReflect.Descriptor instead.
+func (*MaxBattleContext) Descriptor() ([]byte, []int) {
+	return file_grpc_raw_receiver_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *MaxBattleContext) GetStationId() string {
+	if x != nil {
+		return x.StationId
+	}
+	return ""
+}
+
+func (x *MaxBattleContext) GetBreadBattleSeed() int64 {
+	if x != nil {
+		return x.BreadBattleSeed
+	}
+	return 0
+}
+
 type Content struct {
 	state           protoimpl.MessageState `protogen:"open.v1"`
 	ResponsePayload []byte                 `protobuf:"bytes,1,opt,name=response_payload,json=responsePayload,proto3" json:"response_payload,omitempty"`
@@ -359,7 +503,7 @@ type Content struct {
 
 func (x *Content) Reset() {
 	*x = Content{}
-	mi := &file_grpc_raw_receiver_proto_msgTypes[4]
+	mi := &file_grpc_raw_receiver_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -371,7 +515,7 @@ func (x *Content) String() string {
 func (*Content) ProtoMessage() {}
 
 func (x *Content) ProtoReflect() protoreflect.Message {
-	mi := &file_grpc_raw_receiver_proto_msgTypes[4]
+	mi := &file_grpc_raw_receiver_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -384,7 +528,7 @@ func (x *Content) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Content.ProtoReflect.Descriptor instead.
 func (*Content) Descriptor() ([]byte, []int) {
-	return file_grpc_raw_receiver_proto_rawDescGZIP(), []int{4}
+	return file_grpc_raw_receiver_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *Content) GetResponsePayload() []byte {
@@ -418,13 +562,14 @@ func (x *Content) GetHaveAr() bool {
 type RawProtoResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Message       string                 `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	Sequence      uint64                 `protobuf:"varint,2,opt,name=sequence,proto3" json:"sequence,omitempty"` // sequence of the request being acknowledged (StreamRawProto only)
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *RawProtoResponse) Reset() {
 	*x = RawProtoResponse{}
-	mi := &file_grpc_raw_receiver_proto_msgTypes[5]
+	mi := &file_grpc_raw_receiver_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -436,7 +581,7 @@ func (x *RawProtoResponse) String() string {
 func (*RawProtoResponse) ProtoMessage() {}
 
 func (x *RawProtoResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_grpc_raw_receiver_proto_msgTypes[5]
+	mi := &file_grpc_raw_receiver_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -449,7 +594,7 @@ func (x *RawProtoResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RawProtoResponse.ProtoReflect.Descriptor instead.
 func (*RawProtoResponse) Descriptor() ([]byte, []int) {
-	return file_grpc_raw_receiver_proto_rawDescGZIP(), []int{5}
+	return file_grpc_raw_receiver_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *RawProtoResponse) GetMessage() string {
@@ -459,11 +604,18 @@ func (x *RawProtoResponse) GetMessage() string {
 	return ""
 }
 
+func (x *RawProtoResponse) GetSequence() uint64 {
+	if x != nil {
+		return x.Sequence
+	}
+	return 0
+}
+
 var File_grpc_raw_receiver_proto protoreflect.FileDescriptor
 
 const file_grpc_raw_receiver_proto_rawDesc = "" +
 	"\n" +
-	"\x17grpc/raw_receiver.proto\x12\fraw_receiver\"\xee\x03\n" +
+	"\x17grpc/raw_receiver.proto\x12\fraw_receiver\"\x8a\x04\n" +
 	"\x0fRawProtoRequest\x12\x1b\n" +
 	"\tdevice_id\x18\x01 \x01(\tR\bdeviceId\x12\x1a\n" +
 	"\busername\x18\x02 \x01(\tR\busername\x12#\n" +
@@ -478,24 +630,35 @@ const file_grpc_raw_receiver_proto_rawDesc = "" +
 	"\fscan_context\x18\t \x01(\tH\x01R\vscanContext\x88\x01\x01\x12D\n" +
 	"\x0fnebula_contents\x18\n" +
 	" \x03(\v2\x1b.raw_receiver.NebulaContentR\x0enebulaContents\x12E\n" +
-	"\rpush_contents\x18\v \x03(\v2 .raw_receiver.PushGatewayContentR\fpushContentsB\n" +
+	"\rpush_contents\x18\v \x03(\v2 .raw_receiver.PushGatewayContentR\fpushContents\x12\x1a\n" +
+	"\bsequence\x18\f \x01(\x04R\bsequenceB\n" +
 	"\n" +
 	"\b_have_arB\x0f\n" +
 	"\r_scan_context\"Q\n" +
 	"\x12PushGatewayContent\x12!\n" +
 	"\fmessage_type\x18\x01 \x01(\tR\vmessageType\x12\x18\n" +
-	"\apayload\x18\x02 \x01(\fR\apayload\"\xe4\x01\n" +
+	"\apayload\x18\x02 \x01(\fR\apayload\"\xd6\x02\n" +
 	"\rNebulaContent\x12\x1a\n" +
 	"\bendpoint\x18\x01 \x01(\tR\bendpoint\x12)\n" +
 	"\x10response_payload\x18\x02 \x01(\fR\x0fresponsePayload\x12'\n" +
 	"\x0frequest_payload\x18\x03 \x01(\fR\x0erequestPayload\x12;\n" +
-	"\binvasion\x18\x04 \x01(\v2\x1d.raw_receiver.InvasionContextH\x00R\binvasion\x12\x1b\n" +
+	"\binvasion\x18\x04 \x01(\v2\x1d.raw_receiver.InvasionContextH\x00R\binvasion\x12/\n" +
+	"\x04raid\x18\x06 \x01(\v2\x19.raw_receiver.RaidContextH\x00R\x04raid\x12?\n" +
+	"\n" +
+	"max_battle\x18\a \x01(\v2\x1e.raw_receiver.MaxBattleContextH\x00R\tmaxBattle\x12\x1b\n" +
 	"\tbattle_id\x18\x05 \x01(\tR\bbattleIdB\t\n" +
 	"\acontext\"K\n" +
 	"\x0fInvasionContext\x12\x17\n" +
 	"\afort_id\x18\x01 \x01(\tR\x06fortId\x12\x1f\n" +
 	"\vincident_id\x18\x02 \x01(\tR\n" +
-	"incidentId\"\xb8\x01\n" +
+	"incidentId\"C\n" +
+	"\vRaidContext\x12\x17\n" +
+	"\afort_id\x18\x01 \x01(\tR\x06fortId\x12\x1b\n" +
+	"\traid_seed\x18\x02 \x01(\x03R\braidSeed\"]\n" +
+	"\x10MaxBattleContext\x12\x1d\n" +
+	"\n" +
+	"station_id\x18\x01 \x01(\tR\tstationId\x12*\n" +
+	"\x11bread_battle_seed\x18\x02 \x01(\x03R\x0fbreadBattleSeed\"\xb8\x01\n" +
 	"\aContent\x12)\n" +
 	"\x10response_payload\x18\x01 \x01(\fR\x0fresponsePayload\x12,\n" +
 	"\x0frequest_payload\x18\x02 \x01(\fH\x00R\x0erequestPayload\x88\x01\x01\x12\x16\n" +
@@ -503,11 +666,13 @@ const file_grpc_raw_receiver_proto_rawDesc = "" +
 	"\ahave_ar\x18\x04 \x01(\bH\x01R\x06haveAr\x88\x01\x01B\x12\n" +
 	"\x10_request_payloadB\n" +
 	"\n" +
-	"\b_have_ar\",\n" +
+	"\b_have_ar\"H\n" +
 	"\x10RawProtoResponse\x12\x18\n" +
-	"\amessage\x18\x01 \x01(\tR\amessage2]\n" +
+	"\amessage\x18\x01 \x01(\tR\amessage\x12\x1a\n" +
+	"\bsequence\x18\x02 \x01(\x04R\bsequence2\xb4\x01\n" +
 	"\bRawProto\x12Q\n" +
-	"\x0eSubmitRawProto\x12\x1d.raw_receiver.RawProtoRequest\x1a\x1e.raw_receiver.RawProtoResponse\"\x00B\"Z github.com/unownhash/golbat/grpcb\x06proto3"
+	"\x0eSubmitRawProto\x12\x1d.raw_receiver.RawProtoRequest\x1a\x1e.raw_receiver.RawProtoResponse\"\x00\x12U\n" +
+	"\x0eStreamRawProto\x12\x1d.raw_receiver.RawProtoRequest\x1a\x1e.raw_receiver.RawProtoResponse\"\x00(\x010\x01B\"Z github.com/unownhash/golbat/grpcb\x06proto3"
 
 var (
 	file_grpc_raw_receiver_proto_rawDescOnce sync.Once
@@ -521,27 +686,33 @@ func file_grpc_raw_receiver_proto_rawDescGZIP() []byte {
 	return file_grpc_raw_receiver_proto_rawDescData
 }
 
-var file_grpc_raw_receiver_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
+var file_grpc_raw_receiver_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
 var file_grpc_raw_receiver_proto_goTypes = []any{
 	(*RawProtoRequest)(nil),    // 0: raw_receiver.RawProtoRequest
 	(*PushGatewayContent)(nil), // 1: raw_receiver.PushGatewayContent
 	(*NebulaContent)(nil),      // 2: raw_receiver.NebulaContent
 	(*InvasionContext)(nil),    // 3: raw_receiver.InvasionContext
-	(*Content)(nil),            // 4: raw_receiver.Content
-	(*RawProtoResponse)(nil),   // 5: raw_receiver.RawProtoResponse
+	(*RaidContext)(nil),        // 4: raw_receiver.RaidContext
+	(*MaxBattleContext)(nil),   // 5: raw_receiver.MaxBattleContext
+	(*Content)(nil),            // 6: raw_receiver.Content
+	(*RawProtoResponse)(nil),   // 7: raw_receiver.RawProtoResponse
 }
 var file_grpc_raw_receiver_proto_depIdxs = []int32{
-	4, // 0: raw_receiver.RawProtoRequest.contents:type_name -> raw_receiver.Content
+	6, // 0: raw_receiver.RawProtoRequest.contents:type_name -> raw_receiver.Content
 	2, // 1: raw_receiver.RawProtoRequest.nebula_contents:type_name -> raw_receiver.NebulaContent
 	1, // 2: raw_receiver.RawProtoRequest.push_contents:type_name -> raw_receiver.PushGatewayContent
 	3, // 3: raw_receiver.NebulaContent.invasion:type_name -> raw_receiver.InvasionContext
-	0, // 4: raw_receiver.RawProto.SubmitRawProto:input_type -> raw_receiver.RawProtoRequest
-	5, // 5: raw_receiver.RawProto.SubmitRawProto:output_type -> raw_receiver.RawProtoResponse
-	5, // [5:6] is the sub-list for method output_type
-	4, // [4:5] is the sub-list for method input_type
-	4, // [4:4] is the sub-list for extension type_name
-	4, // [4:4] is the sub-list for extension extendee
-	0, // [0:4] is the sub-list for field type_name
+	4, // 4: raw_receiver.NebulaContent.raid:type_name -> raw_receiver.RaidContext
+	5, // 5: raw_receiver.NebulaContent.max_battle:type_name -> raw_receiver.MaxBattleContext
+	0, // 6: raw_receiver.RawProto.SubmitRawProto:input_type -> raw_receiver.RawProtoRequest
+	0, // 7: raw_receiver.RawProto.StreamRawProto:input_type -> raw_receiver.RawProtoRequest
+	7, // 8: raw_receiver.RawProto.SubmitRawProto:output_type -> raw_receiver.RawProtoResponse
+	7, // 9: raw_receiver.RawProto.StreamRawProto:output_type -> raw_receiver.RawProtoResponse
+	8, // [8:10] is the sub-list for method output_type
+	6, // [6:8] is the sub-list for method input_type
+	6, // [6:6] is the sub-list for extension type_name
+	6, // [6:6] is the sub-list for extension extendee
+	0, // [0:6] is the sub-list for field type_name
 }
 
 func init() { file_grpc_raw_receiver_proto_init() }
@@ -552,15 +723,17 @@ func file_grpc_raw_receiver_proto_init() {
 	file_grpc_raw_receiver_proto_msgTypes[0].OneofWrappers = []any{}
 	file_grpc_raw_receiver_proto_msgTypes[2].OneofWrappers = []any{
 		(*NebulaContent_Invasion)(nil),
+		(*NebulaContent_Raid)(nil),
+		(*NebulaContent_MaxBattle)(nil),
 	}
-	file_grpc_raw_receiver_proto_msgTypes[4].OneofWrappers = []any{}
+	file_grpc_raw_receiver_proto_msgTypes[6].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_grpc_raw_receiver_proto_rawDesc), len(file_grpc_raw_receiver_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   6,
+			NumMessages:   8,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -20,6 +20,7 @@ const _ = grpc.SupportPackageIsVersion9
 
 const (
 	RawProto_SubmitRawProto_FullMethodName = "/raw_receiver.RawProto/SubmitRawProto"
+	RawProto_StreamRawProto_FullMethodName = "/raw_receiver.RawProto/StreamRawProto"
 )
 
 // RawProtoClient is the client API for RawProto service.
@@ -29,6 +30,9 @@ const (
 // Interface exported by the server.
 type RawProtoClient interface {
 	SubmitRawProto(ctx context.Context, in *RawProtoRequest, opts ...grpc.CallOption) (*RawProtoResponse, error)
+	// Long-lived stream for high-volume senders: one acknowledgement is sent
+	// back for every request received, in order.
+	StreamRawProto(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[RawProtoRequest, RawProtoResponse], error)
 }
 
 type rawProtoClient struct {
@@ -49,6 +53,19 @@ func (c *rawProtoClient) SubmitRawProto(ctx context.Context, in *RawProtoRequest
 	return out, nil
 }
 
+func (c *rawProtoClient) StreamRawProto(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[RawProtoRequest, RawProtoResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &RawProto_ServiceDesc.Streams[0], RawProto_StreamRawProto_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[RawProtoRequest, RawProtoResponse]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type RawProto_StreamRawProtoClient = grpc.BidiStreamingClient[RawProtoRequest, RawProtoResponse]
+
 // RawProtoServer is the server API for RawProto service.
 // All implementations must embed UnimplementedRawProtoServer
 // for forward compatibility.
@@ -56,6 +73,9 @@ func (c *rawProtoClient) SubmitRawProto(ctx context.Context, in *RawProtoRequest
 // Interface exported by the server.
 type RawProtoServer interface {
 	SubmitRawProto(context.Context, *RawProtoRequest) (*RawProtoResponse, error)
+	// Long-lived stream for high-volume senders: one acknowledgement is sent
+	// back for every request received, in order.
+	StreamRawProto(grpc.BidiStreamingServer[RawProtoRequest, RawProtoResponse]) error
 	mustEmbedUnimplementedRawProtoServer()
 }
 
@@ -69,6 +89,9 @@ type UnimplementedRawProtoServer struct{}
 func (UnimplementedRawProtoServer) SubmitRawProto(context.Context, *RawProtoRequest) (*RawProtoResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method SubmitRawProto not implemented")
 }
+func (UnimplementedRawProtoServer) StreamRawProto(grpc.BidiStreamingServer[RawProtoRequest, RawProtoResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method StreamRawProto not implemented")
+}
 func (UnimplementedRawProtoServer) mustEmbedUnimplementedRawProtoServer() {}
 func (UnimplementedRawProtoServer) testEmbeddedByValue()                  {}
 
@@ -108,6 +131,13 @@ func _RawProto_SubmitRawProto_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _RawProto_StreamRawProto_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(RawProtoServer).StreamRawProto(&grpc.GenericServerStream[RawProtoRequest, RawProtoResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type RawProto_StreamRawProtoServer = grpc.BidiStreamingServer[RawProtoRequest, RawProtoResponse]
+
 // RawProto_ServiceDesc is the grpc.ServiceDesc for RawProto service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -120,6 +150,13 @@ var RawProto_ServiceDesc = grpc.ServiceDesc{
 			Handler:    _RawProto_SubmitRawProto_Handler,
 		},
 	},
-	Streams:  []grpc.StreamDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamRawProto",
+			Handler:       _RawProto_StreamRawProto_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+	},
 	Metadata: "grpc/raw_receiver.proto",
 }
@@ -2,13 +2,20 @@ package main
 
 import (
 	"context"
+	"fmt"
 	"golbat/config"
 	"golbat/decoder"
+	"golbat/geo"
 	pb "golbat/grpc"
+	"golbat/webhooks"
+	"slices"
+	"time"
 
 	log "github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
 	_ "google.golang.org/grpc/encoding/gzip" // Install the gzip compressor
 	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
 )
 
 // server is used to implement helloworld.GreeterServer.
@@ -55,3 +62,90 @@ func (s *grpcPokemonServer) SearchV3(ctx context.Context, in *pb.PokemonScanRequ
 		Total:    int32(total),
 	}, nil
 }
+
+// grpcEventTypes are the webhook types that have a grpc event
+var grpcEventTypes = []webhooks.WebhookType{
+	webhooks.PokemonIV, webhooks.PokemonNoIV, webhooks.GymDetails, webhooks.Raid, webhooks.Pokestop,
+	webhooks.Quest, webhooks.Invasion, webhooks.MaxBattle, webhooks.Station, webhooks.Weather,
+}
+
+// Subscribe streams webhook messages as typed events until the client goes
+// away. Slow clients lose events, reported by an EventsDropped event.
+func (s *grpcPokemonServer) Subscribe(in *pb.SubscribeRequest, stream pb.Pokemon_SubscribeServer) error {
+	// Check for authorisation
+	if config.Config.ApiSecret != "" {
+		md, _ := metadata.FromIncomingContext(stream.Context())
+
+		if auth := md.Get("authorization"); len(auth) == 0 || auth[0] != config.Config.ApiSecret {
+			return status.Error(codes.Unauthenticated, "Incorrect authorisation received")
+		}
+	}
+	if liveStreamSource == nil {
+		return status.Error(codes.Unavailable, "webhooks not started")
+	}
+
+	filter, err := grpcSubscribeFilter(in)
+	if err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	log.Infof("Received subscribe request %+v", in)
+
+	sub := liveStreamSource.Subscribe(filter, liveStreamBuffer)
+	defer liveStreamSource.Unsubscribe(sub)
+
+	droppedCheck := time.NewTicker(liveStreamKeepAlive)
+	defer droppedCheck.Stop()
+
+	for {
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case <-liveStreamsClosed:
+			return nil
+		case <-droppedCheck.C:
+			if dropped := sub.Dropped(); dropped > 0 {
+				err = stream.Send(&pb.Event{
+					SchemaVersion: decoder.GrpcEventSchemaVersion,
+					Event:         &pb.Event_Dropped{Dropped: &pb.EventsDropped{Count: dropped}},
+				})
+			}
+		case event, ok := <-sub.Events:
+			if !ok {
+				return nil
+			}
+			if grpcEvent := decoder.GrpcEventFromWebhook(event.Value); grpcEvent != nil {
+				err = stream.Send(grpcEvent)
+			}
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+func grpcSubscribeFilter(in *pb.SubscribeRequest) (webhooks.StreamFilter, error) {
+	var filter webhooks.StreamFilter
+
+	for _, typeStr := range in.Types {
+		types, err := webhooks.ParseWebhookTypes([]string{typeStr})
+		if err != nil {
+			return filter, err
+		}
+		for _, whType := range types {
+			if !slices.Contains(grpcEventTypes, whType) {
+				return filter, fmt.Errorf("webhook type '%s' has no grpc event", typeStr)
+			}
+		}
+		filter.Types = append(filter.Types, types...)
+	}
+	if len(filter.Types) == 0 {
+		filter.Types = grpcEventTypes
+	}
+	filter.Areas = parseStreamAreas(in.Areas)
+
+	if bbox := in.Bbox; bbox != nil {
+		filter.Bounds = &geo.Bbox{MinLat: bbox.MinLat, MinLon: bbox.MinLon, MaxLat: bbox.MaxLat, MaxLon: bbox.MaxLon}
+	}
+	return filter, nil
+}
@@ -0,0 +1,102 @@
+package main
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"golbat/config"
+	"golbat/decoder"
+	pb "golbat/grpc"
+	"golbat/webhooks"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+func newPokemonTestClient(t *testing.T) pb.PokemonClient {
+	t.Helper()
+	lis := bufconn.Listen(1 << 20)
+	s := grpc.NewServer()
+	pb.RegisterPokemonServer(s, &grpcPokemonServer{})
+	go s.Serve(lis) //nolint:errcheck
+	t.Cleanup(s.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return pb.NewPokemonClient(conn)
+}
+
+func TestSubscribeStreamsTypedEvents(t *testing.T) {
+	prevSecret, prevSource := config.Config.ApiSecret, liveStreamSource
+	config.Config.ApiSecret = "secret"
+	defer func() { config.Config.ApiSecret, liveStreamSource = prevSecret, prevSource }()
+
+	sender, err := webhooks.NewWebhooksSender(config.Config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	liveStreamSource = sender
+
+	client := newPokemonTestClient(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	stream, err := client.Subscribe(ctx, &pb.SubscribeRequest{Types: []string{"wut"}})
+	if err == nil {
+		_, err = stream.Recv()
+	}
+	if status.Code(err) != codes.Unauthenticated {
+		t.Fatalf("got %v, want Unauthenticated", err)
+	}
+
+	ctx = metadata.AppendToOutgoingContext(ctx, "authorization", "secret")
+	for _, types := range [][]string{{"wut"}, {"route"}} {
+		stream, err = client.Subscribe(ctx, &pb.SubscribeRequest{Types: types})
+		if err == nil {
+			_, err = stream.Recv()
+		}
+		if status.Code(err) != codes.InvalidArgument {
+			t.Fatalf("%v: got %v, want InvalidArgument", types, err)
+		}
+	}
+
+	stream, err = client.Subscribe(ctx, &pb.SubscribeRequest{Types: []string{"raid"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// keep publishing until the subscription is in place
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		for {
+			sender.AddMessage(webhooks.Weather, decoder.WeatherWebhook{S2CellId: 1}, nil)
+			sender.AddMessage(webhooks.Raid, decoder.RaidWebhook{GymId: "gym", Level: 5}, nil)
+			select {
+			case <-done:
+				return
+			case <-time.After(10 * time.Millisecond):
+			}
+		}
+	}()
+
+	event, err := stream.Recv()
+	if err != nil {
+		t.Fatal(err)
+	}
+	raid := event.GetRaid()
+	if event.SchemaVersion != decoder.GrpcEventSchemaVersion || raid == nil || raid.GymId != "gym" || raid.Level != 5 {
+		t.Fatalf("got %v, want the raid", event)
+	}
+}
@@ -2,13 +2,23 @@ package main
 
 import (
 	"context"
+	"errors"
+	"io"
 	"time"
 
 	"golbat/config"
 	pb "golbat/grpc"
 
+	"google.golang.org/grpc/codes"
 	_ "google.golang.org/grpc/encoding/gzip" // Install the gzip compressor
 	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	// errDecodeQueueFull tells the controller to back off and resend later.
+	errDecodeQueueFull = status.Error(codes.ResourceExhausted, "Decode queue full")
+	errRawOutsideArea  = status.Error(codes.PermissionDenied, "Location outside the areas permitted for this token")
 )
 
 // server is used to implement helloworld.GreeterServer.
@@ -17,32 +27,94 @@ type grpcRawServer struct {
 }
 
 func (s *grpcRawServer) SubmitRawProto(ctx context.Context, in *pb.RawProtoRequest) (*pb.RawProtoResponse, error) {
-	dataReceivedTimestamp := time.Now().UnixMilli()
-	// Check for authorisation
-	if config.Config.RawBearer != "" {
-		md, _ := metadata.FromIncomingContext(ctx)
+	token, err := grpcRawToken(ctx)
+	if err != nil {
+		countRawRequest("error", err.Error(), rawTokenName(token))
+		return &pb.RawProtoResponse{Message: "Incorrect authorisation received"}, nil
+	}
 
-		if auth := md.Get("authorization"); len(auth) == 0 || auth[0] != config.Config.RawBearer {
-			statsCollector.IncRawRequests("error", "auth")
-			return &pb.RawProtoResponse{Message: "Incorrect authorisation received"}, nil
+	if err := processGrpcRawRequest(in, token); err != nil {
+		return nil, err
+	}
+	return &pb.RawProtoResponse{Message: "Processed"}, nil
+}
+
+// StreamRawProto accepts a continuous stream of requests over one connection.
+// Authorisation is checked once when the stream opens; every request is then
+// processed exactly as SubmitRawProto would and acknowledged in order. If the
+// decode queue is full the stream ends with RESOURCE_EXHAUSTED; requests
+// acknowledged before that were accepted.
+func (s *grpcRawServer) StreamRawProto(stream pb.RawProto_StreamRawProtoServer) error {
+	token, err := grpcRawToken(stream.Context())
+	if err != nil {
+		countRawRequest("error", err.Error(), rawTokenName(token))
+		return status.Error(codes.Unauthenticated, "Incorrect authorisation received")
+	}
+
+	for {
+		in, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		if err := processGrpcRawRequest(in, token); err != nil {
+			return err
+		}
+		if err := stream.Send(&pb.RawProtoResponse{Message: "Processed", Sequence: in.Sequence}); err != nil {
+			return err
 		}
 	}
+}
+
+// grpcRawToken identifies the raw token sent in the authorization metadata.
+func grpcRawToken(ctx context.Context) (*config.RawToken, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	presented := ""
+	if auth := md.Get("authorization"); len(auth) > 0 {
+		presented = auth[0]
+	}
+	return findRawToken(presented)
+}
+
+// processGrpcRawRequest normalises a RawProtoRequest into a RawBatch and
+// queues it for decoding. It returns errRawOutsideArea if token may not submit
+// data from the request's location, or errDecodeQueueFull if the batch could
+// not be queued.
+func processGrpcRawRequest(in *pb.RawProtoRequest, token *config.RawToken) error {
+	dataReceivedTimestamp := time.Now().UnixMilli()
 
 	uuid := in.DeviceId
 	account := in.Username
 	level := int(in.TrainerLevel)
-	scanContext := ""
-	if in.ScanContext != nil {
-		scanContext = *in.ScanContext
-	}
+	tokenName := rawTokenName(token)
+	scanContext := rawTokenScanContext(token, in.GetScanContext())
 
 	if in.Timestamp > 0 {
 		dataReceivedTimestamp = in.Timestamp
 	}
 
 	latTarget, lonTarget := float64(in.LatTarget), float64(in.LonTarget)
+	if !rawTokenAllowsLocation(token, latTarget, lonTarget) {
+		countRawRequest("error", "area", tokenName)
+		return errRawOutsideArea
+	}
+
 	globalHaveAr := in.HaveAr
-	var protoData []ProtoData
+	batch := &RawBatch{
+		Source:      "grpc",
+		ReceivedMs:  time.Now().UnixMilli(),
+		Uuid:        uuid,
+		Account:     account,
+		Level:       level,
+		ScanContext: scanContext,
+		Token:       tokenName,
+		Lat:         latTarget,
+		Lon:         lonTarget,
+		TimestampMs: dataReceivedTimestamp,
+	}
 
 	for _, v := range in.Contents {
 		inboundRawData := ProtoData{
@@ -50,6 +122,7 @@ func (s *grpcRawServer) SubmitRawProto(ctx context.Context, in *pb.RawProtoReque
 			Account:     account,
 			Level:       level,
 			ScanContext: scanContext,
+			Token:       tokenName,
 			Lat:         latTarget,
 			Lon:         lonTarget,
 			Data:        v.ResponsePayload,
@@ -64,10 +137,9 @@ func (s *grpcRawServer) SubmitRawProto(ctx context.Context, in *pb.RawProtoReque
 			TimestampMs: dataReceivedTimestamp,
 		}
 
-		protoData = append(protoData, inboundRawData)
+		batch.Protos = append(batch.Protos, inboundRawData)
 	}
 
-	var nebulaData []NebulaData
 	for _, v := range in.NebulaContents {
 		nd := NebulaData{
 			Endpoint:    v.Endpoint,
@@ -86,40 +158,30 @@ func (s *grpcRawServer) SubmitRawProto(ctx context.Context, in *pb.RawProtoReque
 		if inv := v.GetInvasion(); inv != nil {
 			nd.Invasion = &nebulaInvasionContext{FortId: inv.GetFortId(), IncidentId: inv.GetIncidentId()}
 		}
-		nebulaData = append(nebulaData, nd)
-	}
-
-	// Process each proto in a packet in sequence, but in a go-routine
-	go func() {
-		timeout := 5 * time.Second
-		if config.Config.Tuning.ExtendedTimeout {
-			timeout = 30 * time.Second
+		if raid := v.GetRaid(); raid != nil {
+			nd.Raid = &nebulaRaidContext{FortId: raid.GetFortId(), RaidSeed: raid.GetRaidSeed()}
 		}
-
-		for _, entry := range protoData {
-			// provide independent cancellation contexts for each proto decode
-			ctx, cancel := context.WithTimeout(context.Background(), timeout)
-			decode(ctx, entry.Method, &entry)
-			cancel()
+		if maxBattle := v.GetMaxBattle(); maxBattle != nil {
+			nd.MaxBattle = &nebulaMaxBattleContext{StationId: maxBattle.GetStationId(), BreadBattleSeed: maxBattle.GetBreadBattleSeed()}
 		}
+		batch.Nebula = append(batch.Nebula, nd)
+	}
 
-		for _, entry := range nebulaData {
-			ctx, cancel := context.WithTimeout(context.Background(), timeout)
-			decodeNebula(ctx, entry.Endpoint, &entry)
-			cancel()
-		}
+	for _, v := range in.PushContents {
+		batch.Push = append(batch.Push, PushGatewayData{MessageType: v.MessageType, Payload: v.Payload})
+	}
 
-		for _, v := range in.PushContents {
-			ctx, cancel := context.WithTimeout(context.Background(), timeout)
-			decodePushGateway(ctx, v.MessageType, v.Payload)
-			cancel()
-		}
-	}()
+	// Process each proto in a packet in sequence on a decode worker
+	if !rawDecodeQueue.submit(batch) {
+		countRawRequest("error", "queue_full", tokenName)
+		return errDecodeQueueFull
+	}
+	captureRawBatch(batch)
 
 	if latTarget != 0 && lonTarget != 0 && uuid != "" {
 		UpdateDeviceLocation(uuid, latTarget, lonTarget, scanContext)
 	}
 
-	statsCollector.IncRawRequests("ok", "")
-	return &pb.RawProtoResponse{Message: "Processed"}, nil
+	countRawRequest("ok", "", tokenName)
+	return nil
 }
@@ -0,0 +1,126 @@
+package main
+
+import (
+	"context"
+	"net"
+	"sync"
+	"testing"
+
+	"golbat/config"
+	pb "golbat/grpc"
+	"golbat/stats_collector"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+func newRawProtoTestClient(t *testing.T, queue *decodeQueue) pb.RawProtoClient {
+	t.Helper()
+	statsCollector = stats_collector.NewNoopStatsCollector()
+	rawDecodeQueue = queue
+
+	lis := bufconn.Listen(1 << 20)
+	s := grpc.NewServer()
+	pb.RegisterRawProtoServer(s, &grpcRawServer{})
+	go s.Serve(lis) //nolint:errcheck
+	t.Cleanup(s.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return pb.NewRawProtoClient(conn)
+}
+
+// TestStreamRawProto_AcknowledgesInOrder sends requests without contents (so
+// nothing reaches the DB-backed decoders) and checks every one is acknowledged
+// with its sequence, in order.
+func TestStreamRawProto_AcknowledgesInOrder(t *testing.T) {
+	prev := config.Config.RawBearer
+	config.Config.RawBearer = "secret"
+	defer func() { config.Config.RawBearer = prev }()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	var wg sync.WaitGroup
+	defer wg.Wait()
+	defer cancel()
+	queue := newDecodeQueue(1, 10)
+	queue.start(ctx, &wg)
+
+	client := newRawProtoTestClient(t, queue)
+	ctx = metadata.AppendToOutgoingContext(ctx, "authorization", "secret")
+	stream, err := client.StreamRawProto(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for seq := uint64(1); seq <= 3; seq++ {
+		if err := stream.Send(&pb.RawProtoRequest{DeviceId: "dev", TrainerLevel: 40, Sequence: seq}); err != nil {
+			t.Fatal(err)
+		}
+		ack, err := stream.Recv()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if ack.Sequence != seq || ack.Message != "Processed" {
+			t.Errorf("ack = {%d %q}, want {%d \"Processed\"}", ack.Sequence, ack.Message, seq)
+		}
+	}
+	if err := stream.CloseSend(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestStreamRawProto_RejectsBadBearer(t *testing.T) {
+	prev := config.Config.RawBearer
+	config.Config.RawBearer = "secret"
+	defer func() { config.Config.RawBearer = prev }()
+
+	client := newRawProtoTestClient(t, newDecodeQueue(1, 10))
+	ctx := metadata.AppendToOutgoingContext(context.Background(), "authorization", "wrong")
+	stream, err := client.StreamRawProto(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = stream.Recv()
+	if status.Code(err) != codes.Unauthenticated {
+		t.Errorf("got %v, want Unauthenticated", err)
+	}
+}
+
+// TestStreamRawProto_QueueFull uses a queue with no running workers, so the
+// second request cannot be queued and the stream ends with RESOURCE_EXHAUSTED.
+func TestStreamRawProto_QueueFull(t *testing.T) {
+	prev := config.Config.RawBearer
+	config.Config.RawBearer = "secret"
+	defer func() { config.Config.RawBearer = prev }()
+
+	client := newRawProtoTestClient(t, newDecodeQueue(1, 1))
+	ctx := metadata.AppendToOutgoingContext(context.Background(), "authorization", "secret")
+	stream, err := client.StreamRawProto(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := stream.Send(&pb.RawProtoRequest{Sequence: 1}); err != nil {
+		t.Fatal(err)
+	}
+	if ack, err := stream.Recv(); err != nil || ack.Sequence != 1 {
+		t.Fatalf("first request: ack %v, err %v", ack, err)
+	}
+
+	if err := stream.Send(&pb.RawProtoRequest{Sequence: 2}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := stream.Recv(); status.Code(err) != codes.ResourceExhausted {
+		t.Errorf("got %v, want ResourceExhausted", err)
+	}
+}
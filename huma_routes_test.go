@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"net/http"
 	"strconv"
 	"strings"
@@ -503,3 +504,55 @@ func TestTier4OperationalEndpoints(t *testing.T) {
 		}
 	})
 }
+
+// TestIngestHealthEndpoints exercises the per-device and per-account ingest
+// health endpoints over the HTTP pipeline without a database.
+func TestIngestHealthEndpoints(t *testing.T) {
+	prev := config.Config.ApiSecret
+	config.Config.ApiSecret = ""
+	defer func() { config.Config.ApiSecret = prev }()
+
+	initIngestHealthForTest(t)
+	withIngestHealth(context.Background(), &ProtoData{Uuid: "device-1", Account: "acc-1"}, "GET_MAP_OBJECTS")
+
+	_, api := humatest.New(t, newHumaConfig("test"))
+	api.UseMiddleware(golbatSecretMiddleware(api))
+	registerTier4Routes(api)
+
+	t.Run("devices/health lists tracked devices", func(t *testing.T) {
+		resp := api.Get("/api/devices/health")
+		if resp.Code != http.StatusOK {
+			t.Fatalf("got %d, want 200; body=%s", resp.Code, resp.Body.String())
+		}
+		var m struct {
+			Devices []ApiIngestHealth `json:"devices"`
+		}
+		if err := gojson.Unmarshal(resp.Body.Bytes(), &m); err != nil {
+			t.Fatalf("body is not a JSON object: %v; body=%s", err, resp.Body.String())
+		}
+		if len(m.Devices) != 1 || m.Devices[0].Id != "device-1" {
+			t.Errorf("devices = %+v, want device-1", m.Devices)
+		}
+	})
+
+	t.Run("devices/accounts/{account} returns the account", func(t *testing.T) {
+		resp := api.Get("/api/devices/accounts/acc-1")
+		if resp.Code != http.StatusOK {
+			t.Fatalf("got %d, want 200; body=%s", resp.Code, resp.Body.String())
+		}
+		var m ApiIngestHealth
+		if err := gojson.Unmarshal(resp.Body.Bytes(), &m); err != nil {
+			t.Fatalf("body is not a JSON object: %v; body=%s", err, resp.Body.String())
+		}
+		if m.Methods["GET_MAP_OBJECTS"] != 1 || m.LastDevice != "device-1" {
+			t.Errorf("account = %+v", m)
+		}
+	})
+
+	t.Run("devices/health/{uuid} unknown returns 404", func(t *testing.T) {
+		resp := api.Get("/api/devices/health/unknown")
+		if resp.Code != http.StatusNotFound {
+			t.Errorf("got %d, want 404; body=%s", resp.Code, resp.Body.String())
+		}
+	})
+}
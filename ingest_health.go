@@ -0,0 +1,282 @@
+package main
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"golbat/config"
+
+	"github.com/jellydator/ttlcache/v3"
+)
+
+const (
+	// ingestStaleAfter is how long a device or account can go without
+	// submitting anything before it is reported as stale.
+	ingestStaleAfter = 10 * time.Minute
+	// ingestEmptyGmoStreak is the number of consecutive empty GMOs after which
+	// an account is reported as returning empty map data.
+	ingestEmptyGmoStreak = 5
+)
+
+// ingestHealth is what one device or account has been submitting.
+type ingestHealth struct {
+	mu             sync.Mutex
+	firstSeen      int64
+	lastSeen       int64
+	lastPeer       string // the account for a device, the device for an account
+	methods        map[string]int64
+	decodeErrors   int64
+	lowLevel       int64
+	lastGmo        int64
+	emptyGmoStreak int64
+	skippedCells   int64
+}
+
+type ApiIngestHealth struct {
+	Id             string           `json:"id"`
+	FirstSeen      int64            `json:"first_seen"`
+	LastSeen       int64            `json:"last_seen"`
+	LastAccount    string           `json:"last_account,omitempty"`
+	LastDevice     string           `json:"last_device,omitempty"`
+	Methods        map[string]int64 `json:"methods"`
+	DecodeErrors   int64            `json:"decode_errors"`
+	LowLevel       int64            `json:"low_level_rejections"`
+	LastGmo        int64            `json:"last_gmo"`
+	EmptyGmoStreak int64            `json:"empty_gmo_streak"`
+	SkippedCells   int64            `json:"skipped_cells"`
+}
+
+var (
+	deviceHealth  *ttlcache.Cache[string, *ingestHealth]
+	accountHealth *ttlcache.Cache[string, *ingestHealth]
+)
+
+// InitIngestHealth starts tracking per-device and per-account ingest health.
+// Entries expire after cleanup.device_hours without a submission.
+func InitIngestHealth(ctx context.Context) {
+	ttl := time.Hour * time.Duration(config.Config.Cleanup.DeviceHours)
+	deviceHealth = ttlcache.New[string, *ingestHealth](ttlcache.WithTTL[string, *ingestHealth](ttl))
+	accountHealth = ttlcache.New[string, *ingestHealth](ttlcache.WithTTL[string, *ingestHealth](ttl))
+	go deviceHealth.Start()
+	go accountHealth.Start()
+
+	go func() {
+		ticker := time.NewTicker(time.Minute)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				deviceHealth.Stop()
+				accountHealth.Stop()
+				return
+			case <-ticker.C:
+				updateIngestHealthStats()
+			}
+		}
+	}()
+}
+
+// ingestHealthPair is the device and account a proto was submitted by; either
+// may be nil if the submission did not identify it.
+type ingestHealthPair struct {
+	device  *ingestHealth
+	account *ingestHealth
+}
+
+type ingestHealthKey struct{}
+
+// withIngestHealth looks up (or starts) the health entries for the device and
+// account protoData came from, records the proto against them, and returns a
+// context carrying them for the decoders to report errors to.
+func withIngestHealth(ctx context.Context, protoData *ProtoData, method string) context.Context {
+	if deviceHealth == nil {
+		return ctx
+	}
+
+	now := time.Now().Unix()
+	pair := &ingestHealthPair{}
+	if protoData.Uuid != "" {
+		pair.device = getIngestHealth(deviceHealth, protoData.Uuid, now)
+		pair.device.recordProto(method, protoData.Account, now)
+	}
+	if protoData.Account != "" {
+		pair.account = getIngestHealth(accountHealth, protoData.Account, now)
+		pair.account.recordProto(method, protoData.Uuid, now)
+	}
+	return context.WithValue(ctx, ingestHealthKey{}, pair)
+}
+
+func getIngestHealth(cache *ttlcache.Cache[string, *ingestHealth], key string, now int64) *ingestHealth {
+	item, _ := cache.GetOrSetFunc(key, func() *ingestHealth {
+		return &ingestHealth{firstSeen: now, methods: make(map[string]int64)}
+	})
+	return item.Value()
+}
+
+func ingestHealthFromContext(ctx context.Context) *ingestHealthPair {
+	pair, _ := ctx.Value(ingestHealthKey{}).(*ingestHealthPair)
+	return pair
+}
+
+func (pair *ingestHealthPair) each(fn func(h *ingestHealth)) {
+	if pair == nil {
+		return
+	}
+	for _, h := range []*ingestHealth{pair.device, pair.account} {
+		if h != nil {
+			h.mu.Lock()
+			fn(h)
+			h.mu.Unlock()
+		}
+	}
+}
+
+// noteDecodeError records that a proto from the submitting device and account
+// could not be parsed or reported a non-success status.
+func noteDecodeError(ctx context.Context) {
+	ingestHealthFromContext(ctx).each(func(h *ingestHealth) {
+		h.decodeErrors++
+	})
+}
+
+// noteLowLevel records that a proto was rejected because the account is below
+// level 30.
+func noteLowLevel(ctx context.Context) {
+	ingestHealthFromContext(ctx).each(func(h *ingestHealth) {
+		h.lowLevel++
+	})
+}
+
+// noteGmo records a successfully decoded GMO. Empty GMOs (no populated map
+// cells) extend the empty streak; anything else resets it.
+func noteGmo(ctx context.Context, empty bool) {
+	now := time.Now().Unix()
+	ingestHealthFromContext(ctx).each(func(h *ingestHealth) {
+		h.lastGmo = now
+		if empty {
+			h.emptyGmoStreak++
+		} else {
+			h.emptyGmoStreak = 0
+		}
+	})
+}
+
+// noteGmoCellsSkipped records GMO cells that were skipped because another
+// device had just sent the same content.
+func noteGmoCellsSkipped(ctx context.Context, cells int) {
+	ingestHealthFromContext(ctx).each(func(h *ingestHealth) {
+		h.skippedCells += int64(cells)
+	})
+}
+
+func (h *ingestHealth) recordProto(method, peer string, now int64) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.lastSeen = now
+	if peer != "" {
+		h.lastPeer = peer
+	}
+	h.methods[method]++
+}
+
+func (h *ingestHealth) snapshot(id string, isDevice bool) ApiIngestHealth {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	methods := make(map[string]int64, len(h.methods))
+	for method, count := range h.methods {
+		methods[method] = count
+	}
+	snap := ApiIngestHealth{
+		Id:             id,
+		FirstSeen:      h.firstSeen,
+		LastSeen:       h.lastSeen,
+		Methods:        methods,
+		DecodeErrors:   h.decodeErrors,
+		LowLevel:       h.lowLevel,
+		LastGmo:        h.lastGmo,
+		EmptyGmoStreak: h.emptyGmoStreak,
+		SkippedCells:   h.skippedCells,
+	}
+	if isDevice {
+		snap.LastAccount = h.lastPeer
+	} else {
+		snap.LastDevice = h.lastPeer
+	}
+	return snap
+}
+
+func snapshotIngestHealth(cache *ttlcache.Cache[string, *ingestHealth], isDevice bool) []ApiIngestHealth {
+	result := []ApiIngestHealth{}
+	if cache == nil {
+		return result
+	}
+	for key, item := range cache.Items() {
+		result = append(result, item.Value().snapshot(key, isDevice))
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Id < result[j].Id })
+	return result
+}
+
+// GetDeviceHealth returns the ingest health of every tracked device.
+func GetDeviceHealth() []ApiIngestHealth {
+	return snapshotIngestHealth(deviceHealth, true)
+}
+
+// GetAccountHealth returns the ingest health of every tracked account.
+func GetAccountHealth() []ApiIngestHealth {
+	return snapshotIngestHealth(accountHealth, false)
+}
+
+// GetOneDeviceHealth returns the ingest health of one device, or nil if it is
+// not tracked.
+func GetOneDeviceHealth(uuid string) *ApiIngestHealth {
+	return getOneIngestHealth(deviceHealth, uuid, true)
+}
+
+// GetOneAccountHealth returns the ingest health of one account, or nil if it
+// is not tracked.
+func GetOneAccountHealth(account string) *ApiIngestHealth {
+	return getOneIngestHealth(accountHealth, account, false)
+}
+
+func getOneIngestHealth(cache *ttlcache.Cache[string, *ingestHealth], key string, isDevice bool) *ApiIngestHealth {
+	if cache == nil {
+		return nil
+	}
+	item := cache.Get(key, ttlcache.WithDisableTouchOnHit[string, *ingestHealth]())
+	if item == nil {
+		return nil
+	}
+	snap := item.Value().snapshot(key, isDevice)
+	return &snap
+}
+
+// ingestHealthState classifies an entry for the Prometheus gauges.
+func ingestHealthState(h ApiIngestHealth, now time.Time) string {
+	switch {
+	case now.Sub(time.Unix(h.LastSeen, 0)) > ingestStaleAfter:
+		return "stale"
+	case h.EmptyGmoStreak >= ingestEmptyGmoStreak:
+		return "empty_gmo"
+	default:
+		return "active"
+	}
+}
+
+func updateIngestHealthStats() {
+	now := time.Now()
+	for kind, entries := range map[string][]ApiIngestHealth{
+		"device":  GetDeviceHealth(),
+		"account": GetAccountHealth(),
+	} {
+		counts := map[string]int{"active": 0, "stale": 0, "empty_gmo": 0}
+		for _, entry := range entries {
+			counts[ingestHealthState(entry, now)]++
+		}
+		for state, count := range counts {
+			statsCollector.SetIngestHealth(kind, state, float64(count))
+		}
+	}
+}
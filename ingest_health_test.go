@@ -0,0 +1,93 @@
+package main
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"golbat/config"
+)
+
+func initIngestHealthForTest(t *testing.T) {
+	t.Helper()
+	if config.Config.Cleanup.DeviceHours == 0 {
+		config.Config.Cleanup.DeviceHours = 1
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	InitIngestHealth(ctx)
+}
+
+func TestIngestHealthTracksDeviceAndAccount(t *testing.T) {
+	initIngestHealthForTest(t)
+
+	data := &ProtoData{Uuid: "device-1", Account: "acc-1"}
+	ctx := withIngestHealth(context.Background(), data, "GET_MAP_OBJECTS")
+	noteGmo(ctx, true)
+	ctx = withIngestHealth(context.Background(), data, "GET_MAP_OBJECTS")
+	noteGmo(ctx, true)
+	ctx = withIngestHealth(context.Background(), data, "ENCOUNTER")
+	noteDecodeError(ctx)
+	ctx = withIngestHealth(context.Background(), &ProtoData{Uuid: "device-1"}, "FORT_DETAILS")
+	noteLowLevel(ctx)
+
+	device := GetOneDeviceHealth("device-1")
+	if device == nil {
+		t.Fatal("device not tracked")
+	}
+	want := map[string]int64{"GET_MAP_OBJECTS": 2, "ENCOUNTER": 1, "FORT_DETAILS": 1}
+	for method, count := range want {
+		if device.Methods[method] != count {
+			t.Errorf("device %s count = %d, want %d", method, device.Methods[method], count)
+		}
+	}
+	if device.EmptyGmoStreak != 2 || device.DecodeErrors != 1 || device.LowLevel != 1 {
+		t.Errorf("device = %+v, want streak 2, 1 decode error, 1 low level", device)
+	}
+	if device.LastAccount != "acc-1" || device.LastGmo == 0 {
+		t.Errorf("device = %+v, want last account acc-1 and a GMO time", device)
+	}
+
+	account := GetOneAccountHealth("acc-1")
+	if account == nil {
+		t.Fatal("account not tracked")
+	}
+	if account.LowLevel != 0 || account.DecodeErrors != 1 || account.LastDevice != "device-1" {
+		t.Errorf("account = %+v, want 0 low level, 1 decode error, last device device-1", account)
+	}
+
+	// A GMO with data ends the streak.
+	noteGmo(withIngestHealth(context.Background(), data, "GET_MAP_OBJECTS"), false)
+	if streak := GetOneAccountHealth("acc-1").EmptyGmoStreak; streak != 0 {
+		t.Errorf("empty streak after a populated GMO = %d, want 0", streak)
+	}
+}
+
+func TestIngestHealthState(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		health ApiIngestHealth
+		want   string
+	}{
+		{ApiIngestHealth{LastSeen: now.Unix()}, "active"},
+		{ApiIngestHealth{LastSeen: now.Add(-time.Hour).Unix()}, "stale"},
+		{ApiIngestHealth{LastSeen: now.Unix(), EmptyGmoStreak: ingestEmptyGmoStreak}, "empty_gmo"},
+	}
+	for _, tt := range tests {
+		if got := ingestHealthState(tt.health, now); got != tt.want {
+			t.Errorf("ingestHealthState(%+v) = %s, want %s", tt.health, got, tt.want)
+		}
+	}
+}
+
+func TestIngestHealthUntrackedIsNoop(t *testing.T) {
+	initIngestHealthForTest(t)
+
+	// Decoders called without a tracked context must not panic.
+	noteDecodeError(context.Background())
+	noteGmo(context.Background(), true)
+
+	if GetOneDeviceHealth("unknown") != nil {
+		t.Error("unknown device reported as tracked")
+	}
+}
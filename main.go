@@ -2,6 +2,8 @@ package main
 
 import (
 	"context"
+	"errors"
+	"flag"
 	"fmt"
 	"net"
 	"net/http"
@@ -20,10 +22,6 @@ import (
 	"golbat/webhooks"
 
 	"github.com/gin-gonic/gin"
-	"github.com/go-sql-driver/mysql"
-	"github.com/golang-migrate/migrate/v4"
-	_ "github.com/golang-migrate/migrate/v4/database/mysql"
-	_ "github.com/golang-migrate/migrate/v4/source/file"
 	grpcprom "github.com/grpc-ecosystem/go-grpc-middleware/providers/prometheus"
 	"github.com/jmoiron/sqlx"
 	log "github.com/sirupsen/logrus"
@@ -36,6 +34,10 @@ var dbDetails db2.DbDetails
 var statsCollector stats_collector.StatsCollector
 
 func main() {
+	replayPath := flag.String("replay", "", "replay raw captures from this file, directory or glob, then exit")
+	replaySpeed := flag.Float64("replay-speed", 0, "replay pace relative to the original capture (0 = as fast as possible)")
+	flag.Parse()
+
 	var wg sync.WaitGroup
 	ctx, cancelFn := context.WithCancel(context.Background())
 	defer cancelFn()
@@ -78,56 +80,22 @@ func main() {
 		log.Fatalf("failed to setup webhooks sender: %s", err)
 	}
 	decoder.SetWebhooksSender(webhooksSender)
-
-	// Capture connection properties.
-	mysqlConfig := mysql.Config{
-		User:                 cfg.Database.User,     //"root",     //os.Getenv("DBUSER"),
-		Passwd:               cfg.Database.Password, //"transmit", //os.Getenv("DBPASS"),
-		Net:                  "tcp",
-		Addr:                 cfg.Database.Addr,
-		DBName:               cfg.Database.Db,
-		AllowNativePasswords: true,
-	}
-
-	dbConnectionString := mysqlConfig.FormatDSN()
-	driver := "mysql"
-
-	log.Infof("Starting migration")
-
-	m, err := migrate.New(
-		"file://sql",
-		driver+"://"+dbConnectionString+"&multiStatements=true")
-	if err != nil {
-		log.Fatal(err)
-		return
-	}
-	err = m.Up()
-	if err != nil && err != migrate.ErrNoChange {
-		log.Fatal(err)
-		return
-	}
-
-	log.Infof("Opening database for processing, max pool = %d", cfg.Database.MaxPool)
-
-	// Get a database handle.
-
-	db, err = sqlx.Open(driver, dbConnectionString)
-	if err != nil {
-		log.Fatal(err)
-		return
-	}
-
-	db.SetConnMaxLifetime(time.Minute * 3) // Recommended by go mysql driver
-	db.SetMaxOpenConns(cfg.Database.MaxPool)
-	db.SetMaxIdleConns(10)
-	db.SetConnMaxIdleTime(time.Minute)
-
-	pingErr := db.Ping()
-	if pingErr != nil {
-		log.Fatal(pingErr)
-		return
+	webhookStatusSource = webhooksSender
+	webhookReloader = webhooksSender
+	liveStreamSource = webhooksSender
+
+	db = openDatabase("general", cfg.Database)
+	generalReplicas := openReplicas("general", cfg.Database)
+	pokemonDb, statsDb := db, db
+	if cfg.PokemonDatabase.IsSet() {
+		pokemonDb = openDatabase("pokemon", cfg.PokemonDatabase)
+		if len(cfg.PokemonDatabase.Replicas) > 0 {
+			log.Warnf("pokemon_database replicas are not used, only those of the general database")
+		}
+		if cfg.PokemonDatabase.Stats {
+			statsDb = pokemonDb
+		}
 	}
-	log.Infoln("Connected to database")
 
 	decoder.SetKojiUrl(cfg.Koji.Url, cfg.Koji.BearerToken)
 
@@ -163,9 +131,10 @@ func main() {
 	//	}
 	//} else {
 	dbDetails = db2.DbDetails{
-		PokemonDb:       db,
+		PokemonDb:       pokemonDb,
 		UsePokemonCache: true,
 		GeneralDb:       db,
+		GeneralReplicas: generalReplicas,
 	}
 	//}
 
@@ -183,6 +152,7 @@ func main() {
 	// tell the decoder the stats collector to use
 	decoder.SetStatsCollector(statsCollector)
 	db2.SetStatsCollector(statsCollector)
+	webhooks.SetStatsCollector(statsCollector)
 
 	// collect live stats when prometheus and liveStats are enabled
 	if cfg.Prometheus.Enabled && cfg.Prometheus.LiveStats {
@@ -212,6 +182,11 @@ func main() {
 	decoder.LoadStatsGeofences()
 	decoder.InitWriteBehindQueue(ctx, dbDetails)
 	InitDeviceCache()
+	InitIngestHealth(ctx)
+	InitGmoDedup(ctx)
+	InitRawCapture()
+	InitDeadLetter()
+	StartDecodeQueue(ctx, &wg)
 
 	wg.Add(1)
 	go func() {
@@ -224,17 +199,27 @@ func main() {
 		}
 	}()
 
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		watchForReload(ctx)
+	}()
+
 	log.Infoln("Golbat started")
 
-	StartDbUsageStatsLogger(db)
-	decoder.StartStatsWriter(db)
+	StartDbUsageStatsLogger("DB", db)
+	if pokemonDb != db {
+		StartDbUsageStatsLogger("Pokemon DB", pokemonDb)
+	}
+	db2.StartReplicaMonitor("general", generalReplicas, cfg.Database.MaxLag)
+	decoder.StartStatsWriter(statsDb)
 
 	if cfg.Tuning.ExtendedTimeout {
 		log.Info("Extended timeout enabled")
 	}
 
 	if cfg.Cleanup.Pokemon && (!cfg.PokemonMemoryOnly || cfg.PreserveInMemoryPokemon) {
-		StartDatabaseArchiver(db)
+		StartDatabaseArchiver(pokemonDb)
 	}
 
 	if cfg.Cleanup.Incidents {
@@ -254,7 +239,7 @@ func main() {
 	}
 
 	if cfg.Cleanup.Stats {
-		StartStatsExpiry(db)
+		StartStatsExpiry(statsDb)
 	}
 
 	// init fort tracker for memory-based fort cleanup
@@ -332,6 +317,8 @@ func main() {
 	r.POST("/raw", Raw)
 	r.GET("/health", GetHealth)
 	r.GET("/version", GetVersion)
+	// the live stream checks the api secret itself, as browsers cannot send it as a header
+	r.GET("/api/stream", LiveStream)
 
 	apiGroup := r.Group("/api", AuthRequired())
 	apiGroup.GET("/health", GetHealth)
@@ -374,6 +361,8 @@ func main() {
 	registerPokemonReadRoutes(humaAPI)
 	registerTier3Routes(humaAPI)
 	registerTier4Routes(humaAPI)
+	registerDeadLetterRoutes(humaAPI)
+	registerWebhookStatusRoutes(humaAPI)
 
 	srv := &http.Server{
 		Addr:    fmt.Sprintf(":%d", cfg.Port),
@@ -383,6 +372,7 @@ func main() {
 		// open until the client closes it otherwise).
 		IdleTimeout: 60 * time.Second,
 	}
+	srv.RegisterOnShutdown(closeLiveStreams)
 
 	// Start the server in a goroutine, as it will block until told to shutdown.
 	wg.Add(1)
@@ -395,6 +385,19 @@ func main() {
 		}
 	}()
 
+	if *replayPath != "" {
+		wg.Add(1)
+		go func() {
+			defer cancelFn()
+			defer wg.Done()
+
+			err := ReplayRawCapture(ctx, *replayPath, *replaySpeed)
+			if err != nil && !errors.Is(err, context.Canceled) {
+				log.Errorf("Replay failed: %s", err)
+			}
+		}()
+	}
+
 	// wait for shutdown to be signaled in some way. This can be from a failure
 	// to start the webhook sender, failure to start the http server, and/or
 	// watchForShutdown() saying it is time to shutdown. (watchForShutdown() on unix
@@ -421,6 +424,9 @@ func main() {
 	log.Info("http server is shutdown, waiting for other go routines to exit...")
 	wg.Wait()
 
+	CloseRawCapture()
+	CloseDeadLetter()
+
 	log.Info("go routines have exited, flushing write-behind queue...")
 	decoder.FlushWriteBehindQueue()
 
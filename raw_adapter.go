@@ -0,0 +1,74 @@
+package main
+
+import (
+	"errors"
+	"net/http"
+)
+
+// errRawUndecodable is returned by an adapter when a body it claimed cannot
+// be decoded.
+var errRawUndecodable = errors.New("undecodable raw body")
+
+// rawAdapter normalises the /raw body of one MITM provider. Each provider
+// seems to be just different enough that this is easier to follow, and to
+// test, one provider at a time.
+type rawAdapter interface {
+	// Name identifies the adapter in logs.
+	Name() string
+	// Detect reports whether the request was sent by this provider.
+	Detect(r *http.Request, body []byte) bool
+	// Parse fills batch from the body. batch arrives with the defaults (level
+	// 30, received timestamp) that the adapter overrides if the provider sends
+	// them. Device details set on the batch are copied to each item
+	// afterwards, so adapters only set the fields specific to an item.
+	Parse(r *http.Request, body []byte, batch *RawBatch) error
+}
+
+// rawAdapters are tried in order; the first to detect a request parses it.
+// The generic JSON adapter detects everything, so it is registered last.
+var rawAdapters []rawAdapter
+
+func registerRawAdapter(adapter rawAdapter) {
+	rawAdapters = append(rawAdapters, adapter)
+}
+
+func init() {
+	registerRawAdapter(pogodroidAdapter{})
+	registerRawAdapter(jsonAdapter{})
+}
+
+// findRawAdapter returns the adapter for a request, or nil if none accepts it.
+func findRawAdapter(r *http.Request, body []byte) rawAdapter {
+	for _, adapter := range rawAdapters {
+		if adapter.Detect(r, body) {
+			return adapter
+		}
+	}
+	return nil
+}
+
+// fillItemDetails copies the batch's device details onto each proto and
+// nebula item.
+func (batch *RawBatch) fillItemDetails() {
+	for i := range batch.Protos {
+		p := &batch.Protos[i]
+		p.Account = batch.Account
+		p.Level = batch.Level
+		p.Uuid = batch.Uuid
+		p.ScanContext = batch.ScanContext
+		p.Token = batch.Token
+		p.Lat = batch.Lat
+		p.Lon = batch.Lon
+		p.TimestampMs = batch.TimestampMs
+	}
+	for i := range batch.Nebula {
+		n := &batch.Nebula[i]
+		n.Account = batch.Account
+		n.Level = batch.Level
+		n.Uuid = batch.Uuid
+		n.ScanContext = batch.ScanContext
+		n.Lat = batch.Lat
+		n.Lon = batch.Lon
+		n.TimestampMs = batch.TimestampMs
+	}
+}
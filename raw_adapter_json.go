@@ -0,0 +1,151 @@
+package main
+
+import (
+	b64 "encoding/base64"
+	"encoding/json"
+	"net/http"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// jsonAdapter accepts the generic JSON object most MITM tools send, with the
+// device details at the top level and protos under contents. Field names that
+// differ between tools are tried in turn.
+type jsonAdapter struct{}
+
+func (jsonAdapter) Name() string {
+	return "json"
+}
+
+func (jsonAdapter) Detect(r *http.Request, body []byte) bool {
+	return true
+}
+
+func (jsonAdapter) Parse(r *http.Request, body []byte, batch *RawBatch) error {
+	var raw map[string]interface{}
+	if err := json.Unmarshal(body, &raw); err != nil {
+		return errRawUndecodable
+	}
+
+	var globalHaveAr *bool
+	if v, ok := raw["have_ar"].(bool); ok {
+		globalHaveAr = &v
+	}
+	batch.Uuid = getString(raw, "uuid")
+	batch.Account = getString(raw, "username")
+	if v, ok := raw["trainerlvl"].(float64); ok {
+		batch.Level = int(v)
+	}
+	if v := getString(raw, "scan_context"); v != "" {
+		batch.ScanContext = v
+	}
+	batch.Lat, _ = raw["lat_target"].(float64)
+	batch.Lon, _ = raw["lon_target"].(float64)
+	if ts, _ := raw["timestamp_ms"].(int64); ts > 0 {
+		batch.TimestampMs = ts
+	}
+
+	if rawNebula, ok := raw["nebula_contents"].([]any); ok {
+		for _, item := range rawNebula {
+			m, ok := item.(map[string]any)
+			if !ok {
+				continue
+			}
+			nd := NebulaData{
+				Endpoint: getString(m, "endpoint"),
+				BattleId: getString(m, "battle_id"),
+			}
+			nd.Data, _ = b64.StdEncoding.DecodeString(getString(m, "payload"))
+			nd.Request, _ = b64.StdEncoding.DecodeString(getString(m, "request"))
+			// context: { "invasion": { "fort_id": "...", "incident_id": "..." } }
+			//      or { "raid": { "fort_id": "...", "raid_seed": "..." } }
+			//      or { "max_battle": { "station_id": "...", "bread_battle_seed": "..." } }
+			if ctxObj, ok := m["context"].(map[string]any); ok {
+				if inv, ok := ctxObj["invasion"].(map[string]any); ok {
+					nd.Invasion = &nebulaInvasionContext{
+						FortId:     getString(inv, "fort_id"),
+						IncidentId: getString(inv, "incident_id"),
+					}
+				}
+				if raid, ok := ctxObj["raid"].(map[string]any); ok {
+					nd.Raid = &nebulaRaidContext{
+						FortId:   getString(raid, "fort_id"),
+						RaidSeed: getInt64(raid, "raid_seed"),
+					}
+				}
+				if maxBattle, ok := ctxObj["max_battle"].(map[string]any); ok {
+					nd.MaxBattle = &nebulaMaxBattleContext{
+						StationId:       getString(maxBattle, "station_id"),
+						BreadBattleSeed: getInt64(maxBattle, "bread_battle_seed"),
+					}
+				}
+			}
+			batch.Nebula = append(batch.Nebula, nd)
+		}
+	}
+
+	if rawPush, ok := raw["push_contents"].([]any); ok {
+		for _, item := range rawPush {
+			m, ok := item.(map[string]any)
+			if !ok {
+				continue
+			}
+			msgType := getString(m, "message_type")
+			payload, _ := b64.StdEncoding.DecodeString(getString(m, "payload"))
+			if msgType == "" || payload == nil {
+				continue
+			}
+			batch.Push = append(batch.Push, PushGatewayData{MessageType: msgType, Payload: payload})
+		}
+	}
+
+	contents, ok := raw["contents"].([]interface{})
+	if !ok {
+		if len(batch.Nebula) == 0 && len(batch.Push) == 0 {
+			return errRawUndecodable
+		}
+		return nil
+	}
+
+	decodeAlternate := func(data map[string]interface{}, key1, key2 string) interface{} {
+		if v := data[key1]; v != nil {
+			return v
+		}
+		if v := data[key2]; v != nil {
+			return v
+		}
+		return nil
+	}
+
+	for _, v := range contents {
+		entry, ok := v.(map[string]interface{})
+		if !ok {
+			log.Errorf("Error decoding raw")
+			continue
+		}
+		// Try to decode the payload automatically without requiring any knowledge of the
+		// provider type
+		b64data := decodeAlternate(entry, "data", "payload")
+		method := decodeAlternate(entry, "method", "type")
+		if method == nil || b64data == nil {
+			log.Errorf("Error decoding raw")
+			continue
+		}
+
+		protoData := ProtoData{HaveAr: globalHaveAr}
+		if res, ok := method.(float64); ok {
+			protoData.Method = int(res)
+		}
+		if res, ok := b64data.(string); ok {
+			protoData.Data, _ = b64.StdEncoding.DecodeString(res)
+		}
+		if res, ok := entry["request"].(string); ok && res != "" {
+			protoData.Request, _ = b64.StdEncoding.DecodeString(res)
+		}
+		if res, ok := entry["have_ar"].(bool); ok {
+			protoData.HaveAr = &res
+		}
+		batch.Protos = append(batch.Protos, protoData)
+	}
+	return nil
+}
@@ -0,0 +1,78 @@
+package main
+
+import (
+	b64 "encoding/base64"
+	"encoding/json"
+	"net/http"
+
+	log "github.com/sirupsen/logrus"
+
+	"golbat/pogo"
+)
+
+// pogodroidAdapter accepts Pogodroid submissions, which identify the device
+// in the origin header and post a bare array of protos.
+type pogodroidAdapter struct{}
+
+func (pogodroidAdapter) Name() string {
+	return "pogodroid"
+}
+
+func (pogodroidAdapter) Detect(r *http.Request, body []byte) bool {
+	return r.Header.Get("origin") != ""
+}
+
+func (pogodroidAdapter) Parse(r *http.Request, body []byte, batch *RawBatch) error {
+	var raw []map[string]interface{}
+	if err := json.Unmarshal(body, &raw); err != nil {
+		return errRawUndecodable
+	}
+
+	for _, entry := range raw {
+		if batch.Lat == 0 && batch.Lon == 0 {
+			lat, _ := entry["lat"].(float64)
+			lng, _ := entry["lng"].(float64)
+			if lat != 0 && lng != 0 {
+				batch.Lat = lat
+				batch.Lon = lng
+			}
+		}
+		method, ok := entry["type"].(float64)
+		if !ok {
+			return errRawUndecodable
+		}
+		protoData := ProtoData{Method: int(method)}
+		protoData.Data, _ = b64.StdEncoding.DecodeString(getString(entry, "payload"))
+		if v := entry["quests_held"]; v != nil {
+			protoData.HaveAr = questsHeldHasARTask(v)
+		}
+		batch.Protos = append(batch.Protos, protoData)
+	}
+	batch.Uuid = r.Header.Get("origin")
+	batch.Account = "Pogodroid"
+	return nil
+}
+
+func questsHeldHasARTask(quests_held any) *bool {
+	const ar_quest_id = int64(pogo.QuestType_QUEST_GEOTARGETED_AR_SCAN)
+
+	quests_held_list, ok := quests_held.([]any)
+	if !ok {
+		log.Errorf("Raw: unexpected quests_held type in data: %T", quests_held)
+		return nil
+	}
+	for _, quest_id := range quests_held_list {
+		if quest_id_f, ok := quest_id.(float64); ok {
+			if int64(quest_id_f) == ar_quest_id {
+				res := true
+				return &res
+			}
+			continue
+		}
+		// quest_id is not float64? Treat the whole thing as unknown.
+		log.Errorf("Raw: unexpected quest_id type in quests_held: %T", quest_id)
+		return nil
+	}
+	res := false
+	return &res
+}
@@ -0,0 +1,142 @@
+package main
+
+import (
+	"bytes"
+	"net/http/httptest"
+	"testing"
+)
+
+func parseRawForTest(t *testing.T, origin, body string) (rawAdapter, *RawBatch, error) {
+	t.Helper()
+	r := httptest.NewRequest("POST", "/raw", bytes.NewReader([]byte(body)))
+	if origin != "" {
+		r.Header.Set("origin", origin)
+	}
+	adapter := findRawAdapter(r, []byte(body))
+	if adapter == nil {
+		t.Fatal("no adapter accepted the request")
+	}
+	batch := &RawBatch{Level: 30, TimestampMs: 1000}
+	err := adapter.Parse(r, []byte(body), batch)
+	batch.fillItemDetails()
+	return adapter, batch, err
+}
+
+func TestPogodroidAdapter(t *testing.T) {
+	body := `[
+		{"type": 106, "payload": "AQI=", "lat": 0, "lng": 0},
+		{"type": 101, "payload": "Aw==", "lat": 51.5, "lng": -0.1, "quests_held": []}
+	]`
+	adapter, batch, err := parseRawForTest(t, "device-1", body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if adapter.Name() != "pogodroid" {
+		t.Fatalf("adapter = %s, want pogodroid", adapter.Name())
+	}
+	if batch.Uuid != "device-1" || batch.Account != "Pogodroid" || batch.Lat != 51.5 || batch.Lon != -0.1 {
+		t.Errorf("batch details = %+v", batch)
+	}
+	if len(batch.Protos) != 2 {
+		t.Fatalf("parsed %d protos, want 2", len(batch.Protos))
+	}
+	first, second := batch.Protos[0], batch.Protos[1]
+	if first.Method != 106 || !bytes.Equal(first.Data, []byte{1, 2}) || first.HaveAr != nil {
+		t.Errorf("first proto = %+v", first)
+	}
+	if second.Method != 101 || second.HaveAr == nil || *second.HaveAr {
+		t.Errorf("second proto = %+v, want have_ar false", second)
+	}
+	if first.Uuid != "device-1" || first.Level != 30 || first.Lat != 51.5 || first.TimestampMs != 1000 {
+		t.Errorf("device details not copied to proto: %+v", first)
+	}
+
+	if _, _, err := parseRawForTest(t, "device-1", `{"contents": []}`); err == nil {
+		t.Error("object body accepted, want an error")
+	}
+	if _, _, err := parseRawForTest(t, "device-1", `[{"payload": "AQI="}]`); err == nil {
+		t.Error("entry without a type accepted, want an error")
+	}
+}
+
+func TestJsonAdapter(t *testing.T) {
+	body := `{
+		"uuid": "device-2", "username": "account-2", "trainerlvl": 35, "have_ar": true,
+		"scan_context": "quests", "lat_target": 40.7, "lon_target": -74.0,
+		"contents": [
+			{"method": 106, "data": "AQI=", "request": "BA=="},
+			{"type": 2, "payload": "Aw==", "have_ar": false},
+			{"payload": "Aw=="}
+		],
+		"nebula_contents": [
+			{"endpoint": "get-state", "payload": "BQ==", "battle_id": "battle-1",
+			 "context": {"invasion": {"fort_id": "fort-1", "incident_id": "incident-1"}}},
+			{"endpoint": "get-state", "payload": "BQ==",
+			 "context": {"raid": {"fort_id": "gym-1", "raid_seed": "-8817278474736478225"}}},
+			{"endpoint": "get-state", "payload": "BQ==",
+			 "context": {"max_battle": {"station_id": "station-1", "bread_battle_seed": 12345}}}
+		],
+		"push_contents": [
+			{"message_type": "raid", "payload": "Bg=="},
+			{"payload": "Bg=="}
+		]
+	}`
+	adapter, batch, err := parseRawForTest(t, "", body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if adapter.Name() != "json" {
+		t.Fatalf("adapter = %s, want json", adapter.Name())
+	}
+	if batch.Uuid != "device-2" || batch.Account != "account-2" || batch.Level != 35 ||
+		batch.ScanContext != "quests" || batch.Lat != 40.7 || batch.Lon != -74.0 {
+		t.Errorf("batch details = %+v", batch)
+	}
+
+	if len(batch.Protos) != 2 {
+		t.Fatalf("parsed %d protos, want 2 (the entry without a method is skipped)", len(batch.Protos))
+	}
+	first, second := batch.Protos[0], batch.Protos[1]
+	if first.Method != 106 || !bytes.Equal(first.Data, []byte{1, 2}) || !bytes.Equal(first.Request, []byte{4}) {
+		t.Errorf("first proto = %+v", first)
+	}
+	if first.HaveAr == nil || !*first.HaveAr {
+		t.Errorf("first proto have_ar = %v, want the batch default true", first.HaveAr)
+	}
+	if second.Method != 2 || second.HaveAr == nil || *second.HaveAr {
+		t.Errorf("second proto = %+v, want its own have_ar false", second)
+	}
+	if second.Account != "account-2" || second.ScanContext != "quests" {
+		t.Errorf("device details not copied to proto: %+v", second)
+	}
+
+	if len(batch.Nebula) != 3 {
+		t.Fatalf("parsed %d nebula items, want 3", len(batch.Nebula))
+	}
+	nebula := batch.Nebula[0]
+	if nebula.Endpoint != "get-state" || nebula.BattleId != "battle-1" || nebula.Invasion == nil ||
+		nebula.Invasion.FortId != "fort-1" || nebula.Uuid != "device-2" {
+		t.Errorf("nebula item = %+v", nebula)
+	}
+	if raid := batch.Nebula[1].Raid; raid == nil || raid.FortId != "gym-1" || raid.RaidSeed != -8817278474736478225 {
+		t.Errorf("raid context = %+v", raid)
+	}
+	if maxBattle := batch.Nebula[2].MaxBattle; maxBattle == nil || maxBattle.StationId != "station-1" || maxBattle.BreadBattleSeed != 12345 {
+		t.Errorf("max battle context = %+v", maxBattle)
+	}
+
+	if len(batch.Push) != 1 || batch.Push[0].MessageType != "raid" {
+		t.Errorf("push items = %+v, want the one with a message type", batch.Push)
+	}
+}
+
+func TestJsonAdapterRejectsEmptyBodies(t *testing.T) {
+	for _, body := range []string{`not json`, `{"uuid": "device-3"}`} {
+		if _, _, err := parseRawForTest(t, "", body); err == nil {
+			t.Errorf("%s accepted, want an error", body)
+		}
+	}
+	if _, batch, err := parseRawForTest(t, "", `{"push_contents": [{"message_type": "raid", "payload": "Bg=="}]}`); err != nil || len(batch.Push) != 1 {
+		t.Errorf("push-only body = %+v, %v", batch, err)
+	}
+}
@@ -0,0 +1,77 @@
+package main
+
+import (
+	"errors"
+
+	"golbat/config"
+	"golbat/decoder"
+	"golbat/geo"
+)
+
+var (
+	errRawAuth          = errors.New("auth")
+	errRawTokenDisabled = errors.New("token_disabled")
+)
+
+// findRawToken identifies the raw token a submission presented. A nil token
+// with no error means the submission is accepted without a named token: either
+// no raw authentication is configured, or it matched the legacy raw_bearer.
+// Once any raw_tokens are configured an unknown token is refused even if
+// raw_bearer is blank.
+func findRawToken(presented string) (*config.RawToken, error) {
+	if config.Config.RawBearer != "" && presented == config.Config.RawBearer {
+		return nil, nil
+	}
+	for i := range config.Config.RawTokens {
+		token := &config.Config.RawTokens[i]
+		if token.Token == "" || presented != token.Token {
+			continue
+		}
+		if !token.IsEnabled() {
+			return token, errRawTokenDisabled
+		}
+		return token, nil
+	}
+	if config.Config.RawBearer == "" && len(config.Config.RawTokens) == 0 {
+		return nil, nil
+	}
+	return nil, errRawAuth
+}
+
+// rawTokenName is the name submissions from token are recorded against.
+func rawTokenName(token *config.RawToken) string {
+	if token == nil {
+		return ""
+	}
+	return token.Name
+}
+
+// countRawRequest records a raw submission's outcome, and against its token
+// too when it arrived with a named one.
+func countRawRequest(status, message, tokenName string) {
+	statsCollector.IncRawRequests(status, message)
+	if tokenName != "" {
+		statsCollector.IncRawRequestsByToken(tokenName, status)
+	}
+}
+
+// rawTokenScanContext returns scanContext, or the token's default if the
+// device did not send one.
+func rawTokenScanContext(token *config.RawToken, scanContext string) string {
+	if scanContext == "" && token != nil {
+		return token.ScanContext
+	}
+	return scanContext
+}
+
+// rawTokenAllowsLocation reports whether token may submit data scanned at
+// lat, lon. Tokens restricted to areas must send a location.
+func rawTokenAllowsLocation(token *config.RawToken, lat, lon float64) bool {
+	if token == nil || len(token.AreaNames) == 0 {
+		return true
+	}
+	if lat == 0 && lon == 0 {
+		return false
+	}
+	return geo.AreaMatchWithWildcards(decoder.MatchStatsGeofence(lat, lon), token.AreaNames)
+}
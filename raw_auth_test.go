@@ -0,0 +1,81 @@
+package main
+
+import (
+	"errors"
+	"testing"
+
+	"golbat/config"
+	"golbat/geo"
+)
+
+func withRawAuthConfig(t *testing.T, bearer string, tokens []config.RawToken) {
+	t.Helper()
+	prevBearer, prevTokens := config.Config.RawBearer, config.Config.RawTokens
+	config.Config.RawBearer, config.Config.RawTokens = bearer, tokens
+	t.Cleanup(func() {
+		config.Config.RawBearer, config.Config.RawTokens = prevBearer, prevTokens
+	})
+}
+
+func TestFindRawToken(t *testing.T) {
+	disabled := false
+	tokens := []config.RawToken{
+		{Name: "partner", Token: "partner-secret", ScanContext: "Partner"},
+		{Name: "revoked", Token: "revoked-secret", Enabled: &disabled},
+	}
+
+	tests := []struct {
+		name      string
+		bearer    string
+		tokens    []config.RawToken
+		presented string
+		wantName  string
+		wantErr   error
+	}{
+		{name: "no auth configured", presented: ""},
+		{name: "legacy bearer", bearer: "shared", presented: "shared"},
+		{name: "legacy bearer wrong", bearer: "shared", presented: "nope", wantErr: errRawAuth},
+		{name: "named token", bearer: "shared", tokens: tokens, presented: "partner-secret", wantName: "partner"},
+		{name: "named token without bearer", tokens: tokens, presented: "partner-secret", wantName: "partner"},
+		{name: "unknown token without bearer", tokens: tokens, presented: "", wantErr: errRawAuth},
+		{name: "disabled token", tokens: tokens, presented: "revoked-secret", wantName: "revoked", wantErr: errRawTokenDisabled},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			withRawAuthConfig(t, tt.bearer, tt.tokens)
+			token, err := findRawToken(tt.presented)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+			if got := rawTokenName(token); got != tt.wantName {
+				t.Errorf("token = %q, want %q", got, tt.wantName)
+			}
+		})
+	}
+}
+
+func TestRawTokenScanContext(t *testing.T) {
+	token := &config.RawToken{Name: "partner", ScanContext: "Partner"}
+	if got := rawTokenScanContext(token, ""); got != "Partner" {
+		t.Errorf("default scan context = %q, want Partner", got)
+	}
+	if got := rawTokenScanContext(token, "Scout"); got != "Scout" {
+		t.Errorf("device scan context = %q, want Scout", got)
+	}
+	if got := rawTokenScanContext(nil, ""); got != "" {
+		t.Errorf("no token scan context = %q, want empty", got)
+	}
+}
+
+func TestRawTokenAllowsLocationRequiresLocation(t *testing.T) {
+	restricted := &config.RawToken{Name: "partner", AreaNames: []geo.AreaName{{Parent: "London", Name: "*"}}}
+	if rawTokenAllowsLocation(restricted, 0, 0) {
+		t.Error("restricted token allowed without a location")
+	}
+	if !rawTokenAllowsLocation(&config.RawToken{Name: "open"}, 0, 0) {
+		t.Error("unrestricted token refused")
+	}
+	if !rawTokenAllowsLocation(nil, 51.5, -0.1) {
+		t.Error("legacy bearer refused")
+	}
+}
@@ -0,0 +1,65 @@
+package main
+
+import (
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"golbat/config"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+var (
+	errRawBodyTooLarge        = errors.New("body too large")
+	errRawUnsupportedEncoding = errors.New("unsupported content encoding")
+)
+
+// rawBodyLimit is the largest /raw body accepted, in bytes after
+// decompression.
+func rawBodyLimit() int64 {
+	limit := config.Config.Tuning.RawBodyLimit
+	if limit <= 0 {
+		limit = 5
+	}
+	return int64(limit) * 1048576
+}
+
+// readRawBody reads a /raw request body, decompressing it according to its
+// Content-Encoding (gzip or zstd). limit applies to the body after
+// decompression; larger bodies return errRawBodyTooLarge.
+func readRawBody(r *http.Request, limit int64) ([]byte, error) {
+	var body io.Reader = r.Body
+
+	switch encoding := strings.ToLower(strings.TrimSpace(r.Header.Get("Content-Encoding"))); encoding {
+	case "", "identity":
+	case "gzip", "x-gzip":
+		gz, err := gzip.NewReader(r.Body)
+		if err != nil {
+			return nil, fmt.Errorf("gzip: %w", err)
+		}
+		defer gz.Close()
+		body = gz
+	case "zstd":
+		zr, err := zstd.NewReader(r.Body, zstd.WithDecoderConcurrency(1))
+		if err != nil {
+			return nil, fmt.Errorf("zstd: %w", err)
+		}
+		defer zr.Close()
+		body = zr
+	default:
+		return nil, fmt.Errorf("%w: %s", errRawUnsupportedEncoding, encoding)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(body, limit+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(data)) > limit {
+		return nil, errRawBodyTooLarge
+	}
+	return data, nil
+}
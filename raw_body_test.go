@@ -0,0 +1,77 @@
+package main
+
+import (
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+func TestReadRawBody(t *testing.T) {
+	payload := []byte(`{"uuid":"device-1","contents":[]}`)
+
+	var gz bytes.Buffer
+	gw := gzip.NewWriter(&gz)
+	gw.Write(payload)
+	gw.Close()
+
+	zw, _ := zstd.NewWriter(nil)
+	zstdBody := zw.EncodeAll(payload, nil)
+	zw.Close()
+
+	tests := []struct {
+		name     string
+		encoding string
+		body     []byte
+	}{
+		{"identity", "", payload},
+		{"gzip", "gzip", gz.Bytes()},
+		{"x-gzip", "x-gzip", gz.Bytes()},
+		{"zstd", "zstd", zstdBody},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest("POST", "/raw", bytes.NewReader(tt.body))
+			if tt.encoding != "" {
+				r.Header.Set("Content-Encoding", tt.encoding)
+			}
+			got, err := readRawBody(r, 1024)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(got, payload) {
+				t.Errorf("body = %q, want %q", got, payload)
+			}
+		})
+	}
+}
+
+func TestReadRawBodyLimitAppliesAfterDecompression(t *testing.T) {
+	// 64KB of zeros compresses to a few hundred bytes, well under the limit.
+	var gz bytes.Buffer
+	gw := gzip.NewWriter(&gz)
+	gw.Write(make([]byte, 65536))
+	gw.Close()
+
+	r := httptest.NewRequest("POST", "/raw", bytes.NewReader(gz.Bytes()))
+	r.Header.Set("Content-Encoding", "gzip")
+	if _, err := readRawBody(r, 4096); !errors.Is(err, errRawBodyTooLarge) {
+		t.Errorf("err = %v, want errRawBodyTooLarge", err)
+	}
+
+	r = httptest.NewRequest("POST", "/raw", bytes.NewReader(make([]byte, 4096)))
+	if _, err := readRawBody(r, 4096); err != nil {
+		t.Errorf("body at the limit: err = %v", err)
+	}
+}
+
+func TestReadRawBodyUnsupportedEncoding(t *testing.T) {
+	r := httptest.NewRequest("POST", "/raw", bytes.NewReader([]byte("x")))
+	r.Header.Set("Content-Encoding", "br")
+	if _, err := readRawBody(r, 1024); !errors.Is(err, errRawUnsupportedEncoding) {
+		t.Errorf("err = %v, want errRawUnsupportedEncoding", err)
+	}
+}
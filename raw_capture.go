@@ -0,0 +1,162 @@
+package main
+
+import (
+	"context"
+	"time"
+
+	"golbat/capture"
+	"golbat/config"
+
+	log "github.com/sirupsen/logrus"
+)
+
+var captureWriter *capture.Writer
+
+// InitRawCapture starts recording raw submissions to disk when capture is
+// enabled in config.
+func InitRawCapture() {
+	cfg := config.Config.Capture
+	if !cfg.Enabled {
+		return
+	}
+	log.Infof("Capturing raw submissions to %s", cfg.Directory)
+	captureWriter = capture.NewWriter(cfg.Directory, cfg.MaxSize, cfg.MaxBackups, cfg.Compress)
+}
+
+// CloseRawCapture flushes and closes the capture file, if capture is enabled.
+func CloseRawCapture() {
+	if captureWriter != nil {
+		captureWriter.Close()
+	}
+}
+
+// captureRawBatch queues a batch for recording. It is a no-op when capture is
+// disabled.
+func captureRawBatch(batch *RawBatch) {
+	if captureWriter == nil {
+		return
+	}
+
+	rec := &capture.Record{
+		ReceivedMs:  batch.ReceivedMs,
+		Source:      batch.Source,
+		Uuid:        batch.Uuid,
+		Account:     batch.Account,
+		Level:       batch.Level,
+		ScanContext: batch.ScanContext,
+		Token:       batch.Token,
+		Lat:         batch.Lat,
+		Lon:         batch.Lon,
+		TimestampMs: batch.TimestampMs,
+	}
+	for _, p := range batch.Protos {
+		rec.Protos = append(rec.Protos, capture.Proto{
+			Method:  p.Method,
+			Data:    p.Data,
+			Request: p.Request,
+			HaveAr:  p.HaveAr,
+		})
+	}
+	for _, n := range batch.Nebula {
+		cn := capture.Nebula{
+			Endpoint: n.Endpoint,
+			Data:     n.Data,
+			Request:  n.Request,
+			BattleId: n.BattleId,
+		}
+		if n.Invasion != nil {
+			cn.Invasion = &capture.InvasionContext{FortId: n.Invasion.FortId, IncidentId: n.Invasion.IncidentId}
+		}
+		if n.Raid != nil {
+			cn.Raid = &capture.RaidContext{FortId: n.Raid.FortId, RaidSeed: n.Raid.RaidSeed}
+		}
+		if n.MaxBattle != nil {
+			cn.MaxBattle = &capture.MaxBattleContext{StationId: n.MaxBattle.StationId, BreadBattleSeed: n.MaxBattle.BreadBattleSeed}
+		}
+		rec.Nebula = append(rec.Nebula, cn)
+	}
+	for _, p := range batch.Push {
+		rec.Push = append(rec.Push, capture.Push{MessageType: p.MessageType, Payload: p.Payload})
+	}
+
+	captureWriter.Write(rec)
+}
+
+// rawBatchFromRecord rebuilds the batch a capture record was taken from.
+func rawBatchFromRecord(rec *capture.Record) *RawBatch {
+	batch := &RawBatch{
+		Source:      rec.Source,
+		ReceivedMs:  rec.ReceivedMs,
+		Uuid:        rec.Uuid,
+		Account:     rec.Account,
+		Level:       rec.Level,
+		ScanContext: rec.ScanContext,
+		Token:       rec.Token,
+		Lat:         rec.Lat,
+		Lon:         rec.Lon,
+		TimestampMs: rec.TimestampMs,
+	}
+	for _, p := range rec.Protos {
+		batch.Protos = append(batch.Protos, ProtoData{
+			Method:      p.Method,
+			Data:        p.Data,
+			Request:     p.Request,
+			HaveAr:      p.HaveAr,
+			Account:     rec.Account,
+			Level:       rec.Level,
+			Uuid:        rec.Uuid,
+			ScanContext: rec.ScanContext,
+			Token:       rec.Token,
+			Lat:         rec.Lat,
+			Lon:         rec.Lon,
+			TimestampMs: rec.TimestampMs,
+		})
+	}
+	for _, n := range rec.Nebula {
+		nd := NebulaData{
+			Endpoint:    n.Endpoint,
+			Data:        n.Data,
+			Request:     n.Request,
+			BattleId:    n.BattleId,
+			Account:     rec.Account,
+			Level:       rec.Level,
+			Uuid:        rec.Uuid,
+			ScanContext: rec.ScanContext,
+			Lat:         rec.Lat,
+			Lon:         rec.Lon,
+			TimestampMs: rec.TimestampMs,
+		}
+		if n.Invasion != nil {
+			nd.Invasion = &nebulaInvasionContext{FortId: n.Invasion.FortId, IncidentId: n.Invasion.IncidentId}
+		}
+		if n.Raid != nil {
+			nd.Raid = &nebulaRaidContext{FortId: n.Raid.FortId, RaidSeed: n.Raid.RaidSeed}
+		}
+		if n.MaxBattle != nil {
+			nd.MaxBattle = &nebulaMaxBattleContext{StationId: n.MaxBattle.StationId, BreadBattleSeed: n.MaxBattle.BreadBattleSeed}
+		}
+		batch.Nebula = append(batch.Nebula, nd)
+	}
+	for _, p := range rec.Push {
+		batch.Push = append(batch.Push, PushGatewayData{MessageType: p.MessageType, Payload: p.Payload})
+	}
+	return batch
+}
+
+// ReplayRawCapture feeds previously captured submissions back through the
+// decoders, in order, at the given speed (0 = as fast as possible, 1 = the
+// original pace). Replayed batches are not captured again.
+func ReplayRawCapture(ctx context.Context, path string, speed float64) error {
+	files, err := capture.ResolveFiles(path)
+	if err != nil {
+		return err
+	}
+
+	log.Infof("Replay: replaying %d capture files from %s at speed %g", len(files), path, speed)
+	start := time.Now()
+	count, err := capture.Replay(ctx, files, speed, func(rec *capture.Record) {
+		decodeRawBatch(rawBatchFromRecord(rec))
+	})
+	log.Infof("Replay: replayed %d submissions in %s", count, time.Since(start))
+	return err
+}
@@ -0,0 +1,12 @@
+//go:build !unix
+
+package main
+
+import (
+	"context"
+)
+
+// There is no SIGHUP outside unix; webhooks can still be reloaded through the
+// api.
+func watchForReload(ctx context.Context) {
+}
@@ -0,0 +1,32 @@
+//go:build unix
+
+package main
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"syscall"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// watchForReload reloads the webhook destinations on every SIGHUP until
+// `ctx` is cancelled.
+func watchForReload(ctx context.Context) {
+	sig_ch := make(chan os.Signal, 1)
+	signal.Notify(sig_ch, syscall.SIGHUP)
+	defer signal.Stop(sig_ch)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case sig := <-sig_ch:
+			log.Infof("received signal '%s', reloading webhooks", sig)
+			if _, err := reloadWebhooks(); err != nil {
+				log.Errorf("failed to reload webhooks: %s", err)
+			}
+		}
+	}
+}
@@ -2,10 +2,10 @@ package main
 
 import (
 	"context"
-	b64 "encoding/base64"
-	"encoding/json"
-	"io"
+	"errors"
 	"net/http"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/gin-gonic/gin"
@@ -13,7 +13,6 @@ import (
 
 	"golbat/config"
 	"golbat/decoder"
-	"golbat/pogo"
 )
 
 type ProtoData struct {
@@ -25,6 +24,7 @@ type ProtoData struct {
 	Level       int
 	Uuid        string
 	ScanContext string
+	Token       string // name of the raw token the proto was submitted with
 	Lat         float64
 	Lon         float64
 	TimestampMs int64
@@ -36,11 +36,25 @@ type nebulaInvasionContext struct {
 	IncidentId string
 }
 
+// nebulaRaidContext mirrors the proto RaidContext oneof case.
+type nebulaRaidContext struct {
+	FortId   string
+	RaidSeed int64
+}
+
+// nebulaMaxBattleContext mirrors the proto MaxBattleContext oneof case.
+type nebulaMaxBattleContext struct {
+	StationId       string
+	BreadBattleSeed int64
+}
+
 type NebulaData struct {
 	Endpoint    string
 	Data        []byte
 	Request     []byte
-	Invasion    *nebulaInvasionContext // set when the context oneof case is invasion
+	Invasion    *nebulaInvasionContext  // set when the context oneof case is invasion
+	Raid        *nebulaRaidContext      // set when the context oneof case is raid
+	MaxBattle   *nebulaMaxBattleContext // set when the context oneof case is max_battle
 	BattleId    string
 	Account     string
 	Level       int
@@ -51,11 +65,28 @@ type NebulaData struct {
 	TimestampMs int64
 }
 
-type InboundRawData struct {
-	Base64Data string
-	Request    string
-	Method     int
-	HaveAr     *bool
+// PushGatewayData is a push-gateway message forwarded verbatim by the device.
+type PushGatewayData struct {
+	MessageType string
+	Payload     []byte
+}
+
+// RawBatch is one normalised raw submission (a /raw POST or a gRPC
+// SubmitRawProto call) together with the device details it arrived with.
+type RawBatch struct {
+	Source      string
+	ReceivedMs  int64
+	Uuid        string
+	Account     string
+	Level       int
+	ScanContext string
+	Token       string
+	Lat         float64
+	Lon         float64
+	TimestampMs int64
+	Protos      []ProtoData
+	Nebula      []NebulaData
+	Push        []PushGatewayData
 }
 
 type StatusResponse struct {
@@ -68,28 +99,17 @@ func getString(m map[string]any, k string) string {
 	return v
 }
 
-func questsHeldHasARTask(quests_held any) *bool {
-	const ar_quest_id = int64(pogo.QuestType_QUEST_GEOTARGETED_AR_SCAN)
-
-	quests_held_list, ok := quests_held.([]any)
-	if !ok {
-		log.Errorf("Raw: unexpected quests_held type in data: %T", quests_held)
-		return nil
+// getInt64 extracts an integer from a map[string]any, returning 0 if absent. Seeds do not fit in
+// a JSON number without losing precision, so a decimal string is accepted as well.
+func getInt64(m map[string]any, k string) int64 {
+	switch v := m[k].(type) {
+	case string:
+		i, _ := strconv.ParseInt(v, 10, 64)
+		return i
+	case float64:
+		return int64(v)
 	}
-	for _, quest_id := range quests_held_list {
-		if quest_id_f, ok := quest_id.(float64); ok {
-			if int64(quest_id_f) == ar_quest_id {
-				res := true
-				return &res
-			}
-			continue
-		}
-		// quest_id is not float64? Treat the whole thing as unknown.
-		log.Errorf("Raw: unexpected quest_id type in quests_held: %T", quest_id)
-		return nil
-	}
-	res := false
-	return &res
+	return 0
 }
 
 func Raw(c *gin.Context) {
@@ -99,308 +119,93 @@ func Raw(c *gin.Context) {
 	dataReceivedTimestamp := time.Now().UnixMilli()
 
 	authHeader := r.Header.Get("Authorization")
-	if config.Config.RawBearer != "" {
-		if authHeader != "Bearer "+config.Config.RawBearer {
-			statsCollector.IncRawRequests("error", "auth")
+	presented, found := strings.CutPrefix(authHeader, "Bearer ")
+	if !found {
+		presented = ""
+	}
+	token, err := findRawToken(presented)
+	tokenName := rawTokenName(token)
+	if err != nil {
+		countRawRequest("error", err.Error(), tokenName)
+		if token != nil {
+			log.Errorf("Raw: Submission with disabled token %s refused", tokenName)
+		} else {
 			log.Errorf("Raw: Incorrect authorisation received (%s)", authHeader)
-			return
 		}
+		return
 	}
 
-	body, err := io.ReadAll(io.LimitReader(r.Body, 5*1048576))
+	body, err := readRawBody(r, rawBodyLimit())
+	if errors.Is(err, errRawBodyTooLarge) {
+		countRawRequest("error", "too_large", tokenName)
+		log.Warnf("Raw: Body exceeds %d bytes after decompression", rawBodyLimit())
+		w.WriteHeader(http.StatusRequestEntityTooLarge)
+		return
+	}
+	if errors.Is(err, errRawUnsupportedEncoding) {
+		countRawRequest("error", "encoding", tokenName)
+		log.Warnf("Raw: %s", err)
+		w.WriteHeader(http.StatusUnsupportedMediaType)
+		return
+	}
 	if err != nil {
-		statsCollector.IncRawRequests("error", "io_error")
+		countRawRequest("error", "io_error", tokenName)
 		log.Errorf("Raw: Error (1) during HTTP receive %s", err)
 		return
 	}
 	if err := r.Body.Close(); err != nil {
-		statsCollector.IncRawRequests("error", "io_close_error")
+		countRawRequest("error", "io_close_error", tokenName)
 		log.Errorf("Raw: Error (2) during HTTP receive %s", err)
 		return
 	}
 
-	decodeError := false
-	uuid := ""
-	account := ""
-	level := 30
-	scanContext := ""
-	var latTarget, lonTarget float64
-	var globalHaveAr *bool
-	var protoData []InboundRawData
-	var nebulaItems []NebulaData
-	type pushItem struct {
-		MessageType string
-		Payload     []byte
+	batch := &RawBatch{
+		Source:      "http",
+		ReceivedMs:  dataReceivedTimestamp,
+		Level:       30,
+		Token:       tokenName,
+		TimestampMs: dataReceivedTimestamp,
 	}
-	var pushItems []pushItem
-
-	// Objective is to normalise incoming proto data. Unfortunately each provider seems
-	// to be just different enough that this ends up being a little bit more of a mess
-	// than I would like
-
-	pogodroidHeader := r.Header.Get("origin")
-	userAgent := r.Header.Get("User-Agent")
-
-	//log.Infof("Raw: Received data from %s", body)
-	//log.Infof("User agent is %s", userAgent)
-
-	if pogodroidHeader != "" {
-		var raw []map[string]interface{}
-		if err := json.Unmarshal(body, &raw); err != nil {
-			decodeError = true
-		} else {
-			for _, entry := range raw {
-				if latTarget == 0 && lonTarget == 0 {
-					lat := entry["lat"]
-					lng := entry["lng"]
-					if lat != nil && lng != nil {
-						lat_f, _ := lat.(float64)
-						lng_f, _ := lng.(float64)
-						if lat_f != 0 && lng_f != 0 {
-							latTarget = lat_f
-							lonTarget = lng_f
-						}
-					}
-				}
-				protoData = append(protoData, InboundRawData{
-					Base64Data: entry["payload"].(string),
-					Method:     int(entry["type"].(float64)),
-					HaveAr: func() *bool {
-						if v := entry["quests_held"]; v != nil {
-							return questsHeldHasARTask(v)
-						}
-						return nil
-					}(),
-				})
-			}
-		}
-		uuid = pogodroidHeader
-		account = "Pogodroid"
-	} else {
-		var raw map[string]interface{}
-		if err := json.Unmarshal(body, &raw); err != nil {
-			decodeError = true
-		} else {
-			if v := raw["have_ar"]; v != nil {
-				res, ok := v.(bool)
-				if ok {
-					globalHaveAr = &res
-				}
-			}
-			if v := raw["uuid"]; v != nil {
-				uuid, _ = v.(string)
-			}
-			if v := raw["username"]; v != nil {
-				account, _ = v.(string)
-			}
-			if v := raw["trainerlvl"]; v != nil {
-				lvl, ok := v.(float64)
-				if ok {
-					level = int(lvl)
-				}
-			}
-			if v := raw["scan_context"]; v != nil {
-				scanContext, _ = v.(string)
-			}
 
-			if v := raw["lat_target"]; v != nil {
-				latTarget, _ = v.(float64)
-			}
-
-			if v := raw["lon_target"]; v != nil {
-				lonTarget, _ = v.(float64)
-			}
-
-			if v := raw["timestamp_ms"]; v != nil {
-				ts, _ := v.(int64)
-				if ts > 0 {
-					dataReceivedTimestamp = ts
-				}
-			}
-
-			if rawNebula, ok := raw["nebula_contents"].([]any); ok {
-				for _, item := range rawNebula {
-					m, ok := item.(map[string]any)
-					if !ok {
-						continue
-					}
-					payload, _ := b64.StdEncoding.DecodeString(getString(m, "payload"))
-					request, _ := b64.StdEncoding.DecodeString(getString(m, "request"))
-					nd := NebulaData{
-						Endpoint:    getString(m, "endpoint"),
-						Data:        payload,
-						Request:     request,
-						BattleId:    getString(m, "battle_id"),
-						Account:     account,
-						Level:       level,
-						Uuid:        uuid,
-						ScanContext: scanContext,
-						Lat:         latTarget,
-						Lon:         lonTarget,
-						TimestampMs: dataReceivedTimestamp,
-					}
-					// context: { "invasion": { "fort_id": "...", "incident_id": "..." } }
-					if ctxObj, ok := m["context"].(map[string]any); ok {
-						if inv, ok := ctxObj["invasion"].(map[string]any); ok {
-							nd.Invasion = &nebulaInvasionContext{
-								FortId:     getString(inv, "fort_id"),
-								IncidentId: getString(inv, "incident_id"),
-							}
-						}
-					}
-					nebulaItems = append(nebulaItems, nd)
-				}
-			}
-
-			if rawPush, ok := raw["push_contents"].([]any); ok {
-				for _, item := range rawPush {
-					m, ok := item.(map[string]any)
-					if !ok {
-						continue
-					}
-					msgType := getString(m, "message_type")
-					payload, _ := b64.StdEncoding.DecodeString(getString(m, "payload"))
-					if msgType == "" || payload == nil {
-						continue
-					}
-					pushItems = append(pushItems, pushItem{MessageType: msgType, Payload: payload})
-				}
-			}
-
-			contents, ok := raw["contents"].([]interface{})
-			if !ok {
-				if len(nebulaItems) == 0 && len(pushItems) == 0 {
-					decodeError = true
-				}
-			} else {
-
-				decodeAlternate := func(data map[string]interface{}, key1, key2 string) interface{} {
-					if v := data[key1]; v != nil {
-						return v
-					}
-					if v := data[key2]; v != nil {
-						return v
-					}
-					return nil
-				}
-
-				for _, v := range contents {
-					entry := v.(map[string]interface{})
-					// Try to decode the payload automatically without requiring any knowledge of the
-					// provider type
-
-					b64data := decodeAlternate(entry, "data", "payload")
-					method := decodeAlternate(entry, "method", "type")
-					request := entry["request"]
-					haveAr := entry["have_ar"]
-
-					if method == nil || b64data == nil {
-						log.Errorf("Error decoding raw")
-						continue
-					}
-					inboundRawData := InboundRawData{
-						Base64Data: func() string {
-							if res, ok := b64data.(string); ok {
-								return res
-							}
-							return ""
-						}(),
-						Method: func() int {
-							if res, ok := method.(float64); ok {
-								return int(res)
-							}
-
-							return 0
-						}(),
-						Request: func() string {
-							if request != nil {
-								res, ok := request.(string)
-								if ok {
-									return res
-								}
-							}
-							return ""
-						}(),
-						HaveAr: func() *bool {
-							if haveAr != nil {
-								res, ok := haveAr.(bool)
-								if ok {
-									return &res
-								}
-							}
-							return nil
-						}(),
-					}
-
-					protoData = append(protoData, inboundRawData)
-				}
-			}
+	// Objective is to normalise incoming proto data. Each provider seems to be just
+	// different enough that they each have their own adapter
+	adapter := findRawAdapter(r, body)
+	if adapter == nil || adapter.Parse(r, body, batch) != nil {
+		adapterName := "none"
+		if adapter != nil {
+			adapterName = adapter.Name()
 		}
-	}
-
-	if decodeError {
-		statsCollector.IncRawRequests("error", "decode")
-		log.Infof("Raw: Data could not be decoded. From User agent %s - Received data %s", userAgent, body)
+		countRawRequest("error", "decode", tokenName)
+		log.Infof("Raw: Data could not be decoded by %s adapter. From User agent %s - Received data %s", adapterName, r.Header.Get("User-Agent"), body)
 
 		w.Header().Set("Content-Type", "application/json; charset=UTF-8")
 		w.WriteHeader(http.StatusUnprocessableEntity)
 		return
 	}
+	batch.ScanContext = rawTokenScanContext(token, batch.ScanContext)
+	batch.fillItemDetails()
 
-	// Process each proto in a packet in sequence, but in a go-routine
-	go func() {
-		timeout := 5 * time.Second
-		if config.Config.Tuning.ExtendedTimeout {
-			timeout = 30 * time.Second
-		}
-
-		for _, entry := range protoData {
-			method := entry.Method
-			payload := entry.Base64Data
-			request := entry.Request
-
-			haveAr := globalHaveAr
-			if entry.HaveAr != nil {
-				haveAr = entry.HaveAr
-			}
-
-			protoData := ProtoData{
-				Account:     account,
-				Level:       level,
-				HaveAr:      haveAr,
-				Uuid:        uuid,
-				Lat:         latTarget,
-				Lon:         lonTarget,
-				ScanContext: scanContext,
-				TimestampMs: dataReceivedTimestamp,
-			}
-			protoData.Data, _ = b64.StdEncoding.DecodeString(payload)
-			if request != "" {
-				protoData.Request, _ = b64.StdEncoding.DecodeString(request)
-			}
-
-			// provide independent cancellation contexts for each proto decode
-			ctx, cancel := context.WithTimeout(context.Background(), timeout)
-			decode(ctx, method, &protoData)
-			cancel()
-		}
-
-		for _, entry := range nebulaItems {
-			go decodeNebula(context.Background(), entry.Endpoint, &entry)
-		}
+	if !rawTokenAllowsLocation(token, batch.Lat, batch.Lon) {
+		countRawRequest("error", "area", tokenName)
+		log.Warnf("Raw: Token %s submitted data outside its areas (%f,%f)", tokenName, batch.Lat, batch.Lon)
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
 
-		for _, entry := range pushItems {
-			ctx, cancel := context.WithTimeout(context.Background(), timeout)
-			decodePushGateway(ctx, entry.MessageType, entry.Payload)
-			cancel()
-		}
-	}()
+	// Process each proto in a packet in sequence on a decode worker
+	if !rawDecodeQueue.submit(batch) {
+		countRawRequest("error", "queue_full", tokenName)
+		w.Header().Set("Retry-After", "1")
+		w.WriteHeader(http.StatusTooManyRequests)
+		return
+	}
+	captureRawBatch(batch)
 
-	if latTarget != 0 && lonTarget != 0 && uuid != "" {
-		UpdateDeviceLocation(uuid, latTarget, lonTarget, scanContext)
+	if batch.Lat != 0 && batch.Lon != 0 && batch.Uuid != "" {
+		UpdateDeviceLocation(batch.Uuid, batch.Lat, batch.Lon, batch.ScanContext)
 	}
 
-	statsCollector.IncRawRequests("ok", "")
+	countRawRequest("ok", "", tokenName)
 	w.Header().Set("Content-Type", "application/json; charset=UTF-8")
 	w.WriteHeader(http.StatusCreated)
 	//if err := json.NewEncoder(w).Encode(t); err != nil {
@@ -408,6 +213,33 @@ func Raw(c *gin.Context) {
 	//}
 }
 
+// decodeRawBatch decodes the protos and push-gateway messages of a batch in
+// sequence, each with its own timeout; nebula contents are decoded alongside
+// in their own go-routines.
+func decodeRawBatch(batch *RawBatch) {
+	timeout := 5 * time.Second
+	if config.Config.Tuning.ExtendedTimeout {
+		timeout = 30 * time.Second
+	}
+
+	for i := range batch.Protos {
+		// provide independent cancellation contexts for each proto decode
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		decode(ctx, batch.Protos[i].Method, &batch.Protos[i])
+		cancel()
+	}
+
+	for i := range batch.Nebula {
+		go decodeNebula(context.Background(), batch.Nebula[i].Endpoint, &batch.Nebula[i])
+	}
+
+	for _, entry := range batch.Push {
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		decodePushGateway(ctx, batch, entry.MessageType, entry.Payload)
+		cancel()
+	}
+}
+
 func AuthRequired() gin.HandlerFunc {
 	return func(context *gin.Context) {
 		if config.Config.ApiSecret != "" {
@@ -633,6 +633,74 @@ func registerTier4Routes(api huma.API) {
 		return out, nil
 	})
 
+	// GET /api/devices/health
+	huma.Register(api, huma.Operation{
+		OperationID:   "get-devices-health",
+		Method:        http.MethodGet,
+		Path:          "/api/devices/health",
+		Summary:       "Ingest health of all devices",
+		Description:   "Returns, per device, the protos received by method, decode errors, low-level rejections, last GMO time and current empty-GMO streak.",
+		Tags:          []string{"Devices"},
+		Security:      []map[string][]string{{securitySchemeName: {}}},
+		DefaultStatus: http.StatusOK,
+	}, func(ctx context.Context, in *struct{}) (*deviceHealthListOutput, error) {
+		out := &deviceHealthListOutput{}
+		out.Body.Devices = GetDeviceHealth()
+		return out, nil
+	})
+
+	// GET /api/devices/health/{uuid}
+	huma.Register(api, huma.Operation{
+		OperationID:   "get-device-health",
+		Method:        http.MethodGet,
+		Path:          "/api/devices/health/{uuid}",
+		Summary:       "Ingest health of one device",
+		Description:   "Returns the ingest health of a single device.",
+		Tags:          []string{"Devices"},
+		Security:      []map[string][]string{{securitySchemeName: {}}},
+		DefaultStatus: http.StatusOK,
+	}, func(ctx context.Context, in *deviceHealthInput) (*ingestHealthOutput, error) {
+		health := GetOneDeviceHealth(in.Uuid)
+		if health == nil {
+			return nil, huma.Error404NotFound("Device not found")
+		}
+		return &ingestHealthOutput{Body: *health}, nil
+	})
+
+	// GET /api/devices/accounts
+	huma.Register(api, huma.Operation{
+		OperationID:   "get-accounts-health",
+		Method:        http.MethodGet,
+		Path:          "/api/devices/accounts",
+		Summary:       "Ingest health of all accounts",
+		Description:   "Returns, per account, the protos received by method, decode errors, low-level rejections, last GMO time and current empty-GMO streak.",
+		Tags:          []string{"Devices"},
+		Security:      []map[string][]string{{securitySchemeName: {}}},
+		DefaultStatus: http.StatusOK,
+	}, func(ctx context.Context, in *struct{}) (*accountHealthListOutput, error) {
+		out := &accountHealthListOutput{}
+		out.Body.Accounts = GetAccountHealth()
+		return out, nil
+	})
+
+	// GET /api/devices/accounts/{account}
+	huma.Register(api, huma.Operation{
+		OperationID:   "get-account-health",
+		Method:        http.MethodGet,
+		Path:          "/api/devices/accounts/{account}",
+		Summary:       "Ingest health of one account",
+		Description:   "Returns the ingest health of a single account.",
+		Tags:          []string{"Devices"},
+		Security:      []map[string][]string{{securitySchemeName: {}}},
+		DefaultStatus: http.StatusOK,
+	}, func(ctx context.Context, in *accountHealthInput) (*ingestHealthOutput, error) {
+		health := GetOneAccountHealth(in.Account)
+		if health == nil {
+			return nil, huma.Error404NotFound("Account not found")
+		}
+		return &ingestHealthOutput{Body: *health}, nil
+	})
+
 	// GET /api/fort-tracker/cell/{cell_id}
 	huma.Register(api, huma.Operation{
 		OperationID:   "get-fort-tracker-cell",
@@ -740,6 +808,28 @@ type devicesOutput struct {
 	}
 }
 
+type deviceHealthListOutput struct {
+	Body struct {
+		Devices []ApiIngestHealth `json:"devices"`
+	}
+}
+
+type accountHealthListOutput struct {
+	Body struct {
+		Accounts []ApiIngestHealth `json:"accounts"`
+	}
+}
+
+type ingestHealthOutput struct{ Body ApiIngestHealth }
+
+type deviceHealthInput struct {
+	Uuid string `path:"uuid" doc:"Device uuid"`
+}
+
+type accountHealthInput struct {
+	Account string `path:"account" doc:"Account username"`
+}
+
 type fortTrackerCellInput struct {
 	CellId uint64 `path:"cell_id" doc:"S2 cell id"`
 }
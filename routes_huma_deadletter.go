@@ -0,0 +1,116 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"golbat/deadletter"
+
+	"github.com/danielgtaylor/huma/v2"
+)
+
+type deadLettersOutput struct {
+	Body struct {
+		DeadLetters []deadletter.Summary `json:"dead_letters"`
+	}
+}
+
+type deadLetterInput struct {
+	Id string `path:"id" doc:"Dead-letter id"`
+}
+type deadLetterOutput struct{ Body *deadletter.Entry }
+
+type deadLetterDownloadInput struct {
+	Id   string `path:"id" doc:"Dead-letter id"`
+	Part string `query:"part" enum:"data,request" default:"data" doc:"Download the response payload (data) or the request payload"`
+}
+type deadLetterDownloadOutput struct {
+	ContentType        string `header:"Content-Type"`
+	ContentDisposition string `header:"Content-Disposition"`
+	Body               []byte
+}
+
+// registerDeadLetterRoutes registers the dead-letter list and download
+// operations. They return 503 when the dead-letter store is disabled.
+func registerDeadLetterRoutes(api huma.API) {
+	listOp := huma.Operation{
+		OperationID:   "list-dead-letters",
+		Method:        http.MethodGet,
+		Path:          "/api/dead-letters",
+		Summary:       "List payloads that failed to decode",
+		Description:   "Returns the most recent dead-letter entries held in memory, newest first, without their payloads.",
+		Tags:          []string{"DeadLetters"},
+		Security:      []map[string][]string{{securitySchemeName: {}}},
+		DefaultStatus: http.StatusOK,
+	}
+	draftBadge(&listOp)
+	huma.Register(api, listOp, func(ctx context.Context, in *struct{}) (*deadLettersOutput, error) {
+		if deadLetterStore == nil {
+			return nil, huma.Error503ServiceUnavailable("dead_letter not enabled")
+		}
+		out := &deadLettersOutput{}
+		out.Body.DeadLetters = deadLetterStore.List()
+		return out, nil
+	})
+
+	getOp := huma.Operation{
+		OperationID:   "get-dead-letter",
+		Method:        http.MethodGet,
+		Path:          "/api/dead-letters/{id}",
+		Summary:       "Get a dead-letter entry",
+		Description:   "Returns one dead-letter entry with its payloads base64 encoded. Entries no longer held in memory are read from disk.",
+		Tags:          []string{"DeadLetters"},
+		Security:      []map[string][]string{{securitySchemeName: {}}},
+		DefaultStatus: http.StatusOK,
+	}
+	draftBadge(&getOp)
+	huma.Register(api, getOp, func(ctx context.Context, in *deadLetterInput) (*deadLetterOutput, error) {
+		entry, err := getDeadLetter(in.Id)
+		if err != nil {
+			return nil, err
+		}
+		return &deadLetterOutput{Body: entry}, nil
+	})
+
+	downloadOp := huma.Operation{
+		OperationID:   "download-dead-letter",
+		Method:        http.MethodGet,
+		Path:          "/api/dead-letters/{id}/download",
+		Summary:       "Download a dead-letter payload",
+		Description:   "Returns the raw bytes of a dead-letter entry's response or request payload.",
+		Tags:          []string{"DeadLetters"},
+		Security:      []map[string][]string{{securitySchemeName: {}}},
+		DefaultStatus: http.StatusOK,
+	}
+	draftBadge(&downloadOp)
+	huma.Register(api, downloadOp, func(ctx context.Context, in *deadLetterDownloadInput) (*deadLetterDownloadOutput, error) {
+		entry, err := getDeadLetter(in.Id)
+		if err != nil {
+			return nil, err
+		}
+		payload := entry.Data
+		if in.Part == "request" {
+			payload = entry.Request
+		}
+		return &deadLetterDownloadOutput{
+			ContentType:        "application/octet-stream",
+			ContentDisposition: fmt.Sprintf(`attachment; filename="%s-%s-%s.bin"`, entry.MethodName, entry.Id, in.Part),
+			Body:               payload,
+		}, nil
+	})
+}
+
+func getDeadLetter(id string) (*deadletter.Entry, error) {
+	if deadLetterStore == nil {
+		return nil, huma.Error503ServiceUnavailable("dead_letter not enabled")
+	}
+	entry, err := deadLetterStore.Get(id)
+	if err != nil {
+		return nil, huma.Error500InternalServerError("Unable to read dead letters", err)
+	}
+	if entry == nil {
+		return nil, huma.Error404NotFound("Dead letter not found")
+	}
+	return entry, nil
+}
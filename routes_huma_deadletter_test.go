@@ -0,0 +1,87 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"golbat/config"
+	"golbat/deadletter"
+
+	"github.com/danielgtaylor/huma/v2/humatest"
+	gojson "github.com/goccy/go-json"
+)
+
+func TestDeadLetterRoutesDisabled(t *testing.T) {
+	prev := deadLetterStore
+	deadLetterStore = nil
+	defer func() { deadLetterStore = prev }()
+
+	_, api := humatest.New(t, newHumaConfig("test"))
+	registerDeadLetterRoutes(api)
+
+	if resp := api.Get("/api/dead-letters"); resp.Code != http.StatusServiceUnavailable {
+		t.Errorf("got %d, want 503; body=%s", resp.Code, resp.Body.String())
+	}
+}
+
+func TestDeadLetterRoutes(t *testing.T) {
+	prevSecret := config.Config.ApiSecret
+	config.Config.ApiSecret = ""
+	prevStore := deadLetterStore
+	deadLetterStore = deadletter.NewStore(10, "", 0, 0)
+	defer func() {
+		config.Config.ApiSecret = prevSecret
+		deadLetterStore = prevStore
+	}()
+
+	// A decoder reporting a failure dead-letters the proto being decoded.
+	protoData := &ProtoData{Uuid: "device-1", Account: "acc-1", Data: []byte{0xde, 0xad}, Request: []byte{0x01}}
+	ctx := withDeadLetter(context.Background(), protoData, 106)
+	deadLetterFromContext(ctx, "parse")
+
+	_, api := humatest.New(t, newHumaConfig("test"))
+	api.UseMiddleware(golbatSecretMiddleware(api))
+	registerDeadLetterRoutes(api)
+
+	resp := api.Get("/api/dead-letters")
+	if resp.Code != http.StatusOK {
+		t.Fatalf("list: got %d, want 200; body=%s", resp.Code, resp.Body.String())
+	}
+	var list struct {
+		DeadLetters []deadletter.Summary `json:"dead_letters"`
+	}
+	if err := gojson.Unmarshal(resp.Body.Bytes(), &list); err != nil {
+		t.Fatalf("list body: %v; body=%s", err, resp.Body.String())
+	}
+	if len(list.DeadLetters) != 1 || list.DeadLetters[0].Reason != "parse" || list.DeadLetters[0].Account != "acc-1" {
+		t.Fatalf("dead letters = %+v", list.DeadLetters)
+	}
+	id := list.DeadLetters[0].Id
+
+	t.Run("download returns the raw payload", func(t *testing.T) {
+		resp := api.Get("/api/dead-letters/" + id + "/download")
+		if resp.Code != http.StatusOK {
+			t.Fatalf("got %d, want 200; body=%s", resp.Code, resp.Body.String())
+		}
+		if got := resp.Body.Bytes(); string(got) != "\xde\xad" {
+			t.Errorf("payload = %x, want dead", got)
+		}
+		if ct := resp.Header().Get("Content-Type"); ct != "application/octet-stream" {
+			t.Errorf("Content-Type = %q", ct)
+		}
+	})
+
+	t.Run("download request part", func(t *testing.T) {
+		resp := api.Get("/api/dead-letters/" + id + "/download?part=request")
+		if resp.Code != http.StatusOK || string(resp.Body.Bytes()) != "\x01" {
+			t.Errorf("got %d %x, want 200 01", resp.Code, resp.Body.Bytes())
+		}
+	})
+
+	t.Run("unknown id is 404", func(t *testing.T) {
+		if resp := api.Get("/api/dead-letters/nope"); resp.Code != http.StatusNotFound {
+			t.Errorf("got %d, want 404; body=%s", resp.Code, resp.Body.String())
+		}
+	})
+}
@@ -0,0 +1,102 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"golbat/config"
+	"golbat/webhooks"
+
+	"github.com/danielgtaylor/huma/v2"
+)
+
+// webhookStatusSource is the webhooks sender, set once it is started
+var webhookStatusSource interface {
+	Status() []webhooks.DestinationStatus
+}
+
+// webhookReloader is the webhooks sender, set once it is started
+var webhookReloader interface {
+	Reload(configWebhooks []config.Webhook) error
+}
+
+var errWebhooksNotStarted = errors.New("webhooks not started")
+
+// reloadWebhooks reads the webhooks from the config again and swaps them in,
+// returning how many destinations there now are
+func reloadWebhooks() (int, error) {
+	if webhookReloader == nil {
+		return 0, errWebhooksNotStarted
+	}
+	configWebhooks, err := config.ReadWebhooks()
+	if err != nil {
+		return 0, err
+	}
+	if err := webhookReloader.Reload(configWebhooks); err != nil {
+		return 0, err
+	}
+	return len(configWebhooks), nil
+}
+
+type webhookStatusOutput struct {
+	Body struct {
+		Destinations []webhooks.DestinationStatus `json:"destinations"`
+	}
+}
+
+type webhookReloadOutput struct {
+	Body struct {
+		Status       string `json:"status"`
+		Destinations int    `json:"destinations" doc:"Number of webhook destinations after the reload"`
+	}
+}
+
+// registerWebhookStatusRoutes registers the webhook delivery status and
+// reload operations. They return 503 until the webhooks sender is running.
+func registerWebhookStatusRoutes(api huma.API) {
+	statusOp := huma.Operation{
+		OperationID:   "get-webhook-status",
+		Method:        http.MethodGet,
+		Path:          "/api/webhooks/status",
+		Summary:       "Webhook delivery status",
+		Description:   "Returns the delivery counters of each configured webhook destination since startup, in config order: messages and bytes queued, POST attempts by status class, latency, failures and the latest error.",
+		Tags:          []string{"Webhooks"},
+		Security:      []map[string][]string{{securitySchemeName: {}}},
+		DefaultStatus: http.StatusOK,
+	}
+	draftBadge(&statusOp)
+	huma.Register(api, statusOp, func(ctx context.Context, in *struct{}) (*webhookStatusOutput, error) {
+		if webhookStatusSource == nil {
+			return nil, huma.Error503ServiceUnavailable("webhooks not started")
+		}
+		out := &webhookStatusOutput{}
+		out.Body.Destinations = webhookStatusSource.Status()
+		return out, nil
+	})
+
+	reloadOp := huma.Operation{
+		OperationID:   "reload-webhooks",
+		Method:        http.MethodPost,
+		Path:          "/api/webhooks/reload",
+		Summary:       "Reload webhook destinations",
+		Description:   "Reads the webhooks from config.toml and the environment again and swaps them in without a restart. Messages collected for a destination are kept when it is changed, and sent when it is removed. Nothing changes if the new webhooks are invalid. SIGHUP does the same.",
+		Tags:          []string{"Webhooks"},
+		Security:      []map[string][]string{{securitySchemeName: {}}},
+		DefaultStatus: http.StatusOK,
+	}
+	draftBadge(&reloadOp)
+	huma.Register(api, reloadOp, func(ctx context.Context, in *struct{}) (*webhookReloadOutput, error) {
+		destinations, err := reloadWebhooks()
+		if errors.Is(err, errWebhooksNotStarted) {
+			return nil, huma.Error503ServiceUnavailable(err.Error())
+		}
+		if err != nil {
+			return nil, huma.Error422UnprocessableEntity("failed to reload webhooks: " + err.Error())
+		}
+		out := &webhookReloadOutput{}
+		out.Body.Status = "ok"
+		out.Body.Destinations = destinations
+		return out, nil
+	})
+}
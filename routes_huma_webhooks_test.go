@@ -0,0 +1,67 @@
+package main
+
+import (
+	"net/http"
+	"testing"
+
+	"golbat/config"
+	"golbat/webhooks"
+
+	"github.com/danielgtaylor/huma/v2/humatest"
+	gojson "github.com/goccy/go-json"
+)
+
+type fakeWebhookStatusSource []webhooks.DestinationStatus
+
+func (source fakeWebhookStatusSource) Status() []webhooks.DestinationStatus { return source }
+
+func TestWebhookStatusRouteNotStarted(t *testing.T) {
+	prev, prevReloader := webhookStatusSource, webhookReloader
+	webhookStatusSource, webhookReloader = nil, nil
+	defer func() { webhookStatusSource, webhookReloader = prev, prevReloader }()
+
+	_, api := humatest.New(t, newHumaConfig("test"))
+	registerWebhookStatusRoutes(api)
+
+	if resp := api.Get("/api/webhooks/status"); resp.Code != http.StatusServiceUnavailable {
+		t.Errorf("got %d, want 503; body=%s", resp.Code, resp.Body.String())
+	}
+	if resp := api.Post("/api/webhooks/reload"); resp.Code != http.StatusServiceUnavailable {
+		t.Errorf("reload got %d, want 503; body=%s", resp.Code, resp.Body.String())
+	}
+}
+
+func TestWebhookStatusRoute(t *testing.T) {
+	prevSecret := config.Config.ApiSecret
+	config.Config.ApiSecret = ""
+	prevSource := webhookStatusSource
+	webhookStatusSource = fakeWebhookStatusSource{{
+		Destination: "http://receiver/hook",
+		Messages:    3,
+		Requests:    map[string]uint64{"5xx": 2},
+		Failures:    2,
+		LastError:   "webhook to http://receiver/hook returned 500 Internal Server Error",
+	}}
+	defer func() {
+		config.Config.ApiSecret = prevSecret
+		webhookStatusSource = prevSource
+	}()
+
+	_, api := humatest.New(t, newHumaConfig("test"))
+	api.UseMiddleware(golbatSecretMiddleware(api))
+	registerWebhookStatusRoutes(api)
+
+	resp := api.Get("/api/webhooks/status")
+	if resp.Code != http.StatusOK {
+		t.Fatalf("got %d, want 200; body=%s", resp.Code, resp.Body.String())
+	}
+	var body struct {
+		Destinations []webhooks.DestinationStatus `json:"destinations"`
+	}
+	if err := gojson.Unmarshal(resp.Body.Bytes(), &body); err != nil {
+		t.Fatalf("body: %v; body=%s", err, resp.Body.String())
+	}
+	if len(body.Destinations) != 1 || body.Destinations[0].Requests["5xx"] != 2 || body.Destinations[0].Failures != 2 {
+		t.Fatalf("destinations = %+v", body.Destinations)
+	}
+}
@@ -0,0 +1,170 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	log "github.com/sirupsen/logrus"
+
+	"golbat/config"
+	"golbat/geo"
+	"golbat/webhooks"
+)
+
+const (
+	liveStreamBuffer    = 1024
+	liveStreamKeepAlive = 15 * time.Second
+)
+
+// liveStreamSource is the webhooks sender, set once it is started
+var liveStreamSource interface {
+	Subscribe(filter webhooks.StreamFilter, buffer int) *webhooks.Subscription
+	Unsubscribe(sub *webhooks.Subscription)
+}
+
+var (
+	liveStreamsClosed    = make(chan struct{})
+	closeLiveStreamsOnce sync.Once
+)
+
+// closeLiveStreams ends every open stream, so they do not hold up a graceful
+// shutdown of the http server.
+func closeLiveStreams() {
+	closeLiveStreamsOnce.Do(func() { close(liveStreamsClosed) })
+}
+
+// LiveStream streams webhook messages as server-sent events, each named
+// after its webhook type with the webhook message as data. Clients select
+// messages with the `types` and `areas` query parameters (comma separated,
+// as in the webhooks config) and a `min_lat`, `min_lon`, `max_lat`,
+// `max_lon` bounding box. EventSource cannot set headers, so the api secret
+// may also be given as the `secret` query parameter.
+func LiveStream(c *gin.Context) {
+	if config.Config.ApiSecret != "" {
+		secret := c.Request.Header.Get("X-Golbat-Secret")
+		if secret == "" {
+			secret = c.Query("secret")
+		}
+		if secret != config.Config.ApiSecret {
+			log.Errorf("Incorrect authorisation received for live stream")
+			c.String(http.StatusUnauthorized, "Unauthorised")
+			return
+		}
+	}
+	if liveStreamSource == nil {
+		c.String(http.StatusServiceUnavailable, "webhooks not started")
+		return
+	}
+
+	filter, err := parseStreamFilter(c)
+	if err != nil {
+		c.String(http.StatusBadRequest, err.Error())
+		return
+	}
+	filter.EncodeJSON = true
+
+	sub := liveStreamSource.Subscribe(filter, liveStreamBuffer)
+	defer liveStreamSource.Unsubscribe(sub)
+
+	header := c.Writer.Header()
+	header.Set("Content-Type", "text/event-stream")
+	header.Set("Cache-Control", "no-cache")
+	header.Set("Connection", "keep-alive")
+	header.Set("X-Accel-Buffering", "no")
+	c.Status(http.StatusOK)
+	c.Writer.Flush()
+
+	keepAlive := time.NewTicker(liveStreamKeepAlive)
+	defer keepAlive.Stop()
+
+	for {
+		select {
+		case <-c.Request.Context().Done():
+			return
+		case <-liveStreamsClosed:
+			return
+		case <-keepAlive.C:
+			if dropped := sub.Dropped(); dropped > 0 {
+				fmt.Fprintf(c.Writer, "event: dropped\ndata: {\"count\":%d}\n\n", dropped)
+			} else {
+				fmt.Fprint(c.Writer, ": keep-alive\n\n")
+			}
+		case event, ok := <-sub.Events:
+			if !ok {
+				return
+			}
+			fmt.Fprintf(c.Writer, "event: %s\ndata: %s\n\n", event.Type, event.Message)
+			// send whatever else is waiting before flushing
+			for pending := len(sub.Events); pending > 0; pending-- {
+				event = <-sub.Events
+				fmt.Fprintf(c.Writer, "event: %s\ndata: %s\n\n", event.Type, event.Message)
+			}
+		}
+		c.Writer.Flush()
+	}
+}
+
+func parseStreamFilter(c *gin.Context) (webhooks.StreamFilter, error) {
+	var filter webhooks.StreamFilter
+
+	var err error
+	if filter.Types, err = webhooks.ParseWebhookTypes(splitQueryList(c, "types")); err != nil {
+		return filter, err
+	}
+
+	filter.Areas = parseStreamAreas(splitQueryList(c, "areas"))
+
+	var bounds [4]float64
+	given := 0
+	for i, key := range []string{"min_lat", "min_lon", "max_lat", "max_lon"} {
+		value := c.Query(key)
+		if value == "" {
+			continue
+		}
+		if bounds[i], err = strconv.ParseFloat(value, 64); err != nil {
+			return filter, fmt.Errorf("invalid %s: %s", key, value)
+		}
+		given++
+	}
+	switch given {
+	case 0:
+	case 4:
+		filter.Bounds = &geo.Bbox{MinLat: bounds[0], MinLon: bounds[1], MaxLat: bounds[2], MaxLon: bounds[3]}
+	default:
+		return filter, fmt.Errorf("a bounding box needs all of min_lat, min_lon, max_lat and max_lon")
+	}
+	return filter, nil
+}
+
+// parseStreamAreas translates "parent/name" or "name" area names, as used in
+// the webhooks config, to area names
+func parseStreamAreas(areas []string) []geo.AreaName {
+	var areaNames []geo.AreaName
+	for _, area := range areas {
+		parent, name, found := strings.Cut(area, "/")
+		if !found {
+			parent, name = "*", area
+		}
+		areaNames = append(areaNames, geo.AreaName{Parent: parent, Name: name})
+	}
+	return areaNames
+}
+
+// splitQueryList returns the comma separated values of a query parameter,
+// which may also be repeated
+func splitQueryList(c *gin.Context, key string) []string {
+	var values []string
+	for _, value := range c.QueryArray(key) {
+		for _, part := range strings.Split(value, ",") {
+			if part = strings.TrimSpace(part); part != "" {
+				values = append(values, part)
+			}
+		}
+	}
+	return values
+}
@@ -0,0 +1,58 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	"golbat/config"
+	"golbat/geo"
+	"golbat/webhooks"
+)
+
+func TestParseStreamFilter(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	c.Request = httptest.NewRequest(http.MethodGet,
+		"/api/stream?types=raid,pokemon_iv&areas=London/*&areas=Harrow&min_lat=51&min_lon=-1&max_lat=52&max_lon=1", nil)
+
+	filter, err := parseStreamFilter(c)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(filter.Types) != 2 || filter.Types[0] != webhooks.Raid || filter.Types[1] != webhooks.PokemonIV {
+		t.Errorf("types = %v", filter.Types)
+	}
+	wantAreas := []geo.AreaName{{Parent: "London", Name: "*"}, {Parent: "*", Name: "Harrow"}}
+	if len(filter.Areas) != 2 || filter.Areas[0] != wantAreas[0] || filter.Areas[1] != wantAreas[1] {
+		t.Errorf("areas = %v, want %v", filter.Areas, wantAreas)
+	}
+	if filter.Bounds == nil || *filter.Bounds != (geo.Bbox{MinLat: 51, MinLon: -1, MaxLat: 52, MaxLon: 1}) {
+		t.Errorf("bounds = %v", filter.Bounds)
+	}
+
+	for _, query := range []string{"types=wut", "min_lat=51", "min_lat=x&min_lon=1&max_lat=2&max_lon=3"} {
+		c.Request = httptest.NewRequest(http.MethodGet, "/api/stream?"+query, nil)
+		if _, err := parseStreamFilter(c); err == nil {
+			t.Errorf("%s accepted", query)
+		}
+	}
+}
+
+func TestLiveStreamRequiresSecret(t *testing.T) {
+	prev := config.Config.ApiSecret
+	config.Config.ApiSecret = "s3cret"
+	defer func() { config.Config.ApiSecret = prev }()
+
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.GET("/api/stream", LiveStream)
+
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/stream?secret=wrong", nil))
+	if rec.Code != http.StatusUnauthorized {
+		t.Errorf("got %d, want 401", rec.Code)
+	}
+}
@@ -0,0 +1,542 @@
+-- Schema of the PostgreSQL backend: the tables Golbat uses, as they stand
+-- after the MySQL migrations up to 54_station_battle. Schema changes from then
+-- on need a migration here as well as in sql/.
+--
+-- Unsigned MySQL columns take the next larger signed type, and booleans stay
+-- smallints so queries comparing them with 0 and 1 work unchanged.
+
+CREATE EXTENSION IF NOT EXISTS postgis;
+
+CREATE TABLE gym (
+  id VARCHAR(35) NOT NULL,
+  lat DOUBLE PRECISION NOT NULL,
+  lon DOUBLE PRECISION NOT NULL,
+  name VARCHAR(128) DEFAULT NULL,
+  url VARCHAR(200) DEFAULT NULL,
+  last_modified_timestamp BIGINT DEFAULT NULL,
+  raid_end_timestamp BIGINT DEFAULT NULL,
+  raid_spawn_timestamp BIGINT DEFAULT NULL,
+  raid_battle_timestamp BIGINT DEFAULT NULL,
+  updated BIGINT NOT NULL,
+  raid_pokemon_id INTEGER DEFAULT NULL,
+  guarding_pokemon_id INTEGER DEFAULT NULL,
+  guarding_pokemon_display TEXT DEFAULT NULL,
+  available_slots INTEGER DEFAULT NULL,
+  availble_slots INTEGER GENERATED ALWAYS AS (available_slots) STORED,
+  team_id SMALLINT DEFAULT NULL,
+  raid_level SMALLINT DEFAULT NULL,
+  enabled SMALLINT DEFAULT NULL,
+  ex_raid_eligible SMALLINT DEFAULT NULL,
+  in_battle SMALLINT DEFAULT NULL,
+  raid_pokemon_move_1 INTEGER DEFAULT NULL,
+  raid_pokemon_move_2 INTEGER DEFAULT NULL,
+  raid_pokemon_form INTEGER DEFAULT NULL,
+  raid_pokemon_cp BIGINT DEFAULT NULL,
+  raid_is_exclusive SMALLINT DEFAULT NULL,
+  cell_id BIGINT DEFAULT NULL,
+  deleted SMALLINT NOT NULL DEFAULT 0,
+  total_cp BIGINT DEFAULT NULL,
+  first_seen_timestamp BIGINT NOT NULL,
+  raid_pokemon_gender SMALLINT DEFAULT NULL,
+  sponsor_id INTEGER DEFAULT NULL,
+  partner_id VARCHAR(35) DEFAULT NULL,
+  raid_pokemon_costume INTEGER DEFAULT NULL,
+  raid_pokemon_evolution SMALLINT DEFAULT NULL,
+  ar_scan_eligible SMALLINT DEFAULT NULL,
+  power_up_level INTEGER DEFAULT NULL,
+  power_up_points BIGINT DEFAULT NULL,
+  power_up_end_timestamp BIGINT DEFAULT NULL,
+  description TEXT,
+  raid_pokemon_alignment INTEGER DEFAULT NULL,
+  defenders TEXT DEFAULT NULL,
+  rsvps TEXT DEFAULT NULL,
+  PRIMARY KEY (id)
+);
+
+CREATE TABLE incident (
+  id VARCHAR(35) NOT NULL,
+  pokestop_id VARCHAR(35) NOT NULL,
+  start BIGINT NOT NULL,
+  expiration BIGINT NOT NULL,
+  display_type INTEGER NOT NULL,
+  style INTEGER NOT NULL,
+  "character" INTEGER NOT NULL,
+  updated BIGINT NOT NULL,
+  confirmed SMALLINT NOT NULL DEFAULT 0,
+  slot_1_pokemon_id INTEGER,
+  slot_1_form INTEGER,
+  slot_2_pokemon_id INTEGER,
+  slot_2_form INTEGER,
+  slot_3_pokemon_id INTEGER,
+  slot_3_form INTEGER,
+  PRIMARY KEY (id)
+);
+CREATE INDEX ix_incident_expiration ON incident (expiration);
+CREATE INDEX ix_incident_pokestop ON incident (pokestop_id, expiration);
+
+CREATE TABLE invasion_stats (
+  date DATE NOT NULL,
+  area VARCHAR(255) NOT NULL DEFAULT '',
+  fence VARCHAR(255) NOT NULL DEFAULT '',
+  "character" INTEGER NOT NULL DEFAULT 0,
+  count INTEGER NOT NULL,
+  PRIMARY KEY (date, area, fence, "character")
+);
+
+CREATE TABLE player (
+  friendship_id VARCHAR(100) DEFAULT NULL,
+  name VARCHAR(20) NOT NULL,
+  last_seen INTEGER NOT NULL,
+  friend_code VARCHAR(12) DEFAULT NULL,
+  team SMALLINT DEFAULT NULL,
+  level SMALLINT DEFAULT NULL,
+  xp BIGINT DEFAULT NULL,
+  battles_won BIGINT DEFAULT NULL,
+  km_walked REAL DEFAULT NULL,
+  caught_pokemon BIGINT DEFAULT NULL,
+  gbl_rank SMALLINT DEFAULT NULL,
+  gbl_rating INTEGER DEFAULT NULL,
+  event_badges VARCHAR(500) DEFAULT NULL,
+  stops_spun BIGINT DEFAULT NULL,
+  evolved BIGINT DEFAULT NULL,
+  hatched BIGINT DEFAULT NULL,
+  quests BIGINT DEFAULT NULL,
+  trades BIGINT DEFAULT NULL,
+  photobombs BIGINT DEFAULT NULL,
+  purified BIGINT DEFAULT NULL,
+  grunts_defeated BIGINT DEFAULT NULL,
+  gym_battles_won BIGINT DEFAULT NULL,
+  normal_raids_won BIGINT DEFAULT NULL,
+  legendary_raids_won BIGINT DEFAULT NULL,
+  trainings_won BIGINT DEFAULT NULL,
+  berries_fed BIGINT DEFAULT NULL,
+  hours_defended BIGINT DEFAULT NULL,
+  best_friends BIGINT DEFAULT NULL,
+  best_buddies BIGINT DEFAULT NULL,
+  giovanni_defeated INTEGER DEFAULT NULL,
+  mega_evos BIGINT DEFAULT NULL,
+  collections_done INTEGER DEFAULT NULL,
+  vivillon SMALLINT DEFAULT NULL,
+  showcase_max_size_first_place BIGINT DEFAULT NULL,
+  total_route_play BIGINT DEFAULT NULL,
+  parties_completed BIGINT DEFAULT NULL,
+  event_check_ins BIGINT DEFAULT NULL,
+  unique_stops_spun BIGINT DEFAULT NULL,
+  unique_mega_evos BIGINT DEFAULT NULL,
+  unique_raid_bosses BIGINT DEFAULT NULL,
+  unique_unown SMALLINT DEFAULT NULL,
+  seven_day_streaks BIGINT DEFAULT NULL,
+  trade_km BIGINT DEFAULT NULL,
+  raids_with_friends BIGINT DEFAULT NULL,
+  caught_at_lure BIGINT DEFAULT NULL,
+  wayfarer_agreements BIGINT DEFAULT NULL,
+  trainers_referred BIGINT DEFAULT NULL,
+  raid_achievements BIGINT DEFAULT NULL,
+  xl_karps BIGINT DEFAULT NULL,
+  xs_rats BIGINT DEFAULT NULL,
+  tiny_pokemon_caught BIGINT DEFAULT NULL,
+  jumbo_pokemon_caught BIGINT DEFAULT NULL,
+  pikachu_caught BIGINT DEFAULT NULL,
+  league_great_won BIGINT DEFAULT NULL,
+  league_ultra_won BIGINT DEFAULT NULL,
+  league_master_won BIGINT DEFAULT NULL,
+  dex_gen1 SMALLINT DEFAULT NULL,
+  dex_gen2 SMALLINT DEFAULT NULL,
+  dex_gen3 SMALLINT DEFAULT NULL,
+  dex_gen4 SMALLINT DEFAULT NULL,
+  dex_gen5 SMALLINT DEFAULT NULL,
+  dex_gen6 SMALLINT DEFAULT NULL,
+  dex_gen7 SMALLINT DEFAULT NULL,
+  dex_gen8 SMALLINT DEFAULT NULL,
+  dex_gen8a SMALLINT DEFAULT NULL,
+  dex_gen9 SMALLINT DEFAULT NULL,
+  caught_normal BIGINT DEFAULT NULL,
+  caught_fighting BIGINT DEFAULT NULL,
+  caught_flying BIGINT DEFAULT NULL,
+  caught_poison BIGINT DEFAULT NULL,
+  caught_ground BIGINT DEFAULT NULL,
+  caught_rock BIGINT DEFAULT NULL,
+  caught_bug BIGINT DEFAULT NULL,
+  caught_ghost BIGINT DEFAULT NULL,
+  caught_steel BIGINT DEFAULT NULL,
+  caught_fire BIGINT DEFAULT NULL,
+  caught_water BIGINT DEFAULT NULL,
+  caught_grass BIGINT DEFAULT NULL,
+  caught_electric BIGINT DEFAULT NULL,
+  caught_psychic BIGINT DEFAULT NULL,
+  caught_ice BIGINT DEFAULT NULL,
+  caught_dragon BIGINT DEFAULT NULL,
+  caught_dark BIGINT DEFAULT NULL,
+  caught_fairy BIGINT DEFAULT NULL,
+  PRIMARY KEY (name)
+);
+CREATE UNIQUE INDEX ix_player_friend_code ON player (friend_code);
+CREATE INDEX ix_player_id ON player (friendship_id);
+
+CREATE TABLE pokemon (
+  id VARCHAR(25) NOT NULL,
+  pokestop_id VARCHAR(35) DEFAULT NULL,
+  spawn_id BIGINT DEFAULT NULL,
+  lat DOUBLE PRECISION NOT NULL,
+  lon DOUBLE PRECISION NOT NULL,
+  weight DOUBLE PRECISION DEFAULT NULL,
+  height DOUBLE PRECISION,
+  size SMALLINT,
+  expire_timestamp BIGINT DEFAULT NULL,
+  updated BIGINT DEFAULT NULL,
+  pokemon_id INTEGER NOT NULL,
+  move_1 INTEGER DEFAULT NULL,
+  move_2 INTEGER DEFAULT NULL,
+  gender SMALLINT DEFAULT NULL,
+  cp INTEGER DEFAULT NULL,
+  atk_iv SMALLINT DEFAULT NULL,
+  def_iv SMALLINT DEFAULT NULL,
+  sta_iv SMALLINT DEFAULT NULL,
+  golbat_internal BYTEA DEFAULT NULL,
+  form INTEGER DEFAULT NULL,
+  level SMALLINT DEFAULT NULL,
+  strong SMALLINT DEFAULT NULL,
+  weather SMALLINT DEFAULT NULL,
+  costume SMALLINT DEFAULT NULL,
+  first_seen_timestamp BIGINT NOT NULL,
+  changed BIGINT NOT NULL DEFAULT 0,
+  cell_id BIGINT DEFAULT NULL,
+  expire_timestamp_verified SMALLINT NOT NULL,
+  display_pokemon_id INTEGER DEFAULT NULL,
+  display_pokemon_form INTEGER DEFAULT NULL,
+  is_ditto SMALLINT NOT NULL DEFAULT 0,
+  seen_type TEXT,
+  shiny SMALLINT DEFAULT 0,
+  username VARCHAR(64),
+  capture_1 DOUBLE PRECISION DEFAULT NULL,
+  capture_2 DOUBLE PRECISION DEFAULT NULL,
+  capture_3 DOUBLE PRECISION DEFAULT NULL,
+  pvp TEXT,
+  is_event SMALLINT NOT NULL DEFAULT 0,
+  iv REAL DEFAULT NULL,
+  PRIMARY KEY (id)
+);
+CREATE INDEX ix_pokemon_coords ON pokemon (lat, lon);
+CREATE INDEX ix_pokemon_expire_timestamp_verified ON pokemon (expire_timestamp_verified, expire_timestamp);
+CREATE INDEX ix_pokemon_iv ON pokemon (iv);
+CREATE INDEX ix_pokemon_id ON pokemon (pokemon_id);
+
+CREATE TABLE pokemon_area_stats (
+  datetime INTEGER NOT NULL,
+  area VARCHAR(255) NOT NULL,
+  fence VARCHAR(255) NOT NULL,
+  totMon INTEGER DEFAULT NULL,
+  ivMon INTEGER DEFAULT NULL,
+  verifiedEnc INTEGER DEFAULT NULL,
+  unverifiedEnc INTEGER DEFAULT NULL,
+  verifiedReEnc INTEGER DEFAULT NULL,
+  encSecLeft INTEGER DEFAULT NULL,
+  encTthMax5 INTEGER DEFAULT NULL,
+  encTth5to10 INTEGER DEFAULT NULL,
+  encTth10to15 INTEGER DEFAULT NULL,
+  encTth15to20 INTEGER DEFAULT NULL,
+  encTth20to25 INTEGER DEFAULT NULL,
+  encTth25to30 INTEGER DEFAULT NULL,
+  encTth30to35 INTEGER DEFAULT NULL,
+  encTth35to40 INTEGER DEFAULT NULL,
+  encTth40to45 INTEGER DEFAULT NULL,
+  encTth45to50 INTEGER DEFAULT NULL,
+  encTth50to55 INTEGER DEFAULT NULL,
+  encTthMin55 INTEGER DEFAULT NULL,
+  resetMon INTEGER DEFAULT NULL,
+  re_encSecLeft INTEGER DEFAULT NULL,
+  numWiEnc INTEGER DEFAULT NULL,
+  secWiEnc INTEGER DEFAULT NULL,
+  PRIMARY KEY (datetime, area, fence)
+);
+
+CREATE TABLE pokemon_hundo_stats (
+  date DATE NOT NULL,
+  area VARCHAR(255) NOT NULL DEFAULT '',
+  fence VARCHAR(255) NOT NULL DEFAULT '',
+  pokemon_id INTEGER NOT NULL,
+  form_id INTEGER NOT NULL,
+  count INTEGER NOT NULL,
+  PRIMARY KEY (date, area, fence, pokemon_id, form_id)
+);
+
+CREATE TABLE pokemon_iv_stats (
+  date DATE NOT NULL,
+  area VARCHAR(255) NOT NULL DEFAULT '',
+  fence VARCHAR(255) NOT NULL DEFAULT '',
+  pokemon_id INTEGER NOT NULL,
+  form_id INTEGER NOT NULL,
+  count INTEGER NOT NULL,
+  PRIMARY KEY (date, area, fence, pokemon_id, form_id)
+);
+
+CREATE TABLE pokemon_nundo_stats (
+  date DATE NOT NULL,
+  area VARCHAR(255) NOT NULL,
+  fence VARCHAR(255) NOT NULL,
+  pokemon_id INTEGER NOT NULL,
+  form_id INTEGER NOT NULL,
+  count INTEGER NOT NULL,
+  PRIMARY KEY (date, area, fence, pokemon_id, form_id)
+);
+
+CREATE TABLE pokemon_shiny_stats (
+  date DATE NOT NULL,
+  area VARCHAR(255) NOT NULL DEFAULT '',
+  fence VARCHAR(255) NOT NULL DEFAULT '',
+  pokemon_id INTEGER NOT NULL,
+  form_id INTEGER NOT NULL,
+  count INTEGER NOT NULL,
+  total INTEGER NOT NULL DEFAULT 0,
+  PRIMARY KEY (date, area, fence, pokemon_id, form_id)
+);
+
+CREATE TABLE pokemon_stats (
+  date DATE NOT NULL,
+  area VARCHAR(255) NOT NULL DEFAULT '',
+  fence VARCHAR(255) NOT NULL DEFAULT '',
+  pokemon_id INTEGER NOT NULL,
+  form_id INTEGER NOT NULL,
+  count INTEGER NOT NULL,
+  PRIMARY KEY (date, area, fence, pokemon_id, form_id)
+);
+
+CREATE TABLE pokestop (
+  id VARCHAR(35) NOT NULL,
+  lat DOUBLE PRECISION NOT NULL,
+  lon DOUBLE PRECISION NOT NULL,
+  name VARCHAR(128) DEFAULT NULL,
+  url VARCHAR(200) DEFAULT NULL,
+  lure_expire_timestamp BIGINT DEFAULT NULL,
+  last_modified_timestamp BIGINT DEFAULT NULL,
+  updated BIGINT NOT NULL,
+  enabled SMALLINT DEFAULT NULL,
+  quest_type BIGINT DEFAULT NULL,
+  quest_timestamp BIGINT DEFAULT NULL,
+  quest_target INTEGER DEFAULT NULL,
+  quest_conditions TEXT,
+  quest_rewards TEXT,
+  quest_template VARCHAR(100) DEFAULT NULL,
+  quest_title VARCHAR(100) DEFAULT NULL,
+  cell_id BIGINT DEFAULT NULL,
+  deleted SMALLINT NOT NULL DEFAULT 0,
+  lure_id SMALLINT DEFAULT 0,
+  first_seen_timestamp BIGINT NOT NULL,
+  sponsor_id INTEGER DEFAULT NULL,
+  partner_id VARCHAR(35) DEFAULT NULL,
+  ar_scan_eligible SMALLINT DEFAULT NULL,
+  power_up_level INTEGER DEFAULT NULL,
+  power_up_points BIGINT DEFAULT NULL,
+  power_up_end_timestamp BIGINT DEFAULT NULL,
+  alternative_quest_type BIGINT DEFAULT NULL,
+  alternative_quest_timestamp BIGINT DEFAULT NULL,
+  alternative_quest_target INTEGER DEFAULT NULL,
+  alternative_quest_conditions TEXT,
+  alternative_quest_rewards TEXT,
+  alternative_quest_template VARCHAR(100) DEFAULT NULL,
+  alternative_quest_title VARCHAR(100) DEFAULT NULL,
+  quest_expiry BIGINT,
+  alternative_quest_expiry BIGINT,
+  description TEXT,
+  showcase_pokemon_id INTEGER DEFAULT NULL,
+  showcase_pokemon_form_id INTEGER DEFAULT NULL,
+  showcase_pokemon_type_id INTEGER DEFAULT NULL,
+  showcase_focus TEXT DEFAULT NULL,
+  showcase_ranking_standard SMALLINT DEFAULT NULL,
+  showcase_expiry BIGINT DEFAULT NULL,
+  showcase_rankings TEXT DEFAULT NULL,
+  quest_reward_type INTEGER DEFAULT NULL,
+  quest_item_id INTEGER DEFAULT NULL,
+  quest_reward_amount INTEGER DEFAULT NULL,
+  quest_pokemon_id INTEGER DEFAULT NULL,
+  quest_pokemon_form_id INTEGER DEFAULT NULL,
+  alternative_quest_reward_type INTEGER DEFAULT NULL,
+  alternative_quest_item_id INTEGER DEFAULT NULL,
+  alternative_quest_reward_amount INTEGER DEFAULT NULL,
+  alternative_quest_pokemon_id INTEGER DEFAULT NULL,
+  alternative_quest_pokemon_form_id INTEGER DEFAULT NULL,
+  PRIMARY KEY (id)
+);
+CREATE INDEX ix_pokestop_cell_id ON pokestop (cell_id);
+CREATE INDEX ix_pokestop_alternative_quest_expiry ON pokestop (alternative_quest_expiry);
+CREATE INDEX ix_pokestop_coords ON pokestop (lat, lon);
+-- matches the point geofence queries build, so ST_Contains can use it
+CREATE INDEX ix_pokestop_location ON pokestop USING GIST (ST_SetSRID(ST_MakePoint(lon, lat), 4326));
+CREATE INDEX ix_pokestop_lure_expire_timestamp ON pokestop (lure_expire_timestamp);
+CREATE INDEX ix_pokestop_old_forts ON pokestop (cell_id, deleted, updated);
+CREATE INDEX ix_pokestop_deleted ON pokestop (deleted);
+CREATE INDEX ix_pokestop_quest_expiry ON pokestop (quest_expiry);
+CREATE INDEX ix_pokestop_updated ON pokestop (updated);
+
+CREATE TABLE quest_stats (
+  date DATE NOT NULL,
+  area VARCHAR(255) NOT NULL DEFAULT '',
+  fence VARCHAR(255) NOT NULL DEFAULT '',
+  reward_type INTEGER NOT NULL DEFAULT 0,
+  pokemon_id INTEGER NOT NULL DEFAULT 0,
+  item_id INTEGER NOT NULL DEFAULT 0,
+  item_amount INTEGER NOT NULL,
+  count INTEGER NOT NULL,
+  PRIMARY KEY (date, area, fence, reward_type, pokemon_id, item_id, item_amount)
+);
+
+CREATE TABLE raid_stats (
+  date DATE NOT NULL,
+  area VARCHAR(255) NOT NULL DEFAULT '',
+  fence VARCHAR(255) NOT NULL DEFAULT '',
+  level INTEGER NOT NULL,
+  pokemon_id INTEGER NOT NULL,
+  form_id INTEGER NOT NULL DEFAULT 0,
+  temp_evo_id INTEGER NOT NULL DEFAULT 0,
+  count INTEGER NOT NULL,
+  PRIMARY KEY (date, area, fence, pokemon_id, form_id, temp_evo_id, level)
+);
+
+CREATE TABLE route (
+  id VARCHAR(35) NOT NULL,
+  name VARCHAR(50) NOT NULL,
+  shortcode VARCHAR(255) NOT NULL DEFAULT '',
+  description VARCHAR(255) NOT NULL,
+  distance_meters BIGINT NOT NULL,
+  duration_seconds BIGINT NOT NULL,
+  start_fort_id VARCHAR(35) NOT NULL,
+  start_image VARCHAR(200) NOT NULL,
+  start_lat DOUBLE PRECISION NOT NULL,
+  start_lon DOUBLE PRECISION NOT NULL,
+  end_fort_id VARCHAR(35) NOT NULL,
+  end_image VARCHAR(200) NOT NULL,
+  end_lat DOUBLE PRECISION NOT NULL,
+  end_lon DOUBLE PRECISION NOT NULL,
+  image VARCHAR(200) NOT NULL,
+  image_border_color VARCHAR(10) NOT NULL,
+  reversible SMALLINT NOT NULL,
+  tags TEXT DEFAULT NULL,
+  type SMALLINT NOT NULL,
+  updated BIGINT NOT NULL,
+  version BIGINT NOT NULL,
+  waypoints TEXT NOT NULL,
+  PRIMARY KEY (id)
+);
+CREATE INDEX ix_route_coords_end ON route (end_lat, end_lon);
+CREATE INDEX ix_route_coords_start ON route (start_lat, start_lon);
+
+CREATE TABLE s2cell (
+  -- numeric, as s2 cell ids outgrow a signed 64 bit integer
+  id NUMERIC(20) NOT NULL,
+  level SMALLINT DEFAULT NULL,
+  center_lat DOUBLE PRECISION NOT NULL DEFAULT 0,
+  center_lon DOUBLE PRECISION NOT NULL DEFAULT 0,
+  updated BIGINT NOT NULL,
+  PRIMARY KEY (id)
+);
+CREATE INDEX ix_s2cell_coords ON s2cell (center_lat, center_lon);
+CREATE INDEX ix_s2cell_updated ON s2cell (updated);
+
+CREATE TABLE spawnpoint (
+  id BIGINT NOT NULL,
+  lat DOUBLE PRECISION NOT NULL,
+  lon DOUBLE PRECISION NOT NULL,
+  updated BIGINT NOT NULL DEFAULT 0,
+  last_seen BIGINT NOT NULL DEFAULT 0,
+  despawn_sec INTEGER DEFAULT NULL,
+  first_seen INTEGER NOT NULL DEFAULT CAST(FLOOR(EXTRACT(EPOCH FROM NOW())) AS BIGINT),
+  PRIMARY KEY (id)
+);
+CREATE INDEX ix_spawnpoint_coords ON spawnpoint (lat, lon);
+CREATE INDEX ix_spawnpoint_last_seen ON spawnpoint (last_seen);
+CREATE INDEX ix_spawnpoint_updated ON spawnpoint (updated);
+
+CREATE TABLE station (
+  id VARCHAR(35) NOT NULL,
+  lat DOUBLE PRECISION NOT NULL,
+  lon DOUBLE PRECISION NOT NULL,
+  name VARCHAR(256) NOT NULL,
+  cell_id BIGINT NOT NULL,
+  start_time BIGINT NOT NULL,
+  end_time BIGINT NOT NULL,
+  cooldown_complete BIGINT NOT NULL,
+  is_battle_available SMALLINT NOT NULL,
+  is_inactive SMALLINT NOT NULL,
+  updated BIGINT NOT NULL,
+  battle_level SMALLINT DEFAULT NULL,
+  battle_start BIGINT,
+  battle_end BIGINT,
+  battle_pokemon_id INTEGER,
+  battle_pokemon_form INTEGER,
+  battle_pokemon_costume INTEGER,
+  battle_pokemon_gender SMALLINT,
+  battle_pokemon_alignment INTEGER,
+  battle_pokemon_bread_mode INTEGER,
+  battle_pokemon_move_1 INTEGER,
+  battle_pokemon_move_2 INTEGER,
+  battle_pokemon_stamina BIGINT,
+  battle_pokemon_cp_multiplier REAL,
+  total_stationed_pokemon INTEGER DEFAULT NULL,
+  total_stationed_gmax INTEGER,
+  stationed_pokemon TEXT DEFAULT NULL,
+  PRIMARY KEY (id)
+);
+CREATE INDEX ix_station_cell_id ON station (cell_id);
+CREATE INDEX ix_station_battle_pokemon_id ON station (battle_pokemon_id);
+CREATE INDEX ix_station_coords ON station (lat, lon);
+CREATE INDEX ix_station_end_time ON station (end_time);
+CREATE INDEX ix_station_updated ON station (updated);
+
+CREATE TABLE station_battle (
+  bread_battle_seed BIGINT NOT NULL,
+  station_id VARCHAR(35) NOT NULL,
+  battle_level SMALLINT NOT NULL,
+  battle_start BIGINT NOT NULL,
+  battle_end BIGINT NOT NULL,
+  battle_pokemon_id INTEGER DEFAULT NULL,
+  battle_pokemon_form INTEGER DEFAULT NULL,
+  battle_pokemon_costume INTEGER DEFAULT NULL,
+  battle_pokemon_gender SMALLINT DEFAULT NULL,
+  battle_pokemon_alignment INTEGER DEFAULT NULL,
+  battle_pokemon_bread_mode INTEGER DEFAULT NULL,
+  battle_pokemon_move_1 INTEGER DEFAULT NULL,
+  battle_pokemon_move_2 INTEGER DEFAULT NULL,
+  battle_pokemon_stamina BIGINT DEFAULT NULL,
+  battle_pokemon_cp_multiplier REAL DEFAULT NULL,
+  updated BIGINT NOT NULL,
+  PRIMARY KEY (bread_battle_seed)
+);
+CREATE INDEX ix_station_battle_end ON station_battle (battle_end);
+CREATE INDEX ix_station_battle_station_end ON station_battle (station_id, battle_end);
+
+CREATE TABLE tappable (
+  id VARCHAR(25) NOT NULL,
+  lat DOUBLE PRECISION NOT NULL,
+  lon DOUBLE PRECISION NOT NULL,
+  fort_id VARCHAR(35) DEFAULT NULL,
+  spawn_id BIGINT,
+  type VARCHAR(50) NOT NULL,
+  pokemon_id INTEGER DEFAULT NULL,
+  item_id INTEGER DEFAULT NULL,
+  count INTEGER DEFAULT NULL,
+  expire_timestamp_verified SMALLINT NOT NULL,
+  expire_timestamp BIGINT DEFAULT NULL,
+  updated BIGINT NOT NULL,
+  PRIMARY KEY (id)
+);
+CREATE INDEX ix_tappable_coords ON tappable (lat, lon);
+CREATE INDEX ix_tappable_expire_timestamp ON tappable (expire_timestamp, expire_timestamp_verified);
+
+CREATE TABLE weather (
+  id BIGINT NOT NULL,
+  level SMALLINT DEFAULT NULL,
+  latitude DOUBLE PRECISION NOT NULL DEFAULT 0,
+  longitude DOUBLE PRECISION NOT NULL DEFAULT 0,
+  gameplay_condition SMALLINT DEFAULT NULL,
+  wind_direction INTEGER DEFAULT NULL,
+  cloud_level SMALLINT DEFAULT NULL,
+  rain_level SMALLINT DEFAULT NULL,
+  wind_level SMALLINT DEFAULT NULL,
+  snow_level SMALLINT DEFAULT NULL,
+  fog_level SMALLINT DEFAULT NULL,
+  special_effect_level SMALLINT DEFAULT NULL,
+  severity SMALLINT DEFAULT NULL,
+  warn_weather SMALLINT DEFAULT NULL,
+  updated BIGINT NOT NULL,
+  PRIMARY KEY (id)
+);
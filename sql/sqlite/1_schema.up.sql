@@ -0,0 +1,535 @@
+-- Schema of the SQLite backend: the tables Golbat uses, as they stand after
+-- the MySQL migrations up to 54_station_battle. Schema changes from then on
+-- need a migration here as well as in sql/.
+
+CREATE TABLE gym (
+  id TEXT NOT NULL,
+  lat REAL NOT NULL,
+  lon REAL NOT NULL,
+  name TEXT DEFAULT NULL,
+  url TEXT DEFAULT NULL,
+  last_modified_timestamp INTEGER DEFAULT NULL,
+  raid_end_timestamp INTEGER DEFAULT NULL,
+  raid_spawn_timestamp INTEGER DEFAULT NULL,
+  raid_battle_timestamp INTEGER DEFAULT NULL,
+  updated INTEGER NOT NULL,
+  raid_pokemon_id INTEGER DEFAULT NULL,
+  guarding_pokemon_id INTEGER DEFAULT NULL,
+  guarding_pokemon_display TEXT DEFAULT NULL,
+  available_slots INTEGER DEFAULT NULL,
+  availble_slots INTEGER GENERATED ALWAYS AS (available_slots) VIRTUAL,
+  team_id INTEGER DEFAULT NULL,
+  raid_level INTEGER DEFAULT NULL,
+  enabled INTEGER DEFAULT NULL,
+  ex_raid_eligible INTEGER DEFAULT NULL,
+  in_battle INTEGER DEFAULT NULL,
+  raid_pokemon_move_1 INTEGER DEFAULT NULL,
+  raid_pokemon_move_2 INTEGER DEFAULT NULL,
+  raid_pokemon_form INTEGER DEFAULT NULL,
+  raid_pokemon_cp INTEGER DEFAULT NULL,
+  raid_is_exclusive INTEGER DEFAULT NULL,
+  cell_id INTEGER DEFAULT NULL,
+  deleted INTEGER NOT NULL DEFAULT 0,
+  total_cp INTEGER DEFAULT NULL,
+  first_seen_timestamp INTEGER NOT NULL,
+  raid_pokemon_gender INTEGER DEFAULT NULL,
+  sponsor_id INTEGER DEFAULT NULL,
+  partner_id TEXT DEFAULT NULL,
+  raid_pokemon_costume INTEGER DEFAULT NULL,
+  raid_pokemon_evolution INTEGER DEFAULT NULL,
+  ar_scan_eligible INTEGER DEFAULT NULL,
+  power_up_level INTEGER DEFAULT NULL,
+  power_up_points INTEGER DEFAULT NULL,
+  power_up_end_timestamp INTEGER DEFAULT NULL,
+  description TEXT,
+  raid_pokemon_alignment INTEGER DEFAULT NULL,
+  defenders TEXT DEFAULT NULL,
+  rsvps TEXT DEFAULT NULL,
+  PRIMARY KEY (id)
+);
+
+CREATE TABLE incident (
+  id TEXT NOT NULL,
+  pokestop_id TEXT NOT NULL,
+  start INTEGER NOT NULL,
+  expiration INTEGER NOT NULL,
+  display_type INTEGER NOT NULL,
+  style INTEGER NOT NULL,
+  "character" INTEGER NOT NULL,
+  updated INTEGER NOT NULL,
+  confirmed INTEGER NOT NULL DEFAULT 0,
+  slot_1_pokemon_id INTEGER,
+  slot_1_form INTEGER,
+  slot_2_pokemon_id INTEGER,
+  slot_2_form INTEGER,
+  slot_3_pokemon_id INTEGER,
+  slot_3_form INTEGER,
+  PRIMARY KEY (id)
+);
+CREATE INDEX ix_incident_expiration ON incident (expiration);
+CREATE INDEX ix_incident_pokestop ON incident (pokestop_id,expiration);
+
+CREATE TABLE invasion_stats (
+  date TEXT NOT NULL,
+  area TEXT NOT NULL DEFAULT '',
+  fence TEXT NOT NULL DEFAULT '',
+  "character" INTEGER NOT NULL DEFAULT 0,
+  count INTEGER NOT NULL,
+  PRIMARY KEY (date,area,fence,"character")
+);
+
+CREATE TABLE player (
+  friendship_id TEXT DEFAULT NULL,
+  name TEXT NOT NULL,
+  last_seen INTEGER NOT NULL,
+  friend_code TEXT DEFAULT NULL,
+  team INTEGER DEFAULT NULL,
+  level INTEGER DEFAULT NULL,
+  xp INTEGER DEFAULT NULL,
+  battles_won INTEGER DEFAULT NULL,
+  km_walked REAL DEFAULT NULL,
+  caught_pokemon INTEGER DEFAULT NULL,
+  gbl_rank INTEGER DEFAULT NULL,
+  gbl_rating INTEGER DEFAULT NULL,
+  event_badges TEXT DEFAULT NULL,
+  stops_spun INTEGER DEFAULT NULL,
+  evolved INTEGER DEFAULT NULL,
+  hatched INTEGER DEFAULT NULL,
+  quests INTEGER DEFAULT NULL,
+  trades INTEGER DEFAULT NULL,
+  photobombs INTEGER DEFAULT NULL,
+  purified INTEGER DEFAULT NULL,
+  grunts_defeated INTEGER DEFAULT NULL,
+  gym_battles_won INTEGER DEFAULT NULL,
+  normal_raids_won INTEGER DEFAULT NULL,
+  legendary_raids_won INTEGER DEFAULT NULL,
+  trainings_won INTEGER DEFAULT NULL,
+  berries_fed INTEGER DEFAULT NULL,
+  hours_defended INTEGER DEFAULT NULL,
+  best_friends INTEGER DEFAULT NULL,
+  best_buddies INTEGER DEFAULT NULL,
+  giovanni_defeated INTEGER DEFAULT NULL,
+  mega_evos INTEGER DEFAULT NULL,
+  collections_done INTEGER DEFAULT NULL,
+  vivillon INTEGER DEFAULT NULL,
+  showcase_max_size_first_place INTEGER DEFAULT NULL,
+  total_route_play INTEGER DEFAULT NULL,
+  parties_completed INTEGER DEFAULT NULL,
+  event_check_ins INTEGER DEFAULT NULL,
+  unique_stops_spun INTEGER DEFAULT NULL,
+  unique_mega_evos INTEGER DEFAULT NULL,
+  unique_raid_bosses INTEGER DEFAULT NULL,
+  unique_unown INTEGER DEFAULT NULL,
+  seven_day_streaks INTEGER DEFAULT NULL,
+  trade_km INTEGER DEFAULT NULL,
+  raids_with_friends INTEGER DEFAULT NULL,
+  caught_at_lure INTEGER DEFAULT NULL,
+  wayfarer_agreements INTEGER DEFAULT NULL,
+  trainers_referred INTEGER DEFAULT NULL,
+  raid_achievements INTEGER DEFAULT NULL,
+  xl_karps INTEGER DEFAULT NULL,
+  xs_rats INTEGER DEFAULT NULL,
+  tiny_pokemon_caught INTEGER DEFAULT NULL,
+  jumbo_pokemon_caught INTEGER DEFAULT NULL,
+  pikachu_caught INTEGER DEFAULT NULL,
+  league_great_won INTEGER DEFAULT NULL,
+  league_ultra_won INTEGER DEFAULT NULL,
+  league_master_won INTEGER DEFAULT NULL,
+  dex_gen1 INTEGER DEFAULT NULL,
+  dex_gen2 INTEGER DEFAULT NULL,
+  dex_gen3 INTEGER DEFAULT NULL,
+  dex_gen4 INTEGER DEFAULT NULL,
+  dex_gen5 INTEGER DEFAULT NULL,
+  dex_gen6 INTEGER DEFAULT NULL,
+  dex_gen7 INTEGER DEFAULT NULL,
+  dex_gen8 INTEGER DEFAULT NULL,
+  dex_gen8a INTEGER DEFAULT NULL,
+  dex_gen9 INTEGER DEFAULT NULL,
+  caught_normal INTEGER DEFAULT NULL,
+  caught_fighting INTEGER DEFAULT NULL,
+  caught_flying INTEGER DEFAULT NULL,
+  caught_poison INTEGER DEFAULT NULL,
+  caught_ground INTEGER DEFAULT NULL,
+  caught_rock INTEGER DEFAULT NULL,
+  caught_bug INTEGER DEFAULT NULL,
+  caught_ghost INTEGER DEFAULT NULL,
+  caught_steel INTEGER DEFAULT NULL,
+  caught_fire INTEGER DEFAULT NULL,
+  caught_water INTEGER DEFAULT NULL,
+  caught_grass INTEGER DEFAULT NULL,
+  caught_electric INTEGER DEFAULT NULL,
+  caught_psychic INTEGER DEFAULT NULL,
+  caught_ice INTEGER DEFAULT NULL,
+  caught_dragon INTEGER DEFAULT NULL,
+  caught_dark INTEGER DEFAULT NULL,
+  caught_fairy INTEGER DEFAULT NULL,
+  PRIMARY KEY (name)
+);
+CREATE UNIQUE INDEX ix_player_friend_code ON player (friend_code);
+CREATE INDEX ix_player_id ON player (friendship_id);
+
+CREATE TABLE pokemon (
+  id TEXT NOT NULL,
+  pokestop_id TEXT DEFAULT NULL,
+  spawn_id INTEGER DEFAULT NULL,
+  lat REAL NOT NULL,
+  lon REAL NOT NULL,
+  weight REAL DEFAULT NULL,
+  height REAL,
+  size INTEGER,
+  expire_timestamp INTEGER DEFAULT NULL,
+  updated INTEGER DEFAULT NULL,
+  pokemon_id INTEGER NOT NULL,
+  move_1 INTEGER DEFAULT NULL,
+  move_2 INTEGER DEFAULT NULL,
+  gender INTEGER DEFAULT NULL,
+  cp INTEGER DEFAULT NULL,
+  atk_iv INTEGER DEFAULT NULL,
+  def_iv INTEGER DEFAULT NULL,
+  sta_iv INTEGER DEFAULT NULL,
+  golbat_internal BLOB DEFAULT NULL,
+  form INTEGER DEFAULT NULL,
+  level INTEGER DEFAULT NULL,
+  strong INTEGER DEFAULT NULL,
+  weather INTEGER DEFAULT NULL,
+  costume INTEGER DEFAULT NULL,
+  first_seen_timestamp INTEGER NOT NULL,
+  changed INTEGER NOT NULL DEFAULT 0,
+  cell_id INTEGER DEFAULT NULL,
+  expire_timestamp_verified INTEGER NOT NULL,
+  display_pokemon_id INTEGER DEFAULT NULL,
+  display_pokemon_form INTEGER DEFAULT NULL,
+  is_ditto INTEGER NOT NULL DEFAULT 0,
+  seen_type TEXT,
+  shiny INTEGER DEFAULT 0,
+  username TEXT,
+  capture_1 REAL DEFAULT NULL,
+  capture_2 REAL DEFAULT NULL,
+  capture_3 REAL DEFAULT NULL,
+  pvp TEXT,
+  is_event INTEGER NOT NULL DEFAULT 0,
+  iv REAL DEFAULT NULL,
+  PRIMARY KEY (id)
+);
+CREATE INDEX ix_pokemon_coords ON pokemon (lat,lon);
+CREATE INDEX ix_pokemon_expire_timestamp_verified ON pokemon (expire_timestamp_verified,expire_timestamp);
+CREATE INDEX ix_pokemon_iv ON pokemon (iv);
+CREATE INDEX ix_pokemon_id ON pokemon (pokemon_id);
+
+CREATE TABLE pokemon_area_stats (
+  datetime INTEGER NOT NULL,
+  area TEXT NOT NULL,
+  fence TEXT NOT NULL,
+  totMon INTEGER DEFAULT NULL,
+  ivMon INTEGER DEFAULT NULL,
+  verifiedEnc INTEGER DEFAULT NULL,
+  unverifiedEnc INTEGER DEFAULT NULL,
+  verifiedReEnc INTEGER DEFAULT NULL,
+  encSecLeft INTEGER DEFAULT NULL,
+  encTthMax5 INTEGER DEFAULT NULL,
+  encTth5to10 INTEGER DEFAULT NULL,
+  encTth10to15 INTEGER DEFAULT NULL,
+  encTth15to20 INTEGER DEFAULT NULL,
+  encTth20to25 INTEGER DEFAULT NULL,
+  encTth25to30 INTEGER DEFAULT NULL,
+  encTth30to35 INTEGER DEFAULT NULL,
+  encTth35to40 INTEGER DEFAULT NULL,
+  encTth40to45 INTEGER DEFAULT NULL,
+  encTth45to50 INTEGER DEFAULT NULL,
+  encTth50to55 INTEGER DEFAULT NULL,
+  encTthMin55 INTEGER DEFAULT NULL,
+  resetMon INTEGER DEFAULT NULL,
+  re_encSecLeft INTEGER DEFAULT NULL,
+  numWiEnc INTEGER DEFAULT NULL,
+  secWiEnc INTEGER DEFAULT NULL,
+  PRIMARY KEY (datetime,area,fence)
+);
+
+CREATE TABLE pokemon_hundo_stats (
+  date TEXT NOT NULL,
+  area TEXT NOT NULL DEFAULT '',
+  fence TEXT NOT NULL DEFAULT '',
+  pokemon_id INTEGER NOT NULL,
+  form_id INTEGER NOT NULL,
+  count INTEGER NOT NULL,
+  PRIMARY KEY (date,area,fence,pokemon_id,form_id)
+);
+
+CREATE TABLE pokemon_iv_stats (
+  date TEXT NOT NULL,
+  area TEXT NOT NULL DEFAULT '',
+  fence TEXT NOT NULL DEFAULT '',
+  pokemon_id INTEGER NOT NULL,
+  form_id INTEGER NOT NULL,
+  count INTEGER NOT NULL,
+  PRIMARY KEY (date,area,fence,pokemon_id,form_id)
+);
+
+CREATE TABLE pokemon_nundo_stats (
+  date TEXT NOT NULL,
+  area TEXT NOT NULL,
+  fence TEXT NOT NULL,
+  pokemon_id INTEGER NOT NULL,
+  form_id INTEGER NOT NULL,
+  count INTEGER NOT NULL,
+  PRIMARY KEY (date,area,fence,pokemon_id,form_id)
+);
+
+CREATE TABLE pokemon_shiny_stats (
+  date TEXT NOT NULL,
+  area TEXT NOT NULL DEFAULT '',
+  fence TEXT NOT NULL DEFAULT '',
+  pokemon_id INTEGER NOT NULL,
+  form_id INTEGER NOT NULL,
+  count INTEGER NOT NULL,
+  total INTEGER NOT NULL DEFAULT 0,
+  PRIMARY KEY (date,area,fence,pokemon_id,form_id)
+);
+
+CREATE TABLE pokemon_stats (
+  date TEXT NOT NULL,
+  area TEXT NOT NULL DEFAULT '',
+  fence TEXT NOT NULL DEFAULT '',
+  pokemon_id INTEGER NOT NULL,
+  form_id INTEGER NOT NULL,
+  count INTEGER NOT NULL,
+  PRIMARY KEY (date,area,fence,pokemon_id,form_id)
+);
+
+CREATE TABLE pokestop (
+  id TEXT NOT NULL,
+  lat REAL NOT NULL,
+  lon REAL NOT NULL,
+  name TEXT DEFAULT NULL,
+  url TEXT DEFAULT NULL,
+  lure_expire_timestamp INTEGER DEFAULT NULL,
+  last_modified_timestamp INTEGER DEFAULT NULL,
+  updated INTEGER NOT NULL,
+  enabled INTEGER DEFAULT NULL,
+  quest_type INTEGER DEFAULT NULL,
+  quest_timestamp INTEGER DEFAULT NULL,
+  quest_target INTEGER DEFAULT NULL,
+  quest_conditions TEXT,
+  quest_rewards TEXT,
+  quest_template TEXT DEFAULT NULL,
+  quest_title TEXT DEFAULT NULL,
+  cell_id INTEGER DEFAULT NULL,
+  deleted INTEGER NOT NULL DEFAULT 0,
+  lure_id INTEGER DEFAULT 0,
+  first_seen_timestamp INTEGER NOT NULL,
+  sponsor_id INTEGER DEFAULT NULL,
+  partner_id TEXT DEFAULT NULL,
+  ar_scan_eligible INTEGER DEFAULT NULL,
+  power_up_level INTEGER DEFAULT NULL,
+  power_up_points INTEGER DEFAULT NULL,
+  power_up_end_timestamp INTEGER DEFAULT NULL,
+  alternative_quest_type INTEGER DEFAULT NULL,
+  alternative_quest_timestamp INTEGER DEFAULT NULL,
+  alternative_quest_target INTEGER DEFAULT NULL,
+  alternative_quest_conditions TEXT,
+  alternative_quest_rewards TEXT,
+  alternative_quest_template TEXT DEFAULT NULL,
+  alternative_quest_title TEXT DEFAULT NULL,
+  quest_expiry INTEGER,
+  alternative_quest_expiry INTEGER,
+  description TEXT,
+  showcase_pokemon_id INTEGER DEFAULT NULL,
+  showcase_pokemon_form_id INTEGER DEFAULT NULL,
+  showcase_pokemon_type_id INTEGER DEFAULT NULL,
+  showcase_focus TEXT DEFAULT NULL,
+  showcase_ranking_standard INTEGER DEFAULT NULL,
+  showcase_expiry INTEGER DEFAULT NULL,
+  showcase_rankings TEXT DEFAULT NULL,
+  quest_reward_type INTEGER DEFAULT NULL,
+  quest_item_id INTEGER DEFAULT NULL,
+  quest_reward_amount INTEGER DEFAULT NULL,
+  quest_pokemon_id INTEGER DEFAULT NULL,
+  quest_pokemon_form_id INTEGER DEFAULT NULL,
+  alternative_quest_reward_type INTEGER DEFAULT NULL,
+  alternative_quest_item_id INTEGER DEFAULT NULL,
+  alternative_quest_reward_amount INTEGER DEFAULT NULL,
+  alternative_quest_pokemon_id INTEGER DEFAULT NULL,
+  alternative_quest_pokemon_form_id INTEGER DEFAULT NULL,
+  PRIMARY KEY (id)
+);
+CREATE INDEX ix_pokestop_cell_id ON pokestop (cell_id);
+CREATE INDEX ix_pokestop_alternative_quest_expiry ON pokestop (alternative_quest_expiry);
+CREATE INDEX ix_pokestop_coords ON pokestop (lat,lon);
+CREATE INDEX ix_pokestop_lure_expire_timestamp ON pokestop (lure_expire_timestamp);
+CREATE INDEX ix_pokestop_old_forts ON pokestop (cell_id,deleted,updated);
+CREATE INDEX ix_pokestop_deleted ON pokestop (deleted);
+CREATE INDEX ix_pokestop_quest_expiry ON pokestop (quest_expiry);
+CREATE INDEX ix_pokestop_updated ON pokestop (updated);
+
+CREATE TABLE quest_stats (
+  date TEXT NOT NULL,
+  area TEXT NOT NULL DEFAULT '',
+  fence TEXT NOT NULL DEFAULT '',
+  reward_type INTEGER NOT NULL DEFAULT 0,
+  pokemon_id INTEGER NOT NULL DEFAULT 0,
+  item_id INTEGER NOT NULL DEFAULT 0,
+  item_amount INTEGER NOT NULL,
+  count INTEGER NOT NULL,
+  PRIMARY KEY (date,area,fence,reward_type,pokemon_id,item_id,item_amount)
+);
+
+CREATE TABLE raid_stats (
+  date TEXT NOT NULL,
+  area TEXT NOT NULL DEFAULT '',
+  fence TEXT NOT NULL DEFAULT '',
+  level INTEGER NOT NULL,
+  pokemon_id INTEGER NOT NULL,
+  form_id INTEGER NOT NULL DEFAULT 0,
+  temp_evo_id INTEGER NOT NULL DEFAULT 0,
+  count INTEGER NOT NULL,
+  PRIMARY KEY (date,area,fence,pokemon_id,form_id,temp_evo_id,level)
+);
+
+CREATE TABLE route (
+  id TEXT NOT NULL,
+  name TEXT NOT NULL,
+  shortcode TEXT NOT NULL DEFAULT '',
+  description TEXT NOT NULL,
+  distance_meters INTEGER NOT NULL,
+  duration_seconds INTEGER NOT NULL,
+  start_fort_id TEXT NOT NULL,
+  start_image TEXT NOT NULL,
+  start_lat REAL NOT NULL,
+  start_lon REAL NOT NULL,
+  end_fort_id TEXT NOT NULL,
+  end_image TEXT NOT NULL,
+  end_lat REAL NOT NULL,
+  end_lon REAL NOT NULL,
+  image TEXT NOT NULL,
+  image_border_color TEXT NOT NULL,
+  reversible INTEGER NOT NULL,
+  tags TEXT DEFAULT NULL,
+  type INTEGER NOT NULL,
+  updated INTEGER NOT NULL,
+  version INTEGER NOT NULL,
+  waypoints TEXT NOT NULL,
+  PRIMARY KEY (id)
+);
+CREATE INDEX ix_route_coords_end ON route (end_lat,end_lon);
+CREATE INDEX ix_route_coords_start ON route (start_lat,start_lon);
+
+CREATE TABLE s2cell (
+  -- text, as s2 cell ids outgrow a signed 64 bit integer
+  id TEXT NOT NULL,
+  level INTEGER DEFAULT NULL,
+  center_lat REAL NOT NULL DEFAULT 0,
+  center_lon REAL NOT NULL DEFAULT 0,
+  updated INTEGER NOT NULL,
+  PRIMARY KEY (id)
+);
+CREATE INDEX ix_s2cell_coords ON s2cell (center_lat,center_lon);
+CREATE INDEX ix_s2cell_updated ON s2cell (updated);
+
+CREATE TABLE spawnpoint (
+  id INTEGER NOT NULL,
+  lat REAL NOT NULL,
+  lon REAL NOT NULL,
+  updated INTEGER NOT NULL DEFAULT 0,
+  last_seen INTEGER NOT NULL DEFAULT 0,
+  despawn_sec INTEGER DEFAULT NULL,
+  first_seen INTEGER NOT NULL DEFAULT (unixepoch()),
+  PRIMARY KEY (id)
+);
+CREATE INDEX ix_spawnpoint_coords ON spawnpoint (lat,lon);
+CREATE INDEX ix_spawnpoint_last_seen ON spawnpoint (last_seen);
+CREATE INDEX ix_spawnpoint_updated ON spawnpoint (updated);
+
+CREATE TABLE station (
+  id TEXT NOT NULL,
+  lat REAL NOT NULL,
+  lon REAL NOT NULL,
+  name TEXT NOT NULL,
+  cell_id INTEGER NOT NULL,
+  start_time INTEGER NOT NULL,
+  end_time INTEGER NOT NULL,
+  cooldown_complete INTEGER NOT NULL,
+  is_battle_available INTEGER NOT NULL,
+  is_inactive INTEGER NOT NULL,
+  updated INTEGER NOT NULL,
+  battle_level INTEGER DEFAULT NULL,
+  battle_start INTEGER,
+  battle_end INTEGER,
+  battle_pokemon_id INTEGER,
+  battle_pokemon_form INTEGER,
+  battle_pokemon_costume INTEGER,
+  battle_pokemon_gender INTEGER,
+  battle_pokemon_alignment INTEGER,
+  battle_pokemon_bread_mode INTEGER,
+  battle_pokemon_move_1 INTEGER,
+  battle_pokemon_move_2 INTEGER,
+  battle_pokemon_stamina INTEGER,
+  battle_pokemon_cp_multiplier REAL,
+  total_stationed_pokemon INTEGER DEFAULT NULL,
+  total_stationed_gmax INTEGER,
+  stationed_pokemon TEXT DEFAULT NULL,
+  PRIMARY KEY (id)
+);
+CREATE INDEX ix_station_cell_id ON station (cell_id);
+CREATE INDEX ix_station_battle_pokemon_id ON station (battle_pokemon_id);
+CREATE INDEX ix_station_coords ON station (lat,lon);
+CREATE INDEX ix_station_end_time ON station (end_time);
+CREATE INDEX ix_station_updated ON station (updated);
+
+CREATE TABLE station_battle (
+  bread_battle_seed INTEGER NOT NULL,
+  station_id TEXT NOT NULL,
+  battle_level INTEGER NOT NULL,
+  battle_start INTEGER NOT NULL,
+  battle_end INTEGER NOT NULL,
+  battle_pokemon_id INTEGER DEFAULT NULL,
+  battle_pokemon_form INTEGER DEFAULT NULL,
+  battle_pokemon_costume INTEGER DEFAULT NULL,
+  battle_pokemon_gender INTEGER DEFAULT NULL,
+  battle_pokemon_alignment INTEGER DEFAULT NULL,
+  battle_pokemon_bread_mode INTEGER DEFAULT NULL,
+  battle_pokemon_move_1 INTEGER DEFAULT NULL,
+  battle_pokemon_move_2 INTEGER DEFAULT NULL,
+  battle_pokemon_stamina INTEGER DEFAULT NULL,
+  battle_pokemon_cp_multiplier REAL DEFAULT NULL,
+  updated INTEGER NOT NULL,
+  PRIMARY KEY (bread_battle_seed)
+);
+CREATE INDEX ix_station_battle_end ON station_battle (battle_end);
+CREATE INDEX ix_station_battle_station_end ON station_battle (station_id,battle_end);
+
+CREATE TABLE tappable (
+  id TEXT NOT NULL,
+  lat REAL NOT NULL,
+  lon REAL NOT NULL,
+  fort_id TEXT DEFAULT NULL,
+  spawn_id INTEGER,
+  type TEXT NOT NULL,
+  pokemon_id INTEGER DEFAULT NULL,
+  item_id INTEGER DEFAULT NULL,
+  count INTEGER DEFAULT NULL,
+  expire_timestamp_verified INTEGER NOT NULL,
+  expire_timestamp INTEGER DEFAULT NULL,
+  updated INTEGER NOT NULL,
+  PRIMARY KEY (id)
+);
+CREATE INDEX ix_tappable_coords ON tappable (lat,lon);
+CREATE INDEX ix_tappable_expire_timestamp ON tappable (expire_timestamp,expire_timestamp_verified);
+
+CREATE TABLE weather (
+  id INTEGER NOT NULL,
+  level INTEGER DEFAULT NULL,
+  latitude REAL NOT NULL DEFAULT 0,
+  longitude REAL NOT NULL DEFAULT 0,
+  gameplay_condition INTEGER DEFAULT NULL,
+  wind_direction INTEGER DEFAULT NULL,
+  cloud_level INTEGER DEFAULT NULL,
+  rain_level INTEGER DEFAULT NULL,
+  wind_level INTEGER DEFAULT NULL,
+  snow_level INTEGER DEFAULT NULL,
+  fog_level INTEGER DEFAULT NULL,
+  special_effect_level INTEGER DEFAULT NULL,
+  severity INTEGER DEFAULT NULL,
+  warn_weather INTEGER DEFAULT NULL,
+  updated INTEGER NOT NULL,
+  PRIMARY KEY (id)
+);
@@ -13,14 +13,14 @@ import (
 	log "github.com/sirupsen/logrus"
 )
 
-func StartDbUsageStatsLogger(db *sqlx.DB) {
+func StartDbUsageStatsLogger(name string, db *sqlx.DB) {
 	ticker := time.NewTicker(30 * time.Second)
 	go func() {
 		for {
 			<-ticker.C
 
 			stats := db.Stats()
-			log.Infof("DB - InUse: %d Idle %d WaitDuration %s", stats.InUse, stats.Idle, stats.WaitDuration)
+			log.Infof("%s - InUse: %d Idle %d WaitDuration %s", name, stats.InUse, stats.Idle, stats.WaitDuration)
 		}
 	}()
 }
@@ -48,7 +48,7 @@ func StartDatabaseArchiver(db *sqlx.DB) {
 			for {
 				pokemonId := []PokemonIdToDelete{}
 				err = db.Select(&pokemonId,
-					fmt.Sprintf("SELECT id FROM pokemon WHERE expire_timestamp < UNIX_TIMESTAMP() AND expire_timestamp_verified = 1 LIMIT %d;", databaseDeleteChunkSize))
+					db2.Rebind(db, fmt.Sprintf("SELECT id FROM pokemon WHERE expire_timestamp < UNIX_TIMESTAMP() AND expire_timestamp_verified = 1 LIMIT %d;", databaseDeleteChunkSize)))
 				if err != nil {
 					log.Errorf("DB - Archive of pokemon table (expire time verified) select error [after %d rows] %s", resultCounter, err)
 					break
@@ -90,7 +90,7 @@ func StartDatabaseArchiver(db *sqlx.DB) {
 			for {
 				pokemonId := []PokemonIdToDelete{}
 				err = db.Select(&pokemonId,
-					fmt.Sprintf("SELECT id FROM pokemon WHERE expire_timestamp < (UNIX_TIMESTAMP() - 2400) AND expire_timestamp_verified = 0 LIMIT %d;", databaseDeleteChunkSize))
+					db2.Rebind(db, fmt.Sprintf("SELECT id FROM pokemon WHERE expire_timestamp < (UNIX_TIMESTAMP() - 2400) AND expire_timestamp_verified = 0 LIMIT %d;", databaseDeleteChunkSize)))
 				if err != nil {
 					log.Errorf("DB - Archive of pokemon table (unverified timestamps) select error [after %d rows] %s", resultCounter, err)
 					break
@@ -138,7 +138,7 @@ func StartStatsExpiry(db *sqlx.DB) {
 			var result sql.Result
 			var err error
 
-			result, err = db.Exec("DELETE FROM pokemon_area_stats WHERE `datetime` < UNIX_TIMESTAMP() - 10080;")
+			result, err = db.Exec(db2.Rebind(db, "DELETE FROM pokemon_area_stats WHERE `datetime` < UNIX_TIMESTAMP() - 10080;"))
 
 			elapsed := time.Since(start)
 
@@ -154,7 +154,7 @@ func StartStatsExpiry(db *sqlx.DB) {
 			for _, table := range tables {
 				start = time.Now()
 
-				result, err = db.Exec(fmt.Sprintf("DELETE FROM %s WHERE `date` < DATE(NOW() - INTERVAL %d DAY);", table, config.Config.Cleanup.StatsDays))
+				result, err = db.Exec(fmt.Sprintf("DELETE FROM %s WHERE `date` < %s;", table, db2.DialectOf(db).DaysAgo(config.Config.Cleanup.StatsDays)))
 				elapsed = time.Since(start)
 
 				if err != nil {
@@ -178,7 +178,7 @@ func StartIncidentExpiry(db *sqlx.DB) {
 			var result sql.Result
 			var err error
 
-			result, err = db.Exec("DELETE FROM incident WHERE expiration < UNIX_TIMESTAMP();")
+			result, err = db.Exec(db2.Rebind(db, "DELETE FROM incident WHERE expiration < UNIX_TIMESTAMP();"))
 
 			elapsed := time.Since(start)
 
@@ -202,7 +202,7 @@ func StartStationBattleExpiry(db *sqlx.DB) {
 			var result sql.Result
 			var err error
 
-			result, err = db.Exec("DELETE FROM station_battle WHERE battle_end < UNIX_TIMESTAMP();")
+			result, err = db.Exec(db2.Rebind(db, "DELETE FROM station_battle WHERE battle_end < UNIX_TIMESTAMP();"))
 
 			elapsed := time.Since(start)
 
@@ -226,7 +226,7 @@ func StartTappableExpiry(db *sqlx.DB) {
 			var result sql.Result
 			var err error
 
-			result, err = db.Exec("DELETE FROM tappable WHERE expire_timestamp < UNIX_TIMESTAMP();")
+			result, err = db.Exec(db2.Rebind(db, "DELETE FROM tappable WHERE expire_timestamp < UNIX_TIMESTAMP();"))
 
 			elapsed := time.Since(start)
 
@@ -12,6 +12,7 @@ type noopCollector struct {
 }
 
 func (col *noopCollector) IncRawRequests(string, string)                         {}
+func (col *noopCollector) IncRawRequestsByToken(string, string)                  {}
 func (col *noopCollector) IncDecodeMethods(string, string, string)               {}
 func (col *noopCollector) IncDecodeFortDetails(string, string)                   {}
 func (col *noopCollector) IncDecodeGetMapForts(string, string)                   {}
@@ -24,6 +25,7 @@ func (col *noopCollector) IncDecodeGetFriendDetails(string, string)
 func (col *noopCollector) IncDecodeSearchPlayer(string, string)                  {}
 func (col *noopCollector) IncDecodeGMO(string, string)                           {}
 func (col *noopCollector) AddDecodeGMOType(string, float64)                      {}
+func (col *noopCollector) IncDecodePushGateway(string, string)                   {}
 func (col *noopCollector) IncDecodeStartIncident(string, string)                 {}
 func (col *noopCollector) IncDecodeOpenInvasion(string, string)                  {}
 func (col *noopCollector) AddPokemonStatsResetCount(string, float64)             {}
@@ -63,6 +65,25 @@ func (col *noopCollector) ObserveWriteBehindBatchSize(string, float64) {}
 func (col *noopCollector) ObserveWriteBehindBatchTime(string, float64) {}
 func (col *noopCollector) SetS2CellBatchSize(int)                      {}
 
+// Raw decode queue metrics (noop)
+func (col *noopCollector) SetDecodeQueueDepth(float64)   {}
+func (col *noopCollector) SetDecodeWorkersBusy(float64)  {}
+func (col *noopCollector) IncDecodeQueueRejected(string) {}
+
+// Device and account ingest health (noop)
+func (col *noopCollector) SetIngestHealth(string, string, float64) {}
+
+// GMO cell deduplication (noop)
+func (col *noopCollector) AddGmoCellsDeduplicated(float64) {}
+
+// Webhook delivery (noop)
+func (col *noopCollector) AddWebhookMessages(string, float64, float64)   {}
+func (col *noopCollector) ObserveWebhookRequest(string, string, float64) {}
+func (col *noopCollector) SetWebhookSpooled(string, float64)             {}
+
+// Database read replicas (noop)
+func (col *noopCollector) SetReplicaLag(string, string, float64) {}
+
 func NewNoopStatsCollector() StatsCollector {
 	return &noopCollector{}
 }
@@ -25,6 +25,14 @@ var (
 		},
 		[]string{"status", "message"},
 	)
+	rawRequestsByToken = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: ns,
+			Name:      "raw_requests_by_token",
+			Help:      "Total number of requests received by raw endpoint for each named raw token",
+		},
+		[]string{"token", "status"},
+	)
 
 	decodeMethods = prometheus.NewCounterVec(
 		prometheus.CounterOpts{
@@ -122,6 +130,14 @@ var (
 		},
 		[]string{"type"},
 	)
+	decodePushGateway = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: ns,
+			Name:      "decode_push_gateway",
+			Help:      "Total number of push-gateway messages by message type (handled, ignored, error)",
+		},
+		[]string{"message_type", "status"},
+	)
 	decodeStartIncident = prometheus.NewCounterVec(
 		prometheus.CounterOpts{
 			Namespace: ns,
@@ -421,6 +437,93 @@ var (
 			Help:      "Number of S2Cells written in the last batch flush",
 		},
 	)
+
+	// Raw decode queue metrics
+	decodeQueueDepth = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Namespace: ns,
+			Name:      "decode_queue_depth",
+			Help:      "Raw batches waiting for a decode worker",
+		},
+	)
+	decodeWorkersBusy = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Namespace: ns,
+			Name:      "decode_workers_busy",
+			Help:      "Decode workers currently processing a raw batch",
+		},
+	)
+	decodeQueueRejected = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: ns,
+			Name:      "decode_queue_rejected_total",
+			Help:      "Total number of raw batches refused because the decode queue was full",
+		},
+		[]string{"source"},
+	)
+
+	// Device and account ingest health
+	ingestHealth = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: ns,
+			Name:      "ingest_health",
+			Help:      "Tracked devices and accounts by health state (active, stale, empty_gmo)",
+		},
+		[]string{"kind", "state"},
+	)
+
+	// GMO cell deduplication
+	gmoCellsDeduplicated = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Namespace: ns,
+			Name:      "decode_gmo_cells_deduplicated_total",
+			Help:      "Total number of GMO map cells skipped because another device sent the same content within the dedup window",
+		},
+	)
+
+	// Webhook delivery
+	webhookMessages = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: ns,
+			Name:      "webhook_messages_total",
+			Help:      "Total number of webhook messages queued for each destination",
+		},
+		[]string{"destination"},
+	)
+	webhookBytes = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: ns,
+			Name:      "webhook_bytes_total",
+			Help:      "Total size of the webhook payloads queued for each destination",
+		},
+		[]string{"destination"},
+	)
+	webhookRequests = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: ns,
+			Name:      "webhook_request_duration_seconds",
+			Help:      "Webhook POST attempts and their latency, by destination and status class (2xx, 4xx, 5xx, error)",
+			Buckets:   []float64{.005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10, 30},
+		},
+		[]string{"destination", "status"},
+	)
+	webhookSpooled = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: ns,
+			Name:      "webhook_spooled_payloads",
+			Help:      "Webhook payloads waiting to be retried, by destination",
+		},
+		[]string{"destination"},
+	)
+
+	replicaLag = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: ns,
+			Name:      "replica_lag_seconds",
+			Help:      "How far each read replica is behind its primary database",
+		},
+		[]string{"database", "replica"},
+	)
 )
 
 var _ StatsCollector = (*promCollector)(nil)
@@ -432,6 +535,10 @@ func (col *promCollector) IncRawRequests(status, message string) {
 	rawRequests.WithLabelValues(status, message).Inc()
 }
 
+func (col *promCollector) IncRawRequestsByToken(token, status string) {
+	rawRequestsByToken.WithLabelValues(token, status).Inc()
+}
+
 func (col *promCollector) IncDecodeMethods(status, message, method string) {
 	decodeMethods.WithLabelValues(status, message, method).Inc()
 }
@@ -480,6 +587,10 @@ func (col *promCollector) AddDecodeGMOType(typ string, value float64) {
 	decodeGMOType.WithLabelValues(typ).Add(value)
 }
 
+func (col *promCollector) IncDecodePushGateway(messageType, status string) {
+	decodePushGateway.WithLabelValues(messageType, status).Inc()
+}
+
 func (col *promCollector) IncDecodeStartIncident(status, message string) {
 	decodeStartIncident.WithLabelValues(status, message).Inc()
 }
@@ -722,11 +833,48 @@ func (col *promCollector) SetS2CellBatchSize(size int) {
 	s2CellBatchSize.Set(float64(size))
 }
 
+func (col *promCollector) SetDecodeQueueDepth(depth float64) {
+	decodeQueueDepth.Set(depth)
+}
+
+func (col *promCollector) SetDecodeWorkersBusy(busy float64) {
+	decodeWorkersBusy.Set(busy)
+}
+
+func (col *promCollector) IncDecodeQueueRejected(source string) {
+	decodeQueueRejected.WithLabelValues(source).Inc()
+}
+
+func (col *promCollector) SetIngestHealth(kind, state string, count float64) {
+	ingestHealth.WithLabelValues(kind, state).Set(count)
+}
+
+func (col *promCollector) AddGmoCellsDeduplicated(count float64) {
+	gmoCellsDeduplicated.Add(count)
+}
+
+func (col *promCollector) AddWebhookMessages(destination string, messages, bytes float64) {
+	webhookMessages.WithLabelValues(destination).Add(messages)
+	webhookBytes.WithLabelValues(destination).Add(bytes)
+}
+
+func (col *promCollector) ObserveWebhookRequest(destination, status string, seconds float64) {
+	webhookRequests.WithLabelValues(destination, status).Observe(seconds)
+}
+
+func (col *promCollector) SetWebhookSpooled(destination string, count float64) {
+	webhookSpooled.WithLabelValues(destination).Set(count)
+}
+
+func (col *promCollector) SetReplicaLag(database, replica string, seconds float64) {
+	replicaLag.WithLabelValues(database, replica).Set(seconds)
+}
+
 func initPrometheus() {
 	prometheus.MustRegister(
-		rawRequests, decodeMethods, decodeFortDetails, decodeGetMapForts, decodeGetGymInfo, decodeEncounter,
+		rawRequests, rawRequestsByToken, decodeMethods, decodeFortDetails, decodeGetMapForts, decodeGetGymInfo, decodeEncounter,
 		decodeDiskEncounter, decodeQuest, decodeSocialActionWithRequest, decodeGMO, decodeGMOType,
-		decodeGetFriendDetails, decodeSearchPlayer, decodeOpenInvasion, decodeStartIncident,
+		decodeGetFriendDetails, decodeSearchPlayer, decodeOpenInvasion, decodeStartIncident, decodePushGateway,
 
 		pokemonStatsResetCount,
 
@@ -742,6 +890,16 @@ func initPrometheus() {
 		writeBehindErrors, writeBehindWrites, writeBehindLatency,
 		writeBehindBatches, writeBehindBatchSize, writeBehindBatchTime,
 		s2CellBatchSize,
+
+		decodeQueueDepth, decodeWorkersBusy, decodeQueueRejected,
+
+		ingestHealth,
+
+		gmoCellsDeduplicated,
+
+		webhookMessages, webhookBytes, webhookRequests, webhookSpooled,
+
+		replicaLag,
 	)
 }
 
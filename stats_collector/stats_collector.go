@@ -12,6 +12,7 @@ import (
 
 type StatsCollector interface {
 	IncRawRequests(status, message string)
+	IncRawRequestsByToken(token, status string)
 	IncDecodeMethods(status, message, method string)
 	IncDecodeFortDetails(status, message string)
 	IncDecodeGetMapForts(status, message string)
@@ -24,6 +25,7 @@ type StatsCollector interface {
 	IncDecodeSearchPlayer(status, message string)
 	IncDecodeGMO(status, message string)
 	AddDecodeGMOType(typ string, value float64)
+	IncDecodePushGateway(messageType, status string)
 	IncDecodeStartIncident(status, message string)
 	IncDecodeOpenInvasion(status, message string)
 	AddPokemonStatsResetCount(area string, val float64)
@@ -64,6 +66,25 @@ type StatsCollector interface {
 
 	// S2Cell batch metrics
 	SetS2CellBatchSize(size int)
+
+	// Raw decode queue metrics
+	SetDecodeQueueDepth(depth float64)
+	SetDecodeWorkersBusy(busy float64)
+	IncDecodeQueueRejected(source string)
+
+	// Device and account ingest health
+	SetIngestHealth(kind, state string, count float64)
+
+	// GMO cell deduplication
+	AddGmoCellsDeduplicated(count float64)
+
+	// Webhook delivery, per destination
+	AddWebhookMessages(destination string, messages, bytes float64)
+	ObserveWebhookRequest(destination, status string, seconds float64)
+	SetWebhookSpooled(destination string, count float64)
+
+	// Database read replicas
+	SetReplicaLag(database, replica string, seconds float64)
 }
 
 type Config interface {
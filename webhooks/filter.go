@@ -0,0 +1,19 @@
+package webhooks
+
+// MessageFilter decides whether a destination wants a message, beyond its
+// type and area.
+type MessageFilter interface {
+	Match(message any) bool
+}
+
+// FilterParser builds the MessageFilter for a destination's pokemon and fort
+// filter clauses.
+type FilterParser func(pokemonFilters, fortFilters []map[string]any) (MessageFilter, error)
+
+var filterParser FilterParser
+
+// SetFilterParser sets the parser for per-webhook filter clauses. The clauses
+// share their shape with the scan APIs, so the decoder provides it.
+func SetFilterParser(parser FilterParser) {
+	filterParser = parser
+}
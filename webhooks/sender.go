@@ -2,6 +2,9 @@ package webhooks
 
 import (
 	"context"
+	"fmt"
+	"net/http"
+	"reflect"
 	"sync"
 	"time"
 
@@ -14,6 +17,7 @@ import (
 type configInterface interface {
 	GetWebhooks() []config.Webhook
 	GetWebhookInterval() time.Duration
+	GetWebhookDelivery() config.WebhookDelivery
 }
 
 type webhookCollection [webhookTypesLength]webhookList
@@ -33,33 +37,50 @@ func (webhookList *webhookList) AddMessage(message webhookMessage) {
 }
 
 type webhooksSender struct {
-	mutex           sync.Mutex
 	webhookInterval time.Duration
+	retryBase       time.Duration
+	retryMax        time.Duration
+	spoolDirectory  string
+	spoolMaxBytes   int64
+	httpClient      *http.Client
 
-	collections webhookCollection
+	// mutex guards webhooks and running. AddMessage holds it for reading
+	// while it fans a message out, so a reload never strands a message in a
+	// destination that is being replaced.
+	mutex       sync.RWMutex
 	webhooks    []*webhook
-}
+	running     context.Context
+	runGroup    sync.WaitGroup
+	reloadMutex sync.Mutex
 
-// this grabs the current collection of webhooks and resets the state such
-// that new messages begin to be added to an empty collection. if a caller
-// does nothing with the collection returned here, the collection is lost.
-func (sender *webhooksSender) getCurrentCollection() webhookCollection {
-	sender.mutex.Lock()
-	current := sender.collections
-	sender.collections = webhookCollection{}
-	sender.mutex.Unlock()
-	return current
+	streams streamHub
 }
 
+// AddMessage adds a message to the collection of each destination that
+// wants its type.
 func (sender *webhooksSender) AddMessage(wh_type WebhookType, message any, areas []geo.AreaName) {
 	wh_message := webhookMessage{
 		Type:    webhookTypeToPayloadType[wh_type],
 		Areas:   areas,
 		Message: message,
 	}
-	sender.mutex.Lock()
-	sender.collections[wh_type].AddMessage(wh_message)
-	sender.mutex.Unlock()
+	sender.mutex.RLock()
+	for _, wh := range sender.webhooks {
+		wh.addMessage(wh_type, wh_message)
+	}
+	sender.mutex.RUnlock()
+	sender.streams.publish(wh_type, wh_message)
+}
+
+// Subscribe starts a live stream of the messages matching filter, buffering
+// up to buffer of them. Call Unsubscribe when done.
+func (sender *webhooksSender) Subscribe(filter StreamFilter, buffer int) *Subscription {
+	return sender.streams.subscribe(filter, buffer)
+}
+
+// Unsubscribe ends a stream and closes its Events channel.
+func (sender *webhooksSender) Unsubscribe(sub *Subscription) {
+	sender.streams.unsubscribe(sub)
 }
 
 // Flush will send the collected webhooks. This is meant to be used after
@@ -67,12 +88,11 @@ func (sender *webhooksSender) AddMessage(wh_type WebhookType, message any, areas
 func (sender *webhooksSender) Flush() {
 	var wg sync.WaitGroup
 
-	currentCollection := sender.getCurrentCollection()
-	for _, wh := range sender.webhooks {
+	for _, wh := range sender.currentWebhooks() {
 		wg.Add(1)
 		go func(wh *webhook) {
 			defer wg.Done()
-			if err := wh.sendCollection(currentCollection); err != nil {
+			if err := wh.flush(); err != nil {
 				log.Warnf("webhooks: flush to %s failed: %s", wh.url, err)
 			}
 		}(wh)
@@ -80,41 +100,228 @@ func (sender *webhooksSender) Flush() {
 	wg.Wait()
 }
 
-// Run will monitor the webhooks collection and send in bulk every 1s.
-// This blocks until `ctx` is cancelled.
+// Status returns the delivery counters of each destination, in config order.
+func (sender *webhooksSender) Status() []DestinationStatus {
+	webhooks := sender.currentWebhooks()
+	statuses := make([]DestinationStatus, 0, len(webhooks))
+	for _, wh := range webhooks {
+		status := wh.status.snapshot()
+		status.SpooledPayloads = wh.spool.len()
+		statuses = append(statuses, status)
+	}
+	return statuses
+}
+
+func (sender *webhooksSender) currentWebhooks() []*webhook {
+	sender.mutex.RLock()
+	defer sender.mutex.RUnlock()
+	return sender.webhooks
+}
+
+// Run will send each destination its collected webhooks in bulk at its
+// interval (every 1s by default), and retry any payloads that could not be
+// delivered. This blocks until `ctx` is cancelled.
 func (sender *webhooksSender) Run(ctx context.Context) error {
-	ticker := time.NewTicker(sender.webhookInterval)
+	sender.mutex.Lock()
+	sender.running = ctx
+	for _, wh := range sender.webhooks {
+		sender.start(ctx, wh)
+	}
+	sender.mutex.Unlock()
+
+	<-ctx.Done()
+
+	sender.mutex.Lock()
+	sender.running = nil
+	sender.mutex.Unlock()
+	sender.runGroup.Wait()
+	return nil
+}
+
+// start runs the send and retry loops of wh until ctx is cancelled or wh is
+// stopped. The caller holds the write lock.
+func (sender *webhooksSender) start(ctx context.Context, wh *webhook) {
+	ctx, wh.stop = context.WithCancel(ctx)
+	sender.runGroup.Add(2)
+	go func() {
+		defer sender.runGroup.Done()
+		wh.retrySpooled(ctx, sender.retryBase, sender.retryMax)
+	}()
+	go func() {
+		defer sender.runGroup.Done()
+		sender.sendPeriodically(ctx, wh)
+	}()
+}
+
+// Reload replaces the destinations with configWebhooks. Destinations whose
+// config is unchanged carry on untouched. Changed ones keep their spool,
+// counters and the messages collected so far; removed ones send what they
+// collected before going away. Nothing is replaced if any webhook is invalid.
+func (sender *webhooksSender) Reload(configWebhooks []config.Webhook) error {
+	sender.reloadMutex.Lock()
+	defer sender.reloadMutex.Unlock()
+
+	previous := sender.currentWebhooks()
+	webhooks, err := sender.buildWebhooks(configWebhooks, previous)
+	if err != nil {
+		return err
+	}
+
+	current := make(map[*webhook]bool, len(webhooks))
+	byKey := make(map[webhookKey]*webhook, len(webhooks))
+	for _, wh := range webhooks {
+		current[wh] = true
+		byKey[wh.key] = wh
+	}
+	var removed []*webhook
+
+	sender.mutex.Lock()
+	sender.webhooks = webhooks
+	for _, old := range previous {
+		if current[old] {
+			continue
+		}
+		if old.stop != nil {
+			old.stop()
+		}
+		if replacement := byKey[old.key]; replacement != nil {
+			replacement.addPending(old.takePending())
+		} else {
+			removed = append(removed, old)
+		}
+	}
+	if sender.running != nil {
+		for _, wh := range webhooks {
+			if wh.stop == nil {
+				sender.start(sender.running, wh)
+			}
+		}
+	}
+	sender.mutex.Unlock()
+
+	for _, old := range removed {
+		go func(wh *webhook) {
+			if err := wh.flush(); err != nil {
+				log.Warnf("webhooks: final send to removed %s failed: %s", wh.url, err)
+			}
+		}(old)
+	}
+	log.Infof("webhooks: reloaded %d destinations, %d removed", len(webhooks), len(removed))
+	return nil
+}
+
+func (sender *webhooksSender) sendPeriodically(ctx context.Context, wh *webhook) {
+	interval := wh.interval
+	if interval <= 0 {
+		interval = sender.webhookInterval
+	}
+	ticker := time.NewTicker(interval)
 	defer ticker.Stop()
 
 	for {
 		select {
 		case <-ctx.Done():
-			return nil
+			return
 		case <-ticker.C:
-			go sender.Flush()
+			go func() {
+				if err := wh.flush(); err != nil {
+					log.Warnf("webhooks: send to %s failed: %s", wh.url, err)
+				}
+			}()
 		}
 	}
 }
 
-func NewWebhooksSender(cfg configInterface) (*webhooksSender, error) {
-	configWebhooks := cfg.GetWebhooks()
+// webhookKey identifies a destination across reloads: its url, and which
+// repeat of that url it is
+type webhookKey struct {
+	url    string
+	repeat int
+}
+
+// buildWebhooks makes the destinations for configWebhooks, reusing those in
+// previous whose config has not changed
+func (sender *webhooksSender) buildWebhooks(configWebhooks []config.Webhook, previous []*webhook) ([]*webhook, error) {
+	previousByKey := make(map[webhookKey]*webhook, len(previous))
+	for _, wh := range previous {
+		previousByKey[wh.key] = wh
+	}
+
 	webhooks := make([]*webhook, len(configWebhooks))
+	repeats := make(map[string]int)
 	for i, configWh := range configWebhooks {
+		key := webhookKey{url: configWh.Url, repeat: repeats[configWh.Url]}
+		repeats[configWh.Url]++
+
+		old := previousByKey[key]
+		if old != nil && reflect.DeepEqual(old.configWh, configWh) {
+			webhooks[i] = old
+			continue
+		}
+
 		webhook, err := webhookFromConfigWebhook(configWh)
 		if err != nil {
 			return nil, err
 		}
+		webhook.key = key
+		webhook.httpClient = sender.httpClient
+		if old != nil {
+			webhook.spool, webhook.wake, webhook.status = old.spool, old.wake, old.status
+			webhooks[i] = webhook
+			continue
+		}
+
+		directory := ""
+		if sender.spoolDirectory != "" {
+			directory = spoolDirectory(sender.spoolDirectory, key.url, key.repeat)
+		}
+		webhook.spool, err = newSpool(directory, sender.spoolMaxBytes)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open webhook spool for '%s': %s", webhook.url, err)
+		}
+		if n := webhook.spool.len(); n > 0 {
+			log.Infof("webhooks: %d spooled payloads to replay to %s", n, webhook.url)
+		}
+		webhook.status.spooled(webhook.spool.len())
 		webhooks[i] = webhook
 	}
+	return webhooks, nil
+}
+
+func NewWebhooksSender(cfg configInterface) (*webhooksSender, error) {
+	delivery := cfg.GetWebhookDelivery()
 
 	interval := cfg.GetWebhookInterval()
 	if interval <= 0 {
 		interval = time.Second
 	}
 
+	retryBase := time.Duration(delivery.RetryBase) * time.Millisecond
+	if retryBase <= 0 {
+		retryBase = 500 * time.Millisecond
+	}
+	retryMax := time.Duration(delivery.RetryMax) * time.Second
+	if retryMax <= 0 {
+		retryMax = time.Minute
+	}
+	retryMax = max(retryMax, retryBase)
+	timeout := time.Duration(delivery.Timeout) * time.Second
+	if timeout <= 0 {
+		timeout = 10 * time.Second
+	}
+
 	sender := &webhooksSender{
 		webhookInterval: interval,
-		webhooks:        webhooks,
+		retryBase:       retryBase,
+		retryMax:        retryMax,
+		spoolDirectory:  delivery.SpoolDirectory,
+		spoolMaxBytes:   int64(delivery.SpoolMaxSize) * 1024 * 1024,
+		httpClient:      &http.Client{Timeout: timeout},
+	}
+
+	var err error
+	if sender.webhooks, err = sender.buildWebhooks(cfg.GetWebhooks(), nil); err != nil {
+		return nil, err
 	}
 
 	return sender, nil
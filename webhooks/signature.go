@@ -0,0 +1,70 @@
+package webhooks
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// SignatureHeader carries the signature of a webhook POST whose destination
+// has a secret, as "t=<unix seconds>,v1=<hex HMAC-SHA256>". The HMAC is keyed
+// with the secret and taken over the timestamp, a dot, and the raw body.
+const SignatureHeader = "X-Golbat-Signature"
+
+var (
+	ErrSignatureMissing  = errors.New("webhook signature missing or malformed")
+	ErrSignatureMismatch = errors.New("webhook signature does not match")
+	ErrSignatureExpired  = errors.New("webhook signature timestamp outside tolerance")
+)
+
+// SignPayload returns the SignatureHeader value for body sent at timestamp.
+func SignPayload(secret []byte, timestamp time.Time, body []byte) string {
+	t := strconv.FormatInt(timestamp.Unix(), 10)
+	return "t=" + t + ",v1=" + hex.EncodeToString(payloadMac(secret, t, body))
+}
+
+// VerifySignature checks the SignatureHeader value a receiver got with body.
+// A signature older or newer than tolerance is rejected so a captured request
+// cannot be replayed later; a tolerance of 0 skips that check.
+func VerifySignature(secret []byte, header string, body []byte, tolerance time.Duration) error {
+	var t, v1 string
+	for _, part := range strings.Split(header, ",") {
+		key, value, _ := strings.Cut(strings.TrimSpace(part), "=")
+		switch key {
+		case "t":
+			t = value
+		case "v1":
+			v1 = value
+		}
+	}
+	timestamp, err := strconv.ParseInt(t, 10, 64)
+	if err != nil || v1 == "" {
+		return ErrSignatureMissing
+	}
+	signature, err := hex.DecodeString(v1)
+	if err != nil {
+		return ErrSignatureMissing
+	}
+	if !hmac.Equal(signature, payloadMac(secret, t, body)) {
+		return ErrSignatureMismatch
+	}
+	if tolerance > 0 {
+		age := time.Since(time.Unix(timestamp, 0))
+		if age > tolerance || age < -tolerance {
+			return ErrSignatureExpired
+		}
+	}
+	return nil
+}
+
+func payloadMac(secret []byte, timestamp string, body []byte) []byte {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return mac.Sum(nil)
+}
@@ -0,0 +1,40 @@
+package webhooks
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSignatureRoundTrip(t *testing.T) {
+	secret := []byte("s3cret")
+	body := []byte(`[{"type":"raid","message":{}}]`)
+	header := SignPayload(secret, time.Now(), body)
+
+	if err := VerifySignature(secret, header, body, 5*time.Minute); err != nil {
+		t.Fatalf("valid signature rejected: %s", err)
+	}
+	if err := VerifySignature([]byte("other"), header, body, 5*time.Minute); !errors.Is(err, ErrSignatureMismatch) {
+		t.Errorf("wrong secret: err = %v, want a mismatch", err)
+	}
+	if err := VerifySignature(secret, header, []byte(`[]`), 5*time.Minute); !errors.Is(err, ErrSignatureMismatch) {
+		t.Errorf("altered body: err = %v, want a mismatch", err)
+	}
+	if err := VerifySignature(secret, "", body, 0); !errors.Is(err, ErrSignatureMissing) {
+		t.Errorf("missing header: err = %v, want missing", err)
+	}
+
+	old := SignPayload(secret, time.Now().Add(-time.Hour), body)
+	if err := VerifySignature(secret, old, body, 5*time.Minute); !errors.Is(err, ErrSignatureExpired) {
+		t.Errorf("old signature: err = %v, want expired", err)
+	}
+	if err := VerifySignature(secret, old, body, 0); err != nil {
+		t.Errorf("old signature without a tolerance rejected: %s", err)
+	}
+	// the timestamp is covered by the signature
+	forged := strings.Replace(old, old[2:strings.Index(old, ",")], "9999999999", 1)
+	if err := VerifySignature(secret, forged, body, 0); !errors.Is(err, ErrSignatureMismatch) {
+		t.Errorf("altered timestamp: err = %v, want a mismatch", err)
+	}
+}
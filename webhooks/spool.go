@@ -0,0 +1,167 @@
+package webhooks
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// spool holds the payloads a destination has not accepted yet, oldest first.
+// With a directory each payload is also kept in its own file there so the
+// spool survives restarts; without one it is held in memory. Once maxBytes is
+// exceeded the oldest payloads are dropped.
+type spool struct {
+	mu        sync.Mutex
+	directory string
+	maxBytes  int64
+	size      int64
+	nextSeq   uint64
+	entries   []spoolEntry
+}
+
+type spoolEntry struct {
+	seq     uint64
+	size    int64
+	payload []byte // nil when the payload is on disk
+}
+
+// spoolDirectory is where the spool for url is kept under directory. A url
+// configured more than once gets a directory per repeat, as each may filter
+// differently.
+func spoolDirectory(directory, url string, repeat int) string {
+	sum := sha1.Sum([]byte(url))
+	name := hex.EncodeToString(sum[:8])
+	if repeat > 0 {
+		name = fmt.Sprintf("%s-%d", name, repeat)
+	}
+	return filepath.Join(directory, name)
+}
+
+// newSpool opens the spool in directory, picking up any payloads left by a
+// previous run. A blank directory keeps the spool in memory.
+func newSpool(directory string, maxBytes int64) (*spool, error) {
+	s := &spool{directory: directory, maxBytes: maxBytes}
+	if directory == "" {
+		return s, nil
+	}
+	if err := os.MkdirAll(directory, 0o755); err != nil {
+		return nil, err
+	}
+	files, err := os.ReadDir(directory)
+	if err != nil {
+		return nil, err
+	}
+	for _, file := range files {
+		name := file.Name()
+		if file.IsDir() || !strings.HasSuffix(name, ".json") {
+			continue
+		}
+		seq, err := strconv.ParseUint(strings.TrimSuffix(name, ".json"), 10, 64)
+		if err != nil {
+			continue
+		}
+		info, err := file.Info()
+		if err != nil {
+			continue
+		}
+		s.entries = append(s.entries, spoolEntry{seq: seq, size: info.Size()})
+		s.size += info.Size()
+		if seq >= s.nextSeq {
+			s.nextSeq = seq + 1
+		}
+	}
+	sort.Slice(s.entries, func(i, j int) bool { return s.entries[i].seq < s.entries[j].seq })
+	return s, nil
+}
+
+func (s *spool) path(seq uint64) string {
+	return filepath.Join(s.directory, fmt.Sprintf("%020d.json", seq))
+}
+
+// push adds a payload after those already spooled, dropping the oldest if the
+// spool grows beyond its limit. It returns the number dropped.
+func (s *spool) push(payload []byte) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entry := spoolEntry{seq: s.nextSeq, size: int64(len(payload))}
+	s.nextSeq++
+	if s.directory == "" {
+		entry.payload = payload
+	} else {
+		tmp := s.path(entry.seq) + ".tmp"
+		if err := os.WriteFile(tmp, payload, 0o644); err != nil {
+			return 0, err
+		}
+		if err := os.Rename(tmp, s.path(entry.seq)); err != nil {
+			return 0, err
+		}
+	}
+	s.entries = append(s.entries, entry)
+	s.size += entry.size
+
+	dropped := 0
+	for s.maxBytes > 0 && s.size > s.maxBytes && len(s.entries) > 1 {
+		s.removeFirstLocked()
+		dropped++
+	}
+	return dropped, nil
+}
+
+// peek returns the oldest payload, or nil if the spool is empty.
+func (s *spool) peek() ([]byte, uint64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if len(s.entries) == 0 {
+		return nil, 0, nil
+	}
+	entry := s.entries[0]
+	if entry.payload != nil {
+		return entry.payload, entry.seq, nil
+	}
+	payload, err := os.ReadFile(s.path(entry.seq))
+	if err != nil {
+		// the file is unreadable, so it can never be delivered
+		log.Warnf("webhooks: dropping unreadable spooled payload %s: %s", s.path(entry.seq), err)
+		s.removeFirstLocked()
+		return nil, 0, err
+	}
+	return payload, entry.seq, nil
+}
+
+// remove removes the payload with sequence seq if it is still the oldest; it
+// may already have been dropped to make room.
+func (s *spool) remove(seq uint64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(s.entries) > 0 && s.entries[0].seq == seq {
+		s.removeFirstLocked()
+	}
+}
+
+func (s *spool) removeFirstLocked() {
+	entry := s.entries[0]
+	s.entries = s.entries[1:]
+	s.size -= entry.size
+	if s.directory != "" {
+		if err := os.Remove(s.path(entry.seq)); err != nil && !os.IsNotExist(err) {
+			log.Warnf("webhooks: failed to remove spooled payload: %s", err)
+		}
+	}
+}
+
+// len returns the number of payloads waiting.
+func (s *spool) len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.entries)
+}
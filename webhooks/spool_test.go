@@ -0,0 +1,71 @@
+package webhooks
+
+import (
+	"fmt"
+	"testing"
+)
+
+func popAll(t *testing.T, s *spool) []string {
+	t.Helper()
+	var payloads []string
+	for {
+		payload, seq, err := s.peek()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if payload == nil {
+			return payloads
+		}
+		payloads = append(payloads, string(payload))
+		s.remove(seq)
+	}
+}
+
+func TestSpoolDropsOldestBeyondLimit(t *testing.T) {
+	for _, directory := range []string{"", t.TempDir()} {
+		s, err := newSpool(directory, 10)
+		if err != nil {
+			t.Fatal(err)
+		}
+		dropped := 0
+		for i := 0; i < 5; i++ {
+			n, err := s.push([]byte(fmt.Sprintf("abcd%d", i)))
+			if err != nil {
+				t.Fatal(err)
+			}
+			dropped += n
+		}
+		got := popAll(t, s)
+		if dropped != 3 || fmt.Sprint(got) != "[abcd3 abcd4]" {
+			t.Errorf("directory %q: dropped %d and holds %v, want the newest two payloads", directory, dropped, got)
+		}
+	}
+}
+
+func TestSpoolSurvivesRestart(t *testing.T) {
+	directory := t.TempDir()
+	s, err := newSpool(directory, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, payload := range []string{"one", "two", "three"} {
+		if _, err := s.push([]byte(payload)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	_, seq, _ := s.peek()
+	s.remove(seq)
+
+	reopened, err := newSpool(directory, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := reopened.push([]byte("four")); err != nil {
+		t.Fatal(err)
+	}
+	got := popAll(t, reopened)
+	want := []string{"two", "three", "four"}
+	if fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Fatalf("reopened spool holds %v, want %v", got, want)
+	}
+}
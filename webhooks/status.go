@@ -0,0 +1,139 @@
+package webhooks
+
+import (
+	"net/url"
+	"sync"
+	"time"
+)
+
+// StatsCollector receives the delivery metrics of each destination.
+// stats_collector.StatsCollector implements it.
+type StatsCollector interface {
+	AddWebhookMessages(destination string, messages, bytes float64)
+	ObserveWebhookRequest(destination, status string, seconds float64)
+	SetWebhookSpooled(destination string, count float64)
+}
+
+type noopStatsCollector struct{}
+
+func (noopStatsCollector) AddWebhookMessages(string, float64, float64)   {}
+func (noopStatsCollector) ObserveWebhookRequest(string, string, float64) {}
+func (noopStatsCollector) SetWebhookSpooled(string, float64)             {}
+
+var statsCollector StatsCollector = noopStatsCollector{}
+
+func SetStatsCollector(collector StatsCollector) {
+	statsCollector = collector
+}
+
+// DestinationStatus is a snapshot of the delivery counters of one webhook
+// destination since startup.
+type DestinationStatus struct {
+	Destination     string            `json:"destination" doc:"Webhook url without credentials or query string"`
+	Messages        uint64            `json:"messages" doc:"Messages queued for the destination after type, area and filter matching"`
+	Bytes           uint64            `json:"bytes" doc:"Size of the payloads queued for the destination"`
+	Requests        map[string]uint64 `json:"requests" doc:"POST attempts by outcome: 2xx, 3xx, 4xx, 5xx, or error when no response was received"`
+	Failures        uint64            `json:"failures" doc:"POST attempts that did not return 2xx"`
+	AverageLatency  float64           `json:"average_latency_ms" doc:"Mean time to a response over all POST attempts, in milliseconds"`
+	LastLatency     float64           `json:"last_latency_ms" doc:"Time to a response of the latest POST attempt, in milliseconds"`
+	LastSuccess     int64             `json:"last_success,omitempty" doc:"Unix time of the latest 2xx response"`
+	LastError       string            `json:"last_error,omitempty" doc:"Error of the latest failed POST attempt"`
+	LastErrorTime   int64             `json:"last_error_time,omitempty" doc:"Unix time of the latest failed POST attempt"`
+	SpooledPayloads int               `json:"spooled_payloads" doc:"Payloads waiting to be retried"`
+}
+
+// deliveryStatus accumulates the counters reported for a destination
+type deliveryStatus struct {
+	mu            sync.Mutex
+	destination   string
+	messages      uint64
+	bytes         uint64
+	requests      map[string]uint64
+	failures      uint64
+	totalLatency  time.Duration
+	lastLatency   time.Duration
+	lastSuccess   time.Time
+	lastError     string
+	lastErrorTime time.Time
+}
+
+func newDeliveryStatus(urlStr string) *deliveryStatus {
+	return &deliveryStatus{
+		destination: destinationLabel(urlStr),
+		requests:    make(map[string]uint64),
+	}
+}
+
+// destinationLabel is the url as shown in metrics and the status api, less
+// any credentials or query string it carries
+func destinationLabel(urlStr string) string {
+	urlObj, err := url.Parse(urlStr)
+	if err != nil {
+		return urlStr
+	}
+	urlObj.User = nil
+	urlObj.RawQuery = ""
+	urlObj.Fragment = ""
+	return urlObj.String()
+}
+
+func (status *deliveryStatus) queued(messages, bytes int) {
+	status.mu.Lock()
+	status.messages += uint64(messages)
+	status.bytes += uint64(bytes)
+	status.mu.Unlock()
+	statsCollector.AddWebhookMessages(status.destination, float64(messages), float64(bytes))
+}
+
+// requested records one POST attempt. outcome is the status class of the
+// response, or "error" if there was none.
+func (status *deliveryStatus) requested(outcome string, latency time.Duration, err error) {
+	now := time.Now()
+	status.mu.Lock()
+	status.requests[outcome]++
+	status.totalLatency += latency
+	status.lastLatency = latency
+	if err == nil {
+		status.lastSuccess = now
+	} else {
+		status.failures++
+		status.lastError = err.Error()
+		status.lastErrorTime = now
+	}
+	status.mu.Unlock()
+	statsCollector.ObserveWebhookRequest(status.destination, outcome, latency.Seconds())
+}
+
+func (status *deliveryStatus) spooled(count int) {
+	statsCollector.SetWebhookSpooled(status.destination, float64(count))
+}
+
+func (status *deliveryStatus) snapshot() DestinationStatus {
+	status.mu.Lock()
+	defer status.mu.Unlock()
+
+	snapshot := DestinationStatus{
+		Destination: status.destination,
+		Messages:    status.messages,
+		Bytes:       status.bytes,
+		Requests:    make(map[string]uint64, len(status.requests)),
+		Failures:    status.failures,
+		LastLatency: float64(status.lastLatency) / float64(time.Millisecond),
+		LastError:   status.lastError,
+	}
+	var attempts uint64
+	for outcome, count := range status.requests {
+		snapshot.Requests[outcome] = count
+		attempts += count
+	}
+	if attempts > 0 {
+		snapshot.AverageLatency = float64(status.totalLatency) / float64(attempts) / float64(time.Millisecond)
+	}
+	if !status.lastSuccess.IsZero() {
+		snapshot.LastSuccess = status.lastSuccess.Unix()
+	}
+	if !status.lastErrorTime.IsZero() {
+		snapshot.LastErrorTime = status.lastErrorTime.Unix()
+	}
+	return snapshot
+}
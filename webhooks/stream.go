@@ -0,0 +1,162 @@
+package webhooks
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+	"sync/atomic"
+
+	log "github.com/sirupsen/logrus"
+
+	"golbat/geo"
+)
+
+// Located is implemented by messages that have a position, so stream
+// subscribers can select them by bounding box.
+type Located interface {
+	WebhookLocation() (lat, lon float64)
+}
+
+// StreamFilter selects the messages a stream subscriber receives. Empty
+// fields select everything.
+type StreamFilter struct {
+	Types []WebhookType
+	Areas []geo.AreaName
+	// Bounds, when set, only passes messages located inside it
+	Bounds *geo.Bbox
+	// EncodeJSON fills in StreamEvent.Message with the webhook json
+	EncodeJSON bool
+}
+
+// ParseWebhookTypes translates type names as used in the webhooks config,
+// such as "pokemon" or "raid", to webhook types.
+func ParseWebhookTypes(typeStrs []string) ([]WebhookType, error) {
+	var types []WebhookType
+	for _, typeStr := range typeStrs {
+		whTypes, ok := webhookConfigStringToType[typeStr]
+		if !ok {
+			return nil, fmt.Errorf("unknown webhook type '%s'", typeStr)
+		}
+		types = append(types, whTypes...)
+	}
+	return types, nil
+}
+
+// StreamEvent is one message delivered to a stream subscriber. Value is the
+// message as handed to AddMessage; Message is its json encoding, present if
+// the subscriber asked for it.
+type StreamEvent struct {
+	Type        string
+	WebhookType WebhookType
+	Value       any
+	Message     json.RawMessage
+}
+
+// Subscription receives the messages matching its filter as they are added.
+// A subscriber that does not keep up loses messages rather than holding up
+// the decoders; Dropped counts them.
+type Subscription struct {
+	Events <-chan StreamEvent
+
+	events  chan StreamEvent
+	wanted  [webhookTypesLength]bool
+	filter  StreamFilter
+	dropped atomic.Uint64
+}
+
+// Dropped returns the number of events lost since the last call because the
+// subscriber was too slow.
+func (sub *Subscription) Dropped() uint64 {
+	return sub.dropped.Swap(0)
+}
+
+func (sub *Subscription) matches(whType WebhookType, message webhookMessage) bool {
+	if !sub.wanted[whType] {
+		return false
+	}
+	if len(sub.filter.Areas) > 0 && !geo.AreaMatchWithWildcards(message.Areas, sub.filter.Areas) {
+		return false
+	}
+	if bounds := sub.filter.Bounds; bounds != nil {
+		located, ok := message.Message.(Located)
+		if !ok {
+			return false
+		}
+		lat, lon := located.WebhookLocation()
+		if lat < bounds.MinLat || lat > bounds.MaxLat || lon < bounds.MinLon || lon > bounds.MaxLon {
+			return false
+		}
+	}
+	return true
+}
+
+// streamHub fans messages out to the live stream subscribers
+type streamHub struct {
+	mutex       sync.RWMutex
+	subscribers map[*Subscription]struct{}
+	count       atomic.Int32
+}
+
+func (hub *streamHub) subscribe(filter StreamFilter, buffer int) *Subscription {
+	events := make(chan StreamEvent, buffer)
+	sub := &Subscription{Events: events, events: events, filter: filter}
+	for _, whType := range filter.Types {
+		sub.wanted[whType] = true
+	}
+	if len(filter.Types) == 0 {
+		for i := range sub.wanted {
+			sub.wanted[i] = true
+		}
+	}
+
+	hub.mutex.Lock()
+	if hub.subscribers == nil {
+		hub.subscribers = make(map[*Subscription]struct{})
+	}
+	hub.subscribers[sub] = struct{}{}
+	hub.count.Store(int32(len(hub.subscribers)))
+	hub.mutex.Unlock()
+	return sub
+}
+
+func (hub *streamHub) unsubscribe(sub *Subscription) {
+	hub.mutex.Lock()
+	if _, ok := hub.subscribers[sub]; ok {
+		delete(hub.subscribers, sub)
+		close(sub.events)
+	}
+	hub.count.Store(int32(len(hub.subscribers)))
+	hub.mutex.Unlock()
+}
+
+func (hub *streamHub) publish(whType WebhookType, message webhookMessage) {
+	if hub.count.Load() == 0 {
+		return
+	}
+
+	hub.mutex.RLock()
+	defer hub.mutex.RUnlock()
+
+	var encoded json.RawMessage
+	for sub := range hub.subscribers {
+		if !sub.matches(whType, message) {
+			continue
+		}
+		if sub.filter.EncodeJSON && encoded == nil {
+			var err error
+			if encoded, err = json.Marshal(message.Message); err != nil {
+				log.Warnf("webhooks: failed to encode %s for streaming: %s", message.Type, err)
+				return
+			}
+		}
+		event := StreamEvent{Type: message.Type, WebhookType: whType, Value: message.Message}
+		if sub.filter.EncodeJSON {
+			event.Message = encoded
+		}
+		select {
+		case sub.events <- event:
+		default:
+			sub.dropped.Add(1)
+		}
+	}
+}
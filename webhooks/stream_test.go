@@ -0,0 +1,106 @@
+package webhooks
+
+import (
+	"testing"
+
+	"golbat/config"
+	"golbat/geo"
+)
+
+type locatedMessage struct {
+	Name     string  `json:"name"`
+	Lat, Lon float64 `json:"-"`
+}
+
+func (m locatedMessage) WebhookLocation() (float64, float64) { return m.Lat, m.Lon }
+
+func TestStreamSubscription(t *testing.T) {
+	sender, err := NewWebhooksSender(webhookConfig{webhooks: []config.Webhook{}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	raids := sender.Subscribe(StreamFilter{Types: []WebhookType{Raid}, EncodeJSON: true}, 10)
+	area := sender.Subscribe(StreamFilter{Areas: []geo.AreaName{{Parent: "London", Name: "*"}}, EncodeJSON: true}, 10)
+	bounded := sender.Subscribe(StreamFilter{Bounds: &geo.Bbox{MinLat: 51, MinLon: -1, MaxLat: 52, MaxLon: 1}, EncodeJSON: true}, 10)
+	defer sender.Unsubscribe(raids)
+	defer sender.Unsubscribe(area)
+	defer sender.Unsubscribe(bounded)
+
+	london := []geo.AreaName{{Parent: "London", Name: "Chelsea"}}
+	sender.AddMessage(Raid, locatedMessage{Name: "raid", Lat: 51.5}, london)
+	sender.AddMessage(PokemonIV, locatedMessage{Name: "pokemon", Lat: 40}, london)
+	sender.AddMessage(Weather, "no location", nil)
+
+	expect := func(name string, sub *Subscription, want ...string) {
+		t.Helper()
+		var got []string
+		for len(sub.Events) > 0 {
+			event := <-sub.Events
+			got = append(got, event.Type+" "+string(event.Message))
+		}
+		if len(got) != len(want) {
+			t.Fatalf("%s: got %v, want %v", name, got, want)
+		}
+		for i := range want {
+			if got[i] != want[i] {
+				t.Fatalf("%s: got %v, want %v", name, got, want)
+			}
+		}
+	}
+	expect("types", raids, `raid {"name":"raid"}`)
+	expect("areas", area, `raid {"name":"raid"}`, `pokemon {"name":"pokemon"}`)
+	expect("bounds", bounded, `raid {"name":"raid"}`)
+}
+
+func TestStreamDropsWhenSubscriberIsSlow(t *testing.T) {
+	sender, err := NewWebhooksSender(webhookConfig{webhooks: []config.Webhook{}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	sub := sender.Subscribe(StreamFilter{EncodeJSON: true}, 1)
+	sender.AddMessage(Raid, "first", nil)
+	sender.AddMessage(Raid, "second", nil)
+	if dropped := sub.Dropped(); dropped != 1 {
+		t.Fatalf("dropped %d, want 1", dropped)
+	}
+	sender.Unsubscribe(sub)
+	if event := <-sub.Events; string(event.Message) != `"first"` {
+		t.Fatalf("got %s, want the first message", event.Message)
+	}
+	if _, ok := <-sub.Events; ok {
+		t.Fatal("events not closed by Unsubscribe")
+	}
+}
+
+func TestParseWebhookTypes(t *testing.T) {
+	types, err := ParseWebhookTypes([]string{"pokemon", "raid"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(types) != 3 {
+		t.Fatalf("got %v, want pokemon_iv, pokemon_no_iv and raid", types)
+	}
+	if _, err := ParseWebhookTypes([]string{"wut"}); err == nil {
+		t.Fatal("unknown type accepted")
+	}
+}
+
+func TestStreamEventCarriesValue(t *testing.T) {
+	sender, err := NewWebhooksSender(webhookConfig{webhooks: []config.Webhook{}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	sub := sender.Subscribe(StreamFilter{}, 1)
+	defer sender.Unsubscribe(sub)
+
+	message := locatedMessage{Name: "raid"}
+	sender.AddMessage(Raid, message, nil)
+	event := <-sub.Events
+	if event.WebhookType != Raid || event.Value != message {
+		t.Fatalf("got %v %v, want the raid message", event.WebhookType, event.Value)
+	}
+	if event.Message != nil {
+		t.Fatalf("json %s encoded without being asked for", event.Message)
+	}
+}
@@ -2,6 +2,8 @@ package webhooks
 
 import (
 	"bytes"
+	"compress/gzip"
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -10,6 +12,8 @@ import (
 	"io"
 	"net/http"
 	"net/url"
+	"sync"
+	"time"
 
 	log "github.com/sirupsen/logrus"
 )
@@ -29,6 +33,10 @@ const (
 	MaxBattle
 	RaidLobby
 	MaxBattleLobby
+	Tappable
+	Route
+	Station
+	Contest
 	// this magically becomes the number of types we have
 	webhookTypesLength
 )
@@ -48,6 +56,10 @@ func init() {
 	webhookTypeToPayloadType[MaxBattle] = "max_battle"
 	webhookTypeToPayloadType[RaidLobby] = "raid_lobby"
 	webhookTypeToPayloadType[MaxBattleLobby] = "max_battle_lobby"
+	webhookTypeToPayloadType[Tappable] = "tappable"
+	webhookTypeToPayloadType[Route] = "route"
+	webhookTypeToPayloadType[Station] = "station"
+	webhookTypeToPayloadType[Contest] = "contest"
 
 	// if we add more types, make sure one has added everything here
 	for _, str := range webhookTypeToPayloadType {
@@ -58,34 +70,98 @@ func init() {
 }
 
 var webhookConfigStringToType = map[string][]WebhookType{
-	"gym":           []WebhookType{GymDetails},
-	"raid":          []WebhookType{Raid},
-	"quest":         []WebhookType{Quest},
-	"pokestop":      []WebhookType{Pokestop},
-	"invasion":      []WebhookType{Invasion},
-	"weather":       []WebhookType{Weather},
-	"fort_update":   []WebhookType{FortUpdate},
-	"pokemon_iv":    []WebhookType{PokemonIV},
-	"pokemon_no_iv": []WebhookType{PokemonNoIV},
-	"pokemon":       []WebhookType{PokemonIV, PokemonNoIV},
-	"max_battle":      []WebhookType{MaxBattle},
-	"raid_lobby":      []WebhookType{RaidLobby},
+	"gym":              []WebhookType{GymDetails},
+	"raid":             []WebhookType{Raid},
+	"quest":            []WebhookType{Quest},
+	"pokestop":         []WebhookType{Pokestop},
+	"invasion":         []WebhookType{Invasion},
+	"weather":          []WebhookType{Weather},
+	"fort_update":      []WebhookType{FortUpdate},
+	"pokemon_iv":       []WebhookType{PokemonIV},
+	"pokemon_no_iv":    []WebhookType{PokemonNoIV},
+	"pokemon":          []WebhookType{PokemonIV, PokemonNoIV},
+	"max_battle":       []WebhookType{MaxBattle},
+	"raid_lobby":       []WebhookType{RaidLobby},
 	"max_battle_lobby": []WebhookType{MaxBattleLobby},
+	"tappable":         []WebhookType{Tappable},
+	"route":            []WebhookType{Route},
+	"station":          []WebhookType{Station},
+	"contest":          []WebhookType{Contest},
 }
 
 type webhook struct {
+	key              webhookKey
+	configWh         config.Webhook
 	url              string
 	areaNames        []geo.AreaName
 	excludeAreaNames []geo.AreaName
 	typesWanted      []WebhookType
 	headerMap        map[string]string
+	secret           []byte
+	filter           MessageFilter
 	httpClient       *http.Client
+
+	// interval is how often collected messages are sent, 0 for the sender's
+	// default. maxMessages and maxBytes split them into several POSTs.
+	interval    time.Duration
+	maxMessages int
+	maxBytes    int
+	gzip        bool
+
+	wanted       [webhookTypesLength]bool
+	pendingMutex sync.Mutex
+	pending      webhookCollection
+
+	// sendMutex keeps POSTs to this destination in order
+	sendMutex sync.Mutex
+	spool     *spool
+	wake      chan struct{}
+
+	status *deliveryStatus
+	// stop ends the send and retry loops, nil until they are started
+	stop context.CancelFunc
 }
 
-func (wh *webhook) getPayload(collection webhookCollection) ([]byte, error) {
+func (wh *webhook) addMessage(whType WebhookType, message webhookMessage) {
+	if !wh.wanted[whType] {
+		return
+	}
+	wh.pendingMutex.Lock()
+	wh.pending[whType].AddMessage(message)
+	wh.pendingMutex.Unlock()
+}
+
+// takePending returns the messages collected since the last call and starts
+// a new collection.
+func (wh *webhook) takePending() webhookCollection {
+	wh.pendingMutex.Lock()
+	current := wh.pending
+	wh.pending = webhookCollection{}
+	wh.pendingMutex.Unlock()
+	return current
+}
+
+// addPending adds messages collected by the destination this one replaces.
+func (wh *webhook) addPending(collection webhookCollection) {
+	wh.pendingMutex.Lock()
+	for whType := range collection {
+		wh.pending[whType].Messages = append(wh.pending[whType].Messages, collection[whType].Messages...)
+	}
+	wh.pendingMutex.Unlock()
+}
+
+// flush sends the messages collected since the last flush.
+func (wh *webhook) flush() error {
+	return wh.sendCollection(wh.takePending())
+}
+
+// getPayloads returns the messages of collection this destination wants, as
+// JSON arrays of at most maxMessages messages and maxBytes bytes. A message
+// larger than maxBytes on its own is sent alone.
+func (wh *webhook) getPayloads(collection webhookCollection) ([][]byte, error) {
 	var totalCollection []webhookMessage
 
-	if len(wh.areaNames) == 0 && len(wh.excludeAreaNames) == 0 {
+	if len(wh.areaNames) == 0 && len(wh.excludeAreaNames) == 0 && wh.filter == nil {
 		for _, whType := range wh.typesWanted {
 			totalCollection = append(
 				totalCollection,
@@ -101,6 +177,9 @@ func (wh *webhook) getPayload(collection webhookCollection) ([]byte, error) {
 				if len(wh.areaNames) > 0 && !geo.AreaMatchWithWildcards(message.Areas, wh.areaNames) {
 					continue
 				}
+				if wh.filter != nil && !wh.filter.Match(message.Message) {
+					continue
+				}
 				totalCollection = append(totalCollection, message)
 			}
 		}
@@ -112,34 +191,137 @@ func (wh *webhook) getPayload(collection webhookCollection) ([]byte, error) {
 		return nil, nil
 	}
 
-	return json.Marshal(totalCollection)
+	var payloads [][]byte
+	if (wh.maxMessages <= 0 || len(totalCollection) <= wh.maxMessages) && wh.maxBytes <= 0 {
+		payload, err := json.Marshal(totalCollection)
+		if err != nil {
+			return nil, err
+		}
+		payloads = [][]byte{payload}
+	} else {
+		var err error
+		if payloads, err = wh.chunkMessages(totalCollection); err != nil {
+			return nil, err
+		}
+	}
+
+	size := 0
+	for _, payload := range payloads {
+		size += len(payload)
+	}
+	wh.status.queued(len(totalCollection), size)
+	return payloads, nil
+}
+
+// chunkMessages marshals messages into JSON arrays within the destination's
+// message and size limits.
+func (wh *webhook) chunkMessages(messages []webhookMessage) ([][]byte, error) {
+	var payloads [][]byte
+	var chunk bytes.Buffer
+	count := 0
+	for _, message := range messages {
+		encoded, err := json.Marshal(message)
+		if err != nil {
+			return nil, err
+		}
+		if count > 0 && ((wh.maxMessages > 0 && count >= wh.maxMessages) ||
+			(wh.maxBytes > 0 && chunk.Len()+1+len(encoded)+1 > wh.maxBytes)) {
+			chunk.WriteByte(']')
+			payloads = append(payloads, bytes.Clone(chunk.Bytes()))
+			chunk.Reset()
+			count = 0
+		}
+		if count == 0 {
+			chunk.WriteByte('[')
+		} else {
+			chunk.WriteByte(',')
+		}
+		chunk.Write(encoded)
+		count++
+	}
+	chunk.WriteByte(']')
+	return append(payloads, bytes.Clone(chunk.Bytes())), nil
 }
 
 func (wh *webhook) sendCollection(collection webhookCollection) error {
-	payload, err := wh.getPayload(collection)
+	payloads, err := wh.getPayloads(collection)
 	if err != nil {
 		return fmt.Errorf("failed to generate payload: %s", err)
 	}
 
-	if payload == nil {
-		// nothing to send
-		return nil
+	var errs []error
+	for _, payload := range payloads {
+		if err := wh.deliver(payload); err != nil {
+			errs = append(errs, err)
+		}
 	}
+	return errors.Join(errs...)
+}
+
+// deliver sends payload, or spools it for the retry loop if the receiver is
+// down or earlier payloads are still waiting, so they arrive in order.
+func (wh *webhook) deliver(payload []byte) error {
+	wh.sendMutex.Lock()
+	defer wh.sendMutex.Unlock()
 
-	req, err := http.NewRequest("POST", wh.url, bytes.NewReader(payload))
+	if wh.spool.len() == 0 {
+		retryable, err := wh.post(payload)
+		if err == nil || !retryable {
+			return err
+		}
+		log.Warnf("webhooks: %s, will retry", err)
+	}
+	dropped, err := wh.spool.push(payload)
+	if err != nil {
+		return fmt.Errorf("failed to spool webhook to %s: %s", wh.url, err)
+	}
+	if dropped > 0 {
+		log.Warnf("webhooks: spool for %s is full, dropped %d oldest payloads", wh.url, dropped)
+	}
+	wh.status.spooled(wh.spool.len())
+	select {
+	case wh.wake <- struct{}{}:
+	default:
+	}
+	return nil
+}
+
+// post makes one attempt at sending payload. The error is retryable unless
+// the receiver rejected the request itself, which would fail again.
+func (wh *webhook) post(payload []byte) (retryable bool, err error) {
+	body := payload
+	if wh.gzip {
+		var compressed bytes.Buffer
+		writer := gzip.NewWriter(&compressed)
+		writer.Write(payload)
+		if err := writer.Close(); err != nil {
+			return false, fmt.Errorf("failed to compress webhook to %s: %s", wh.url, err)
+		}
+		body = compressed.Bytes()
+	}
+	req, err := http.NewRequest("POST", wh.url, bytes.NewReader(body))
 	if err != nil {
-		return fmt.Errorf("failed to create http request to %s: %s", wh.url, err)
+		return false, fmt.Errorf("failed to create http request to %s: %s", wh.url, err)
 	}
 
 	req.Header.Set("X-Golbat", "hey!")
 	req.Header.Set("Content-Type", "application/json")
+	if wh.gzip {
+		req.Header.Set("Content-Encoding", "gzip")
+	}
 
 	for key, value := range wh.headerMap {
 		req.Header.Set(key, value)
 	}
+	if wh.secret != nil {
+		req.Header.Set(SignatureHeader, SignPayload(wh.secret, time.Now(), payload))
+	}
+	start := time.Now()
 	resp, err := wh.httpClient.Do(req)
 	if err != nil {
-		return fmt.Errorf("failed to send webhook to %s: %s", wh.url, err)
+		err = fmt.Errorf("failed to send webhook to %s: %s", wh.url, err)
+		wh.status.requested("error", time.Since(start), err)
+		return true, err
 	}
 
 	defer func() {
@@ -149,7 +331,87 @@ func (wh *webhook) sendCollection(collection webhookCollection) error {
 	}()
 
 	log.Debugf("Webhook: Response %s", resp.Status)
-	return nil
+	outcome := fmt.Sprintf("%dxx", resp.StatusCode/100)
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		wh.status.requested(outcome, time.Since(start), nil)
+		return false, nil
+	}
+	retryable = resp.StatusCode >= 500 ||
+		resp.StatusCode == http.StatusRequestTimeout ||
+		resp.StatusCode == http.StatusTooManyRequests
+	err = fmt.Errorf("webhook to %s returned %s", wh.url, resp.Status)
+	wh.status.requested(outcome, time.Since(start), err)
+	return retryable, err
+}
+
+// replaySpooled sends spooled payloads oldest first until the spool is empty
+// or one fails. It reports whether the spool was emptied.
+func (wh *webhook) replaySpooled() bool {
+	for {
+		done, ok := wh.replayOldest()
+		if done || !ok {
+			return ok
+		}
+	}
+}
+
+// replayOldest sends the oldest spooled payload. The send lock is only held
+// for one payload so new collections are spooled behind the backlog rather
+// than waiting for all of it.
+func (wh *webhook) replayOldest() (empty bool, ok bool) {
+	wh.sendMutex.Lock()
+	defer wh.sendMutex.Unlock()
+
+	payload, seq, err := wh.spool.peek()
+	if err != nil {
+		// the payload was dropped, carry on with the next
+		return false, true
+	}
+	if payload == nil {
+		return true, true
+	}
+	retryable, err := wh.post(payload)
+	if err != nil {
+		if retryable {
+			log.Warnf("webhooks: retry failed, %d payloads waiting: %s", wh.spool.len(), err)
+			return false, false
+		}
+		log.Warnf("webhooks: dropping spooled payload: %s", err)
+	}
+	wh.spool.remove(seq)
+	wh.status.spooled(wh.spool.len())
+	return false, true
+}
+
+// retrySpooled replays the spool whenever it has payloads, backing off
+// exponentially from retryBase to retryMax while the receiver keeps failing.
+// This blocks until `ctx` is cancelled.
+func (wh *webhook) retrySpooled(ctx context.Context, retryBase, retryMax time.Duration) {
+	delay := retryBase
+	for {
+		if wh.spool.len() == 0 {
+			delay = retryBase
+			select {
+			case <-ctx.Done():
+				return
+			case <-wh.wake:
+			}
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+
+		if wh.replaySpooled() {
+			delay = retryBase
+		} else {
+			delay = min(delay*2, retryMax)
+		}
+	}
 }
 
 func webhookFromConfigWebhook(configWh config.Webhook) (*webhook, error) {
@@ -166,14 +428,12 @@ func webhookFromConfigWebhook(configWh config.Webhook) (*webhook, error) {
 	var typesWanted []WebhookType
 	deduped := make(map[WebhookType]bool)
 
-	for _, typeStr := range configWh.Types {
-		whTypes, ok := webhookConfigStringToType[typeStr]
-		if !ok {
-			return nil, fmt.Errorf("unknown webhook type '%s'", typeStr)
-		}
-		for _, whType := range whTypes {
-			deduped[whType] = true
-		}
+	whTypes, err := ParseWebhookTypes(configWh.Types)
+	if err != nil {
+		return nil, err
+	}
+	for _, whType := range whTypes {
+		deduped[whType] = true
 	}
 
 	// we want typesWanted to return the types in order to make testing easier
@@ -184,12 +444,46 @@ func webhookFromConfigWebhook(configWh config.Webhook) (*webhook, error) {
 		}
 	}
 
+	var filter MessageFilter
+	if len(configWh.PokemonFilters) > 0 || len(configWh.FortFilters) > 0 {
+		if filterParser == nil {
+			return nil, fmt.Errorf("webhook '%s' has filters but they are not supported", urlStr)
+		}
+		filter, err = filterParser(configWh.PokemonFilters, configWh.FortFilters)
+		if err != nil {
+			return nil, fmt.Errorf("invalid filters for webhook '%s': %s", urlStr, err)
+		}
+	}
+
+	var secret []byte
+	if configWh.Secret != "" {
+		secret = []byte(configWh.Secret)
+	}
+
+	if configWh.Interval < 0 || configWh.MaxMessages < 0 || configWh.MaxBytes < 0 {
+		return nil, fmt.Errorf("webhook '%s' has a negative interval, max_messages or max_bytes", urlStr)
+	}
+
+	var wanted [webhookTypesLength]bool
+	for _, whType := range typesWanted {
+		wanted[whType] = true
+	}
+
 	return &webhook{
+		configWh:         configWh,
 		url:              urlStr,
 		typesWanted:      typesWanted,
+		wanted:           wanted,
+		interval:         time.Duration(configWh.Interval) * time.Millisecond,
+		maxMessages:      configWh.MaxMessages,
+		maxBytes:         configWh.MaxBytes,
+		gzip:             configWh.Gzip,
 		areaNames:        configWh.AreaNames,
 		excludeAreaNames: configWh.ExcludeAreaNames,
-		httpClient:       &http.Client{},
+		filter:           filter,
+		secret:           secret,
 		headerMap:        configWh.HeaderMap,
+		wake:             make(chan struct{}, 1),
+		status:           newDeliveryStatus(urlStr),
 	}, nil
 }